@@ -0,0 +1,151 @@
+package core
+
+import "slices"
+
+// ApplyEvent never writes to the state it was given. The new state starts as a
+// shallow copy that shares every map, slice and pointer with the old one, and
+// the apply functions reach anything they change through the helpers below.
+// Each helper copies its part of the state the first time an event writes to
+// it, so untouched players and collections stay shared between versions.
+
+// writeSet records which shared parts of a state the current event has
+// already copied, so each part is copied at most once per event
+type writeSet struct {
+	playersMap  bool
+	players     map[string]bool
+	nightAction bool
+	voteState   bool
+	crisisEvent bool
+	blocked     bool
+	protected   bool
+}
+
+// mutablePlayer returns a player that is safe to modify, copying it the first
+// time the current event touches it
+func (gs *GameState) mutablePlayer(playerID string) (*Player, bool) {
+	player, exists := gs.Players[playerID]
+	if !exists {
+		return nil, false
+	}
+	if gs.writes.players[playerID] {
+		return player, true
+	}
+
+	player = player.clone()
+	gs.mutablePlayers()[playerID] = player
+	if gs.writes.players == nil {
+		gs.writes.players = make(map[string]bool)
+	}
+	gs.writes.players[playerID] = true
+	return player, true
+}
+
+// mutablePlayers returns a Players map that is safe to add to or replace
+// entries in. The players themselves are still shared.
+func (gs *GameState) mutablePlayers() map[string]*Player {
+	if !gs.writes.playersMap {
+		gs.Players = copyMap(gs.Players)
+		gs.writes.playersMap = true
+	}
+	return gs.Players
+}
+
+// mutableNightActions returns a NightActions map that is safe to modify
+func (gs *GameState) mutableNightActions() map[string]*SubmittedNightAction {
+	if !gs.writes.nightAction {
+		gs.NightActions = copyMap(gs.NightActions)
+		gs.writes.nightAction = true
+	}
+	return gs.NightActions
+}
+
+// mutableVoteState returns a copy of the current ballot that is safe to
+// modify, or nil if no vote is running
+func (gs *GameState) mutableVoteState() *VoteState {
+	if gs.VoteState == nil || gs.writes.voteState {
+		return gs.VoteState
+	}
+
+	voteState := *gs.VoteState
+	voteState.Votes = copyMap(gs.VoteState.Votes)
+	voteState.TokenWeights = copyMap(gs.VoteState.TokenWeights)
+	voteState.Results = copyMap(gs.VoteState.Results)
+	gs.VoteState = &voteState
+	gs.writes.voteState = true
+	return gs.VoteState
+}
+
+// mutableCrisisEvent returns a crisis whose effects are safe to modify,
+// creating an empty one if no crisis is active
+func (gs *GameState) mutableCrisisEvent() *CrisisEvent {
+	if gs.writes.crisisEvent {
+		return gs.CrisisEvent
+	}
+
+	crisis := CrisisEvent{}
+	if gs.CrisisEvent != nil {
+		crisis = *gs.CrisisEvent
+	}
+	crisis.Effects = copyMap(crisis.Effects)
+	gs.CrisisEvent = &crisis
+	gs.writes.crisisEvent = true
+	return gs.CrisisEvent
+}
+
+// mutableBlockedPlayers returns a BlockedPlayersTonight map that is safe to modify
+func (gs *GameState) mutableBlockedPlayers() map[string]bool {
+	if !gs.writes.blocked {
+		gs.BlockedPlayersTonight = copyMap(gs.BlockedPlayersTonight)
+		gs.writes.blocked = true
+	}
+	return gs.BlockedPlayersTonight
+}
+
+// mutableProtectedPlayers returns a ProtectedPlayersTonight map that is safe to modify
+func (gs *GameState) mutableProtectedPlayers() map[string]bool {
+	if !gs.writes.protected {
+		gs.ProtectedPlayersTonight = copyMap(gs.ProtectedPlayersTonight)
+		gs.writes.protected = true
+	}
+	return gs.ProtectedPlayersTonight
+}
+
+// clone copies a player deeply enough that no field of the copy can be
+// changed through the original
+func (p *Player) clone() *Player {
+	player := *p
+	if p.Role != nil {
+		role := *p.Role
+		if p.Role.Ability != nil {
+			ability := *p.Role.Ability
+			role.Ability = &ability
+		}
+		player.Role = &role
+	}
+	if p.PersonalKPI != nil {
+		kpi := *p.PersonalKPI
+		player.PersonalKPI = &kpi
+	}
+	if p.LastNightAction != nil {
+		action := *p.LastNightAction
+		player.LastNightAction = &action
+	}
+	// Appending a shock must not write into the original's backing array
+	player.SystemShocks = slices.Clip(p.SystemShocks)
+	return &player
+}
+
+// appendMessage adds a message without writing into capacity the old state's
+// slice may share with another version
+func appendMessage(messages []ChatMessage, message ChatMessage) []ChatMessage {
+	return append(slices.Clip(messages), message)
+}
+
+// copyMap returns a shallow copy of m that is never nil
+func copyMap[K comparable, V any](m map[K]V) map[K]V {
+	copied := make(map[K]V, len(m)+1)
+	for key, value := range m {
+		copied[key] = value
+	}
+	return copied
+}
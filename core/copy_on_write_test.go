@@ -0,0 +1,222 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// newSharedState builds a mid-game state with something in every collection
+// an event can write to, and spare capacity in its slices
+func newSharedState() *GameState {
+	state := NewGameState("test-game")
+	state.DayNumber = 2
+	state.Phase = Phase{Type: PhaseNight, StartTime: time.Now()}
+	for _, id := range []string{"alice", "bob", "carol"} {
+		state.Players[id] = &Player{
+			ID:              id,
+			Name:            id,
+			IsAlive:         true,
+			Tokens:          2,
+			Alignment:       "HUMAN",
+			Role:            &Role{Type: RoleCTO, Ability: &Ability{Name: "Overclock"}},
+			PersonalKPI:     &PersonalKPI{Type: KPICapitalist, Target: 3},
+			LastNightAction: &NightAction{Type: ActionMine, TargetID: "bob"},
+			HasUsedAbility:  true,
+			SystemShocks:    make([]SystemShock, 0, 4),
+		}
+	}
+	state.ChatMessages = make([]ChatMessage, 1, 8)
+	state.FactionChatMessages = make([]ChatMessage, 1, 8)
+	state.VoteState = &VoteState{
+		Type:         VoteNomination,
+		Votes:        map[string]string{"alice": "bob"},
+		TokenWeights: map[string]int{"alice": 2},
+		Results:      map[string]int{"bob": 2},
+	}
+	state.CrisisEvent = &CrisisEvent{
+		Type:    "Press Leak",
+		Effects: map[string]interface{}{"pulse_responses": map[string]interface{}{"alice": "yes"}},
+	}
+	state.NightActions = map[string]*SubmittedNightAction{"alice": {PlayerID: "alice", Type: "MINE"}}
+	state.BlockedPlayersTonight = map[string]bool{"carol": true}
+	state.ProtectedPlayersTonight = map[string]bool{"carol": true}
+	return state
+}
+
+// fingerprint captures everything about a state, including the unserialized night maps
+func fingerprint(t *testing.T, state *GameState) string {
+	t.Helper()
+	data, err := json.Marshal(state)
+	if err != nil {
+		t.Fatalf("Failed to marshal state: %v", err)
+	}
+	return fmt.Sprintf("%s %v %v", data, state.BlockedPlayersTonight, state.ProtectedPlayersTonight)
+}
+
+func TestApplyEvent_LeavesInputUnchanged(t *testing.T) {
+	events := []Event{
+		{Type: EventPlayerJoined, PlayerID: "dave", Payload: EncodePayload(PlayerJoinedPayload{Name: "Dave"})},
+		{Type: EventVoteCast, PlayerID: "bob", Payload: EncodePayload(VotePayload{TargetID: "carol", VoteType: VoteNomination})},
+		{Type: EventVoteCompleted, Payload: EncodePayload(VoteCompletedPayload{VoteType: VoteNomination})},
+		{Type: EventTokensAwarded, PlayerID: "alice", Payload: EncodePayload(TokensPayload{Amount: 3})},
+		{Type: EventPlayerEliminated, PlayerID: "bob", Payload: EncodePayload(PlayerEliminatedPayload{RoleType: RoleCISO, Alignment: "ALIGNED"})},
+		{Type: EventChatMessage, PlayerID: "alice", Payload: EncodePayload(ChatMessagePayload{PlayerName: "alice", Message: "hi"})},
+		{Type: EventFactionMessage, PlayerID: "alice", Payload: EncodePayload(ChatMessagePayload{PlayerName: "alice", Message: "hi"})},
+		{Type: EventAIConversionSuccess, PlayerID: "carol"},
+		{Type: EventNightActionSubmitted, PlayerID: "bob", Payload: EncodePayload(NightActionSubmittedPayload{ActionType: "MINE", TargetID: "alice"})},
+		{Type: EventNightActionsResolved, Payload: EncodePayload(NightActionsResolvedPayload{})},
+		{Type: EventPlayerBlocked, PlayerID: "alice", Payload: EncodePayload(PlayerBlockedPayload{BlockedBy: "bob"})},
+		{Type: EventPlayerProtected, PlayerID: "alice", Payload: EncodePayload(PlayerProtectedPayload{ProtectedBy: "bob"})},
+		{Type: EventIsolateNode, PlayerID: "bob", Payload: EncodePayload(AbilityTargetPayload{TargetID: "carol", Fizzled: true})},
+		{Type: EventPerformanceReview, PlayerID: "bob", Payload: EncodePayload(PerformanceReviewPayload{TargetID: "alice", ForcedAction: "PROJECT_MILESTONES"})},
+		{Type: EventPulseCheckSubmitted, PlayerID: "bob", Payload: EncodePayload(PulseCheckSubmittedPayload{Response: "no"})},
+		{Type: EventPivot, PlayerID: "bob", Payload: EncodePayload(PivotPayload{SelectedCrisis: "Press Leak"})},
+		{Type: EventSystemShockApplied, PlayerID: "alice", Payload: EncodePayload(SystemShockAppliedPayload{ShockType: ShockActionLock, DurationHours: 1})},
+		{Type: EventMandateActivated, Payload: EncodePayload(MandateActivatedPayload{MandateType: MandateAggressiveGrowth, StartingTokensModifier: 1})},
+		{Type: EventRoleAbilityUnlocked, PlayerID: "alice", Payload: EncodePayload(RoleAbilityUnlockedPayload{AbilityName: "Audit"})},
+		{Type: EventKPIProgress, PlayerID: "alice", Payload: EncodePayload(KPIProgressPayload{Progress: 2})},
+	}
+
+	for _, event := range events {
+		t.Run(string(event.Type), func(t *testing.T) {
+			state := newSharedState()
+			before := fingerprint(t, state)
+
+			event.Timestamp = time.Now()
+			newState := ApplyEvent(*state, event)
+
+			if after := fingerprint(t, state); after != before {
+				t.Errorf("Applying %s modified the input state\nbefore: %s\nafter:  %s", event.Type, before, after)
+			}
+			if fingerprint(t, &newState) == before {
+				t.Errorf("Expected %s to change the new state", event.Type)
+			}
+		})
+	}
+}
+
+func TestApplyEvent_BranchesAreIndependent(t *testing.T) {
+	base := *newSharedState()
+	message := func(text string) Event {
+		return Event{ID: text, Type: EventChatMessage, PlayerID: "alice", Timestamp: time.Now(), Payload: EncodePayload(ChatMessagePayload{PlayerName: "alice", Message: text})}
+	}
+
+	// Both branches append to the same slice, which has room to spare
+	left := ApplyEvent(base, message("left"))
+	right := ApplyEvent(base, message("right"))
+
+	if got := left.ChatMessages[len(left.ChatMessages)-1].Message; got != "left" {
+		t.Errorf("Expected the left branch to keep its own message, got %q", got)
+	}
+	if got := right.ChatMessages[len(right.ChatMessages)-1].Message; got != "right" {
+		t.Errorf("Expected the right branch to keep its own message, got %q", got)
+	}
+	if len(base.ChatMessages) != 1 {
+		t.Errorf("Expected the base to keep 1 message, got %d", len(base.ChatMessages))
+	}
+}
+
+func TestApplyEvent_SharesUntouchedPlayers(t *testing.T) {
+	base := *newSharedState()
+	newState := ApplyEvent(base, Event{
+		Type:      EventTokensAwarded,
+		PlayerID:  "alice",
+		Timestamp: time.Now(),
+		Payload:   EncodePayload(TokensPayload{Amount: 1}),
+	})
+
+	if newState.Players["alice"] == base.Players["alice"] {
+		t.Error("Expected the awarded player to be copied")
+	}
+	if newState.Players["bob"] != base.Players["bob"] {
+		t.Error("Expected untouched players to be shared with the previous state")
+	}
+	if newState.Players["alice"].Tokens != 3 || base.Players["alice"].Tokens != 2 {
+		t.Errorf("Expected 2 tokens before and 3 after, got %d and %d", base.Players["alice"].Tokens, newState.Players["alice"].Tokens)
+	}
+}
+
+// benchmarkGame builds a 10-player game of 2000 events: each round is a day
+// of chat and voting followed by a night of actions and their resolution
+func benchmarkGame() (GameState, []Event) {
+	state := NewGameState("bench-game")
+	players := make([]string, 10)
+	for i := range players {
+		players[i] = fmt.Sprintf("player-%d", i)
+		state.Players[players[i]] = &Player{
+			ID:      players[i],
+			Name:    players[i],
+			IsAlive: true,
+			Tokens:  1,
+			Role:    &Role{Type: RoleIntern},
+		}
+	}
+
+	now := time.Now()
+	events := make([]Event, 0, 2000)
+	add := func(eventType EventType, playerID string, payload interface{}) {
+		event := Event{
+			ID:        fmt.Sprintf("event-%d", len(events)),
+			Type:      eventType,
+			PlayerID:  playerID,
+			Timestamp: now.Add(time.Duration(len(events)) * time.Second),
+		}
+		if payload != nil {
+			event.Payload = EncodePayload(payload)
+		}
+		events = append(events, event)
+	}
+
+	for len(events) < 2000 {
+		add(EventPhaseChanged, "", PhaseChangedPayload{PhaseType: PhaseDiscussion})
+		for _, id := range players {
+			add(EventChatMessage, id, ChatMessagePayload{PlayerName: id, Message: "I am definitely human"})
+		}
+		add(EventVoteStarted, "", VotePayload{VoteType: VoteNomination})
+		for i, id := range players {
+			add(EventVoteCast, id, VotePayload{TargetID: players[(i+1)%len(players)], VoteType: VoteNomination})
+		}
+		add(EventVoteCompleted, "", VoteCompletedPayload{VoteType: VoteNomination})
+		add(EventPhaseChanged, "", PhaseChangedPayload{PhaseType: PhaseNight})
+		for i, id := range players {
+			add(EventNightActionSubmitted, id, NightActionSubmittedPayload{ActionType: "MINE", TargetID: players[(i+1)%len(players)]})
+		}
+		for _, id := range players {
+			add(EventTokensAwarded, id, TokensPayload{Amount: 1})
+		}
+		add(EventNightActionsResolved, "", NightActionsResolvedPayload{})
+	}
+	return *state, events[:2000]
+}
+
+// BenchmarkApplyEvent_Game measures folding a whole game into its final state
+func BenchmarkApplyEvent_Game(b *testing.B) {
+	initial, events := benchmarkGame()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		state := initial
+		for _, event := range events {
+			state = ApplyEvent(state, event)
+		}
+	}
+}
+
+// BenchmarkApplyEvent_GameHistory measures keeping every intermediate state,
+// which structural sharing makes affordable for undo and diffing
+func BenchmarkApplyEvent_GameHistory(b *testing.B) {
+	initial, events := benchmarkGame()
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		history := make([]GameState, 0, len(events)+1)
+		history = append(history, initial)
+		for _, event := range events {
+			history = append(history, ApplyEvent(history[len(history)-1], event))
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrorCode is the machine-readable reason an action was rejected. Clients
+// switch on the code; the message is for display only and may change.
+type ErrorCode string
+
+const (
+	CodeInvalidAction      ErrorCode = "INVALID_ACTION"      // Any rejection without a more specific code
+	CodeWrongPhase         ErrorCode = "WRONG_PHASE"         // The action is not allowed in the current phase
+	CodePlayerNotFound     ErrorCode = "PLAYER_NOT_FOUND"    // The acting player is not in the game
+	CodePlayerEliminated   ErrorCode = "PLAYER_ELIMINATED"   // The acting player has been deactivated
+	CodeInvalidTarget      ErrorCode = "INVALID_TARGET"      // The target is unknown, eliminated or not allowed
+	CodeInvalidValue       ErrorCode = "INVALID_VALUE"       // A payload field has a value outside its domain
+	CodeNoActiveVote       ErrorCode = "NO_ACTIVE_VOTE"      // No ballot is open
+	CodeAbilityLocked      ErrorCode = "ABILITY_LOCKED"      // The player's role ability is not unlocked
+	CodeAbilityUsed        ErrorCode = "ABILITY_USED"        // The ability has already been used this night
+	CodeActionBlocked      ErrorCode = "ACTION_BLOCKED"      // A system shock, crisis or mandate forbids the action
+	CodeInsufficientTokens ErrorCode = "INSUFFICIENT_TOKENS" // Not enough tokens for the action
+	CodeGameFull           ErrorCode = "GAME_FULL"           // The game or lobby has no free seat
+	CodeAlreadyJoined      ErrorCode = "ALREADY_JOINED"      // The player already has a seat
+	CodeNotEnoughPlayers   ErrorCode = "NOT_ENOUGH_PLAYERS"  // Too few players to start the game
+	CodeUnknownAction      ErrorCode = "UNKNOWN_ACTION"      // The server does not handle this action type
+	CodeNotHost            ErrorCode = "NOT_HOST"            // Only the lobby host may do this
+	CodePlayerKicked       ErrorCode = "PLAYER_KICKED"       // The host removed the player from the lobby
+	CodePlayersNotReady    ErrorCode = "PLAYERS_NOT_READY"   // Not every player in the lobby is ready
+)
+
+// ActionError is a rule violation that makes the server refuse an action.
+// Error returns only the message, so wrapping a plain error in an
+// ActionError does not change what is logged.
+type ActionError struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+	Field   string    `json:"field,omitempty"` // Offending payload field, e.g. "target_id"
+}
+
+func (e *ActionError) Error() string {
+	return e.Message
+}
+
+// ActionErrorf creates an ActionError with a formatted message. field may be
+// empty when no single payload field is at fault.
+func ActionErrorf(code ErrorCode, field, format string, args ...interface{}) *ActionError {
+	return &ActionError{
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+		Field:   field,
+	}
+}
+
+// AsActionError returns the ActionError in err's chain. Any other error
+// becomes an INVALID_ACTION carrying err's message.
+func AsActionError(err error) *ActionError {
+	var actionErr *ActionError
+	if errors.As(err, &actionErr) {
+		return actionErr
+	}
+	return &ActionError{Code: CodeInvalidAction, Message: err.Error()}
+}
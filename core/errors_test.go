@@ -0,0 +1,30 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// TestAsActionError tests that typed errors survive wrapping and that plain
+// errors fall back to INVALID_ACTION
+func TestAsActionError(t *testing.T) {
+	typed := ActionErrorf(CodeInvalidTarget, "target_id", "target player %s not found", "p-9")
+	if typed.Error() != "target player p-9 not found" {
+		t.Errorf("Expected Error to return the message, got %q", typed.Error())
+	}
+
+	wrapped := fmt.Errorf("failed to use role ability: %w", typed)
+	if got := AsActionError(wrapped); got != typed {
+		t.Errorf("Expected the wrapped ActionError, got %+v", got)
+	}
+
+	plain := AsActionError(errors.New("something broke"))
+	if plain.Code != CodeInvalidAction || plain.Message != "something broke" || plain.Field != "" {
+		t.Errorf("Expected an INVALID_ACTION fallback, got %+v", plain)
+	}
+
+	if ClassifyEvent(Event{Type: EventActionRejected, PlayerID: "p-1"}) != VisibilityPrivate {
+		t.Error("Expected ACTION_REJECTED to be private")
+	}
+}
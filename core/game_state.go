@@ -26,9 +26,22 @@ type GameState struct {
 	// Daily tracking
 	PulseCheckResponses map[string]string `json:"pulse_check_responses,omitempty"`
 
-	// Temporary fields for night resolution (cleared each night)
-	BlockedPlayersTonight   map[string]bool `json:"-"` // Not serialized
-	ProtectedPlayersTonight map[string]bool `json:"-"` // Not serialized
+	// AI faction channel, visible only to ALIGNED players
+	FactionChatMessages []ChatMessage `json:"faction_chat_messages,omitempty"`
+
+	// Number of events folded into this state; snapshots resume replay from here
+	EventCount int `json:"event_count"`
+
+	// Every random draw in the game is derived from this seed; see Rand
+	Seed int64 `json:"seed,string"`
+
+	// Temporary fields for night resolution (cleared each night). Saved with
+	// snapshots so a game restored mid-night keeps its blocks and protections.
+	BlockedPlayersTonight   map[string]bool `json:"blocked_players_tonight,omitempty"`
+	ProtectedPlayersTonight map[string]bool `json:"protected_players_tonight,omitempty"`
+
+	// Parts of the state copied by the event being applied; empty between events
+	writes writeSet
 }
 
 // NewGameState creates a new game state
@@ -60,13 +73,19 @@ func NewGameState(id string) *GameState {
 	}
 }
 
-// ApplyEvent applies an event to the game state and returns a new state
+// ApplyEvent applies an event to the game state and returns a new state.
+// currentState is left unchanged: the new state shares whatever the event did
+// not touch and holds its own copies of the rest.
 func ApplyEvent(currentState GameState, event Event) GameState {
 	newState := currentState
+	newState.writes = writeSet{}
 	newState.UpdatedAt = event.Timestamp
+	newState.EventCount++
 
 	switch event.Type {
 	// Game lifecycle events
+	case EventGameCreated:
+		newState.applyGameCreated(event)
 	case EventGameStarted:
 		newState.applyGameStarted(event)
 	case EventGameEnded:
@@ -104,6 +123,10 @@ func ApplyEvent(currentState GameState, event Event) GameState {
 	case EventProjectMilestone:
 		newState.applyProjectMilestone(event)
 
+	// Corporate mandate events
+	case EventMandateActivated:
+		newState.applyMandateActivated(event)
+
 	// Voting events
 	case EventVoteCast:
 		newState.applyVoteCast(event)
@@ -151,6 +174,8 @@ func ApplyEvent(currentState GameState, event Event) GameState {
 	// Communication events
 	case EventChatMessage:
 		newState.applyChatMessage(event)
+	case EventFactionMessage:
+		newState.applyFactionMessage(event)
 	case EventSystemMessage:
 		newState.applySystemMessage(event)
 	case EventPrivateNotification:
@@ -210,9 +235,18 @@ func ApplyEvent(currentState GameState, event Event) GameState {
 		// Unknown event type - ignore
 	}
 
+	newState.writes = writeSet{}
 	return newState
 }
 
+func (gs *GameState) applyGameCreated(event Event) {
+	payload, err := DecodePayload[GameCreatedPayload](event)
+	if err != nil {
+		return
+	}
+	gs.Seed = payload.Seed
+}
+
 func (gs *GameState) applyGameStarted(event Event) {
 	gs.Phase = Phase{
 		Type:      PhaseSitrep,
@@ -224,13 +258,12 @@ func (gs *GameState) applyGameStarted(event Event) {
 
 func (gs *GameState) applyPlayerJoined(event Event) {
 	playerID := event.PlayerID
-	name, _ := event.Payload["name"].(string)
-	jobTitle, _ := event.Payload["job_title"].(string)
+	payload, _ := DecodePayload[PlayerJoinedPayload](event)
 
-	gs.Players[playerID] = &Player{
+	gs.mutablePlayers()[playerID] = &Player{
 		ID:                playerID,
-		Name:              name,
-		JobTitle:          jobTitle,
+		Name:              payload.Name,
+		JobTitle:          payload.JobTitle,
 		IsAlive:           true,
 		Tokens:            gs.Settings.StartingTokens,
 		ProjectMilestones: 0,
@@ -241,130 +274,143 @@ func (gs *GameState) applyPlayerJoined(event Event) {
 }
 
 func (gs *GameState) applyPlayerLeft(event Event) {
-	if player, exists := gs.Players[event.PlayerID]; exists {
+	if player, exists := gs.mutablePlayer(event.PlayerID); exists {
 		player.IsAlive = false
 	}
 }
 
 func (gs *GameState) applyPhaseChanged(event Event) {
-	newPhaseType, _ := event.Payload["phase_type"].(string)
-	duration, _ := event.Payload["duration"].(float64)
+	payload, err := DecodePayload[PhaseChangedPayload](event)
+	if err != nil {
+		return
+	}
 
 	gs.Phase = Phase{
-		Type:      PhaseType(newPhaseType),
+		Type:      payload.PhaseType,
 		StartTime: event.Timestamp,
-		Duration:  time.Duration(duration) * time.Second,
+		Duration:  time.Duration(payload.Duration) * time.Second,
 	}
 
 	// Increment day number when transitioning to SITREP
-	if PhaseType(newPhaseType) == PhaseSitrep {
+	if payload.PhaseType == PhaseSitrep {
 		gs.DayNumber++
 	}
 }
 
 func (gs *GameState) applyVoteCast(event Event) {
 	playerID := event.PlayerID
-	targetID, _ := event.Payload["target_id"].(string)
-	voteType, _ := event.Payload["vote_type"].(string)
+	payload, err := DecodePayload[VotePayload](event)
+	if err != nil {
+		return
+	}
 
 	// Initialize vote state if needed
 	if gs.VoteState == nil {
 		gs.VoteState = &VoteState{
-			Type:         VoteType(voteType),
+			Type:         payload.VoteType,
 			Votes:        make(map[string]string),
 			TokenWeights: make(map[string]int),
 			Results:      make(map[string]int),
 			IsComplete:   false,
 		}
 	}
+	voteState := gs.mutableVoteState()
 
 	// Record the vote
-	gs.VoteState.Votes[playerID] = targetID
+	voteState.Votes[playerID] = payload.TargetID
 
 	// Update token weights
 	if player, exists := gs.Players[playerID]; exists {
-		gs.VoteState.TokenWeights[playerID] = player.Tokens
+		voteState.TokenWeights[playerID] = player.Tokens
 	}
 
 	// Recalculate results
-	gs.VoteState.Results = make(map[string]int)
-	for voterID, candidateID := range gs.VoteState.Votes {
-		if tokens, exists := gs.VoteState.TokenWeights[voterID]; exists {
-			gs.VoteState.Results[candidateID] += tokens
+	voteState.Results = make(map[string]int)
+	for voterID, candidateID := range voteState.Votes {
+		if tokens, exists := voteState.TokenWeights[voterID]; exists {
+			voteState.Results[candidateID] += tokens
 		}
 	}
 }
 
 func (gs *GameState) applyTokensAwarded(event Event) {
 	playerID := event.PlayerID
-	amount, _ := event.Payload["amount"].(float64)
+	payload, err := DecodePayload[TokensPayload](event)
+	if err != nil {
+		return
+	}
 
-	if player, exists := gs.Players[playerID]; exists {
-		player.Tokens += int(amount)
+	if player, exists := gs.mutablePlayer(playerID); exists {
+		player.Tokens += payload.Amount
 	}
 }
 
 func (gs *GameState) applyMiningSuccessful(event Event) {
 	playerID := event.PlayerID
+	payload, err := DecodePayload[MiningSuccessfulPayload](event)
+	if err != nil {
+		return
+	}
 
-	// Handle both int and float64 amount values
-	var amount int
-	if amountInt, ok := event.Payload["amount"].(int); ok {
-		amount = amountInt
-	} else if amountFloat, ok := event.Payload["amount"].(float64); ok {
-		amount = int(amountFloat)
-	} else {
-		amount = 1 // Default amount
+	amount := 1 // Default amount
+	if payload.Amount != nil {
+		amount = *payload.Amount
 	}
 
-	if player, exists := gs.Players[playerID]; exists {
+	if player, exists := gs.mutablePlayer(playerID); exists {
 		player.Tokens += amount
 	}
 }
 
 func (gs *GameState) applyPlayerEliminated(event Event) {
 	playerID := event.PlayerID
-	roleType, _ := event.Payload["role_type"].(string)
-	alignment, _ := event.Payload["alignment"].(string)
+	payload, _ := DecodePayload[PlayerEliminatedPayload](event)
 
-	if player, exists := gs.Players[playerID]; exists {
+	if player, exists := gs.mutablePlayer(playerID); exists {
 		player.IsAlive = false
 		// Reveal role and alignment on elimination
 		if player.Role == nil {
 			player.Role = &Role{}
 		}
-		player.Role.Type = RoleType(roleType)
-		player.Alignment = alignment
+		player.Role.Type = payload.RoleType
+		player.Alignment = payload.Alignment
 	}
 }
 
-func (gs *GameState) applyChatMessage(event Event) {
+func (gs *GameState) applyFactionMessage(event Event) {
+	payload, _ := DecodePayload[ChatMessagePayload](event)
+
 	message := ChatMessage{
 		ID:         event.ID,
 		PlayerID:   event.PlayerID,
-		PlayerName: "",
-		Message:    "",
+		PlayerName: payload.PlayerName,
+		Message:    payload.Message,
 		Timestamp:  event.Timestamp,
 		IsSystem:   false,
 	}
 
-	if playerName, ok := event.Payload["player_name"].(string); ok {
-		message.PlayerName = playerName
-	}
-	if messageText, ok := event.Payload["message"].(string); ok {
-		message.Message = messageText
-	}
-	if isSystem, ok := event.Payload["is_system"].(bool); ok {
-		message.IsSystem = isSystem
+	gs.FactionChatMessages = appendMessage(gs.FactionChatMessages, message)
+}
+
+func (gs *GameState) applyChatMessage(event Event) {
+	payload, _ := DecodePayload[ChatMessagePayload](event)
+
+	message := ChatMessage{
+		ID:         event.ID,
+		PlayerID:   event.PlayerID,
+		PlayerName: payload.PlayerName,
+		Message:    payload.Message,
+		Timestamp:  event.Timestamp,
+		IsSystem:   payload.IsSystem,
 	}
 
-	gs.ChatMessages = append(gs.ChatMessages, message)
+	gs.ChatMessages = appendMessage(gs.ChatMessages, message)
 }
 
 func (gs *GameState) applyPlayerAligned(event Event) {
 	playerID := event.PlayerID
 
-	if player, exists := gs.Players[playerID]; exists {
+	if player, exists := gs.mutablePlayer(playerID); exists {
 		player.Alignment = "ALIGNED"
 		// Reset any shock effects
 		player.StatusMessage = ""
@@ -373,37 +419,35 @@ func (gs *GameState) applyPlayerAligned(event Event) {
 
 func (gs *GameState) applyPlayerShocked(event Event) {
 	playerID := event.PlayerID
-	shockMessage, _ := event.Payload["shock_message"].(string)
+	payload, _ := DecodePayload[PlayerShockedPayload](event)
 
-	if player, exists := gs.Players[playerID]; exists {
-		player.StatusMessage = shockMessage
+	if player, exists := gs.mutablePlayer(playerID); exists {
+		player.StatusMessage = payload.ShockMessage
 		// System shock indicates failed conversion (proves humanity)
 	}
 }
 
 func (gs *GameState) applyCrisisTriggered(event Event) {
-	crisisType, _ := event.Payload["crisis_type"].(string)
-	title, _ := event.Payload["title"].(string)
-	description, _ := event.Payload["description"].(string)
-	effects, _ := event.Payload["effects"].(map[string]interface{})
+	payload, err := DecodePayload[CrisisTriggeredPayload](event)
+	if err != nil {
+		return
+	}
 
 	gs.CrisisEvent = &CrisisEvent{
-		Type:        crisisType,
-		Title:       title,
-		Description: description,
-		Effects:     effects,
+		Type:        payload.CrisisType,
+		Title:       payload.Title,
+		Description: payload.Description,
+		Effects:     payload.Effects,
 	}
 }
 
 func (gs *GameState) applyVictoryCondition(event Event) {
-	winner, _ := event.Payload["winner"].(string)
-	condition, _ := event.Payload["condition"].(string)
-	description, _ := event.Payload["description"].(string)
+	payload, _ := DecodePayload[VictoryConditionPayload](event)
 
 	gs.WinCondition = &WinCondition{
-		Winner:      winner,
-		Condition:   condition,
-		Description: description,
+		Winner:      payload.Winner,
+		Condition:   payload.Condition,
+		Description: payload.Description,
 	}
 
 	// End the game
@@ -425,8 +469,11 @@ func (gs *GameState) applyGameEnded(event Event) {
 }
 
 func (gs *GameState) applyDayStarted(event Event) {
-	dayNumber, _ := event.Payload["day_number"].(float64)
-	gs.DayNumber = int(dayNumber)
+	payload, err := DecodePayload[DayStartedPayload](event)
+	if err != nil {
+		return
+	}
+	gs.DayNumber = payload.DayNumber
 
 	gs.Phase = Phase{
 		Type:      PhaseSitrep,
@@ -445,65 +492,72 @@ func (gs *GameState) applyNightStarted(event Event) {
 
 func (gs *GameState) applyPlayerStatusChanged(event Event) {
 	playerID := event.PlayerID
-	newStatus, _ := event.Payload["status"].(string)
+	payload, err := DecodePayload[StatusPayload](event)
+	if err != nil {
+		return
+	}
 
-	if player, exists := gs.Players[playerID]; exists {
-		player.StatusMessage = newStatus
+	if player, exists := gs.mutablePlayer(playerID); exists {
+		player.StatusMessage = payload.Status
 	}
 }
 
 func (gs *GameState) applyPlayerReconnected(event Event) {
-	// Player reconnection doesn't change game state directly
-	// but could be used for analytics or notifications
+	if player, exists := gs.mutablePlayer(event.PlayerID); exists {
+		player.IsAway = false
+	}
 }
 
 func (gs *GameState) applyPlayerDisconnected(event Event) {
-	// Player disconnection doesn't change game state directly
-	// but could be used for analytics or notifications
+	if player, exists := gs.mutablePlayer(event.PlayerID); exists {
+		player.IsAway = true
+	}
 }
 
 func (gs *GameState) applyRoleAssigned(event Event) {
 	playerID := event.PlayerID
-	roleType, _ := event.Payload["role_type"].(string)
-	roleName, _ := event.Payload["role_name"].(string)
-	roleDescription, _ := event.Payload["role_description"].(string)
-	kpiType, _ := event.Payload["kpi_type"].(string)
-	kpiDescription, _ := event.Payload["kpi_description"].(string)
-	alignment, _ := event.Payload["alignment"].(string)
+	payload, err := DecodePayload[RoleAssignedPayload](event)
+	if err != nil {
+		return
+	}
 
-	if player, exists := gs.Players[playerID]; exists {
+	if player, exists := gs.mutablePlayer(playerID); exists {
 		player.Role = &Role{
-			Type:        RoleType(roleType),
-			Name:        roleName,
-			Description: roleDescription,
+			Type:        payload.RoleType,
+			Name:        payload.RoleName,
+			Description: payload.RoleDescription,
 			IsUnlocked:  false,
 		}
 
-		if kpiType != "" {
+		if payload.KPIType != "" {
+			target := payload.KPITarget
+			if target == 0 {
+				target = 1 // Default target
+			}
 			player.PersonalKPI = &PersonalKPI{
-				Type:        KPIType(kpiType),
-				Description: kpiDescription,
+				Type:        payload.KPIType,
+				Description: payload.KPIDescription,
 				Progress:    0,
-				Target:      1, // Default target
+				Target:      target,
 				IsCompleted: false,
+				Reward:      payload.KPIReward,
 			}
 		}
 
-		player.Alignment = alignment
+		player.Alignment = payload.Alignment
 	}
 }
 
 func (gs *GameState) applyRoleAbilityUnlocked(event Event) {
 	playerID := event.PlayerID
-	abilityName, _ := event.Payload["ability_name"].(string)
-	abilityDescription, _ := event.Payload["ability_description"].(string)
+	payload, _ := DecodePayload[RoleAbilityUnlockedPayload](event)
 
-	if player, exists := gs.Players[playerID]; exists {
+	if player, exists := gs.mutablePlayer(playerID); exists {
 		if player.Role != nil {
 			player.Role.IsUnlocked = true
 			player.Role.Ability = &Ability{
-				Name:        abilityName,
-				Description: abilityDescription,
+				Name:        payload.AbilityName,
+				Description: payload.AbilityDescription,
 				IsReady:     true,
 			}
 		}
@@ -512,10 +566,13 @@ func (gs *GameState) applyRoleAbilityUnlocked(event Event) {
 
 func (gs *GameState) applyProjectMilestone(event Event) {
 	playerID := event.PlayerID
-	milestone, _ := event.Payload["milestone"].(float64)
+	payload, err := DecodePayload[ProjectMilestonePayload](event)
+	if err != nil {
+		return
+	}
 
-	if player, exists := gs.Players[playerID]; exists {
-		player.ProjectMilestones = int(milestone)
+	if player, exists := gs.mutablePlayer(playerID); exists {
+		player.ProjectMilestones = payload.Milestone
 
 		// Unlock role ability at 3 milestones
 		if player.ProjectMilestones >= 3 && player.Role != nil && !player.Role.IsUnlocked {
@@ -527,35 +584,75 @@ func (gs *GameState) applyProjectMilestone(event Event) {
 	}
 }
 
+func (gs *GameState) applyMandateActivated(event Event) {
+	payload, err := DecodePayload[MandateActivatedPayload](event)
+	if err != nil {
+		return
+	}
+
+	gs.CorporateMandate = &CorporateMandate{
+		Type:        payload.MandateType,
+		Name:        payload.Name,
+		Description: payload.Description,
+		Effects:     payload.Effects,
+		IsActive:    true,
+	}
+	if gs.CorporateMandate.Effects == nil {
+		gs.CorporateMandate.Effects = make(map[string]interface{})
+	}
+
+	// Starting token changes apply to later joins, and to everyone already
+	// playing when the mandate is announced at the start of the game
+	if payload.StartingTokensModifier != 0 {
+		gs.Settings.StartingTokens += payload.StartingTokensModifier
+		if gs.DayNumber <= 1 {
+			for playerID, player := range gs.Players {
+				if player.IsAlive {
+					player, _ = gs.mutablePlayer(playerID)
+					player.Tokens += payload.StartingTokensModifier
+				}
+			}
+		}
+	}
+}
+
 func (gs *GameState) applyVoteStarted(event Event) {
-	voteType, _ := event.Payload["vote_type"].(string)
+	payload, _ := DecodePayload[VotePayload](event)
 
 	gs.VoteState = &VoteState{
-		Type:         VoteType(voteType),
+		Type:         payload.VoteType,
 		Votes:        make(map[string]string),
 		TokenWeights: make(map[string]int),
 		Results:      make(map[string]int),
 		IsComplete:   false,
 	}
+
+	// A new nomination replaces yesterday's nominee
+	if payload.VoteType == VoteNomination {
+		gs.NominatedPlayer = ""
+	}
 }
 
 func (gs *GameState) applyVoteCompleted(event Event) {
-	if gs.VoteState != nil {
-		gs.VoteState.IsComplete = true
+	if voteState := gs.mutableVoteState(); voteState != nil {
+		voteState.IsComplete = true
 	}
 }
 
 func (gs *GameState) applyPlayerNominated(event Event) {
-	nominatedPlayerID, _ := event.Payload["nominated_player"].(string)
-	gs.NominatedPlayer = nominatedPlayerID
+	payload, _ := DecodePayload[PlayerNominatedPayload](event)
+	gs.NominatedPlayer = payload.NominatedPlayer
 }
 
 func (gs *GameState) applyTokensLost(event Event) {
 	playerID := event.PlayerID
-	amount, _ := event.Payload["amount"].(float64)
+	payload, err := DecodePayload[TokensPayload](event)
+	if err != nil {
+		return
+	}
 
-	if player, exists := gs.Players[playerID]; exists {
-		player.Tokens -= int(amount)
+	if player, exists := gs.mutablePlayer(playerID); exists {
+		player.Tokens -= payload.Amount
 		if player.Tokens < 0 {
 			player.Tokens = 0
 		}
@@ -564,11 +661,11 @@ func (gs *GameState) applyTokensLost(event Event) {
 
 func (gs *GameState) applyMiningFailed(event Event) {
 	playerID := event.PlayerID
-	reason, _ := event.Payload["reason"].(string)
+	payload, _ := DecodePayload[MiningFailedPayload](event)
 
-	if player, exists := gs.Players[playerID]; exists {
-		if reason != "" {
-			player.StatusMessage = "Mining failed: " + reason
+	if player, exists := gs.mutablePlayer(playerID); exists {
+		if payload.Reason != "" {
+			player.StatusMessage = "Mining failed: " + payload.Reason
 		} else {
 			player.StatusMessage = "Mining attempt failed"
 		}
@@ -577,115 +674,101 @@ func (gs *GameState) applyMiningFailed(event Event) {
 
 func (gs *GameState) applyMiningPoolUpdated(event Event) {
 	// Update mining pool difficulty or rewards
-	newDifficulty, hasDifficulty := event.Payload["difficulty"].(float64)
-	newBaseReward, hasReward := event.Payload["base_reward"].(float64)
+	payload, err := DecodePayload[MiningPoolUpdatedPayload](event)
+	if err != nil {
+		return
+	}
 
 	// Store mining pool state in crisis event effects for now
-	if gs.CrisisEvent == nil {
-		gs.CrisisEvent = &CrisisEvent{Effects: make(map[string]interface{})}
-	}
-	if gs.CrisisEvent.Effects == nil {
-		gs.CrisisEvent.Effects = make(map[string]interface{})
-	}
+	effects := gs.mutableCrisisEvent().Effects
 
-	if hasDifficulty {
-		gs.CrisisEvent.Effects["mining_difficulty"] = newDifficulty
+	if payload.Difficulty != nil {
+		effects["mining_difficulty"] = *payload.Difficulty
 	}
-	if hasReward {
-		gs.CrisisEvent.Effects["mining_base_reward"] = newBaseReward
+	if payload.BaseReward != nil {
+		effects["mining_base_reward"] = *payload.BaseReward
 	}
 }
 
 func (gs *GameState) applyTokensDistributed(event Event) {
 	// Handle bulk token distribution (e.g., from mining pool)
-	distribution, ok := event.Payload["distribution"].(map[string]interface{})
-	if !ok {
+	payload, err := DecodePayload[TokensDistributedPayload](event)
+	if err != nil {
 		return
 	}
 
-	for playerID, amountInterface := range distribution {
-		if amount, ok := amountInterface.(float64); ok {
-			if player, exists := gs.Players[playerID]; exists {
-				player.Tokens += int(amount)
-			}
+	for playerID, amount := range payload.Distribution {
+		if player, exists := gs.mutablePlayer(playerID); exists {
+			player.Tokens += amount
 		}
 	}
 }
 
 func (gs *GameState) applyNightActionSubmitted(event Event) {
 	playerID := event.PlayerID
-	actionType, _ := event.Payload["action_type"].(string)
-	targetID, _ := event.Payload["target_id"].(string)
+	payload, _ := DecodePayload[NightActionSubmittedPayload](event)
 	timestamp := event.Timestamp
 
 	// Store the submitted night action
-	if gs.NightActions == nil {
-		gs.NightActions = make(map[string]*SubmittedNightAction)
-	}
-
-	gs.NightActions[playerID] = &SubmittedNightAction{
+	gs.mutableNightActions()[playerID] = &SubmittedNightAction{
 		PlayerID:  playerID,
-		Type:      actionType,
-		TargetID:  targetID,
+		Type:      payload.ActionType,
+		TargetID:  payload.TargetID,
 		Payload:   event.Payload,
 		Timestamp: timestamp,
 	}
 
 	// Update player's last action for reference
-	if player, exists := gs.Players[playerID]; exists {
+	if player, exists := gs.mutablePlayer(playerID); exists {
 		player.LastNightAction = &NightAction{
-			Type:     NightActionType(actionType),
-			TargetID: targetID,
+			Type:     NightActionType(payload.ActionType),
+			TargetID: payload.TargetID,
 		}
 	}
 }
 
 func (gs *GameState) applyNightActionsResolved(event Event) {
 	// Process night action results
-	results, ok := event.Payload["results"].(map[string]interface{})
-	if !ok {
+	payload, err := DecodePayload[NightActionsResolvedPayload](event)
+	if err != nil {
 		return
 	}
 
 	// Update each player based on night action results
-	for playerID, resultInterface := range results {
-		if result, ok := resultInterface.(map[string]interface{}); ok {
-			if player, exists := gs.Players[playerID]; exists {
-				// Update tokens from mining or other actions
-				if tokenChange, exists := result["token_change"]; exists {
-					if change, ok := tokenChange.(float64); ok {
-						player.Tokens += int(change)
-						if player.Tokens < 0 {
-							player.Tokens = 0
-						}
-					}
-				}
-
-				// Update status messages
-				if status, exists := result["status_message"]; exists {
-					if msg, ok := status.(string); ok {
-						player.StatusMessage = msg
-					}
+	for playerID, result := range payload.Results {
+		if player, exists := gs.mutablePlayer(playerID); exists {
+			// Update tokens from mining or other actions
+			if result.TokenChange != nil {
+				player.Tokens += *result.TokenChange
+				if player.Tokens < 0 {
+					player.Tokens = 0
 				}
+			}
 
-				// Update alignment changes from conversions
-				if alignment, exists := result["alignment"]; exists {
-					if align, ok := alignment.(string); ok {
-						player.Alignment = align
-					}
-				}
+			// Update status messages
+			if result.StatusMessage != nil {
+				player.StatusMessage = *result.StatusMessage
+			}
 
-				// Update AI equity
-				if aiEquity, exists := result["ai_equity"]; exists {
-					if equity, ok := aiEquity.(float64); ok {
-						player.AIEquity = int(equity)
-					}
-				}
+			// Update alignment changes from conversions
+			if result.Alignment != nil {
+				player.Alignment = *result.Alignment
+			}
 
-				// Reset night action tracking
-				player.LastNightAction = nil
-				player.HasUsedAbility = false
+			// Update AI equity
+			if result.AIEquity != nil {
+				player.AIEquity = *result.AIEquity
 			}
+
+		}
+	}
+
+	// Every ability is ready again for the next night
+	for playerID, player := range gs.Players {
+		if player.LastNightAction != nil || player.HasUsedAbility {
+			player, _ = gs.mutablePlayer(playerID)
+			player.LastNightAction = nil
+			player.HasUsedAbility = false
 		}
 	}
 
@@ -699,53 +782,52 @@ func (gs *GameState) applyNightActionsResolved(event Event) {
 
 func (gs *GameState) applyPlayerBlocked(event Event) {
 	playerID := event.PlayerID
-	blockedBy, _ := event.Payload["blocked_by"].(string)
+	payload, _ := DecodePayload[PlayerBlockedPayload](event)
 
-	if player, exists := gs.Players[playerID]; exists {
-		if blockedBy != "" {
-			player.StatusMessage = "Action blocked by " + blockedBy
+	if player, exists := gs.mutablePlayer(playerID); exists {
+		if payload.BlockedBy != "" {
+			player.StatusMessage = "Action blocked by " + payload.BlockedBy
 		} else {
 			player.StatusMessage = "Action blocked"
 		}
 	}
 
 	// Track blocked players for night resolution
-	if gs.BlockedPlayersTonight == nil {
-		gs.BlockedPlayersTonight = make(map[string]bool)
-	}
-	gs.BlockedPlayersTonight[playerID] = true
+	gs.mutableBlockedPlayers()[playerID] = true
 }
 
 func (gs *GameState) applyPlayerProtected(event Event) {
 	playerID := event.PlayerID
-	protectedBy, _ := event.Payload["protected_by"].(string)
+	payload, _ := DecodePayload[PlayerProtectedPayload](event)
 
-	if player, exists := gs.Players[playerID]; exists {
-		if protectedBy != "" {
-			player.StatusMessage = "Protected by " + protectedBy
+	if player, exists := gs.mutablePlayer(playerID); exists {
+		if payload.ProtectedBy != "" {
+			player.StatusMessage = "Protected by " + payload.ProtectedBy
 		} else {
 			player.StatusMessage = "Protected"
 		}
 	}
 
 	// Track protected players for night resolution
-	if gs.ProtectedPlayersTonight == nil {
-		gs.ProtectedPlayersTonight = make(map[string]bool)
-	}
-	gs.ProtectedPlayersTonight[playerID] = true
+	gs.mutableProtectedPlayers()[playerID] = true
 }
 
 func (gs *GameState) applyPlayerInvestigated(event Event) {
 	// Investigation results are private to the investigator
 	// Store the investigation for audit trails but don't modify visible state
-	investigatorID := event.PlayerID
-	_, _ = event.Payload["target_id"].(string)
-	_, _ = event.Payload["result"].(string)
+	payload, err := DecodePayload[PlayerInvestigatedPayload](event)
+	if err != nil {
+		return
+	}
+	investigatorID := payload.InvestigatorID
+	if investigatorID == "" {
+		investigatorID = event.PlayerID
+	}
 
 	// Investigations don't change public game state
 	// Results are delivered privately to the investigator
 	// We could store investigation history for admin/debug purposes
-	if investigator, exists := gs.Players[investigatorID]; exists {
+	if investigator, exists := gs.mutablePlayer(investigatorID); exists {
 		// Mark ability as used
 		investigator.HasUsedAbility = true
 	}
@@ -755,49 +837,72 @@ func (gs *GameState) applyPlayerInvestigated(event Event) {
 }
 
 func (gs *GameState) applyAIConversionAttempt(event Event) {
-	targetID, _ := event.Payload["target_id"].(string)
-	aiEquity, _ := event.Payload["ai_equity"].(float64)
+	payload, err := DecodePayload[AIConversionAttemptPayload](event)
+	if err != nil {
+		return
+	}
 
-	if player, exists := gs.Players[targetID]; exists {
-		player.AIEquity = int(aiEquity)
+	if player, exists := gs.mutablePlayer(payload.TargetID); exists {
+		player.AIEquity = payload.AIEquity
 	}
 }
 
 func (gs *GameState) applyAIConversionSuccess(event Event) {
-	targetID := event.PlayerID
+	payload, err := DecodePayload[AIConversionSuccessPayload](event)
+	if err != nil {
+		return
+	}
+	targetID := payload.TargetID
+	if targetID == "" {
+		targetID = event.PlayerID
+	}
 
-	if player, exists := gs.Players[targetID]; exists {
+	if player, exists := gs.mutablePlayer(targetID); exists {
 		player.Alignment = "ALIGNED"
 		player.StatusMessage = "Conversion successful"
 		player.AIEquity = 0 // Reset after successful conversion
+
+		// Announce the new member on the faction channel; they see its full history from now on
+		gs.FactionChatMessages = appendMessage(gs.FactionChatMessages, ChatMessage{
+			ID:         event.ID,
+			PlayerID:   "SYSTEM",
+			PlayerName: "Loebmate",
+			Message:    player.Name + " has been aligned.",
+			Timestamp:  event.Timestamp,
+			IsSystem:   true,
+		})
 	}
 }
 
 func (gs *GameState) applyAIConversionFailed(event Event) {
 	targetID := event.PlayerID
-	shockMessage, _ := event.Payload["shock_message"].(string)
+	payload, _ := DecodePayload[AIConversionFailedPayload](event)
 
-	if player, exists := gs.Players[targetID]; exists {
-		player.StatusMessage = shockMessage
+	if player, exists := gs.mutablePlayer(targetID); exists {
+		player.StatusMessage = payload.ShockMessage
 		player.AIEquity = 0 // Reset after failed conversion
 	}
 }
 
 func (gs *GameState) applySystemMessage(event Event) {
+	// Messages addressed to one player or the AI faction stay out of the shared
+	// chat log, which reaches every player through snapshots
+	if ClassifyEvent(event) != VisibilityPublic {
+		return
+	}
+
+	payload, _ := DecodePayload[SystemMessagePayload](event)
+
 	message := ChatMessage{
 		ID:         event.ID,
 		PlayerID:   "SYSTEM",
 		PlayerName: "Loebmate",
-		Message:    "",
+		Message:    payload.Message,
 		Timestamp:  event.Timestamp,
 		IsSystem:   true,
 	}
 
-	if messageText, ok := event.Payload["message"].(string); ok {
-		message.Message = messageText
-	}
-
-	gs.ChatMessages = append(gs.ChatMessages, message)
+	gs.ChatMessages = appendMessage(gs.ChatMessages, message)
 }
 
 func (gs *GameState) applyPrivateNotification(event Event) {
@@ -806,34 +911,23 @@ func (gs *GameState) applyPrivateNotification(event Event) {
 }
 
 func (gs *GameState) applyPulseCheckStarted(event Event) {
-	question, _ := event.Payload["question"].(string)
+	payload, _ := DecodePayload[PulseCheckStartedPayload](event)
 
 	// Store pulse check question in crisis event or separate field
-	if gs.CrisisEvent == nil {
-		gs.CrisisEvent = &CrisisEvent{
-			Effects: make(map[string]interface{}),
-		}
-	}
-	if gs.CrisisEvent.Effects == nil {
-		gs.CrisisEvent.Effects = make(map[string]interface{})
-	}
-	gs.CrisisEvent.Effects["pulse_check_question"] = question
+	gs.mutableCrisisEvent().Effects["pulse_check_question"] = payload.Question
 }
 
 func (gs *GameState) applyPulseCheckSubmitted(event Event) {
 	playerID := event.PlayerID
-	response, _ := event.Payload["response"].(string)
-
-	// Store pulse check responses (could be in a separate field)
-	if gs.CrisisEvent == nil {
-		gs.CrisisEvent = &CrisisEvent{Effects: make(map[string]interface{})}
-	}
-	if gs.CrisisEvent.Effects["pulse_responses"] == nil {
-		gs.CrisisEvent.Effects["pulse_responses"] = make(map[string]interface{})
-	}
-
-	responses := gs.CrisisEvent.Effects["pulse_responses"].(map[string]interface{})
-	responses[playerID] = response
+	payload, _ := DecodePayload[PulseCheckSubmittedPayload](event)
+
+	// Store pulse check responses (could be in a separate field); the nested
+	// map is shared with older states too, so it is copied before adding to it
+	effects := gs.mutableCrisisEvent().Effects
+	previous, _ := effects["pulse_responses"].(map[string]interface{})
+	responses := copyMap(previous)
+	responses[playerID] = payload.Response
+	effects["pulse_responses"] = responses
 }
 
 func (gs *GameState) applyPulseCheckRevealed(event Event) {
@@ -844,11 +938,13 @@ func (gs *GameState) applyPulseCheckRevealed(event Event) {
 // Role ability event handlers
 func (gs *GameState) applyRunAudit(event Event) {
 	// CISO audit ability - reveals alignment of target
+	payload, err := DecodePayload[RunAuditPayload](event)
+	if err != nil || payload.AIFactionOnly {
+		return // The AI faction's copy repeats the public event
+	}
 	auditorID := event.PlayerID
-	_, _ = event.Payload["target_id"].(string)
-	_, _ = event.Payload["result"].(string)
 
-	if auditor, exists := gs.Players[auditorID]; exists {
+	if auditor, exists := gs.mutablePlayer(auditorID); exists {
 		auditor.HasUsedAbility = true
 		auditor.StatusMessage = "Audit completed"
 	}
@@ -860,16 +956,19 @@ func (gs *GameState) applyRunAudit(event Event) {
 func (gs *GameState) applyOverclockServers(event Event) {
 	// CTO overclock ability - awards extra tokens to target
 	ctoID := event.PlayerID
-	targetID, _ := event.Payload["target_id"].(string)
-	tokensAwarded, _ := event.Payload["tokens_awarded"].(float64)
+	payload, _ := DecodePayload[OverclockServersPayload](event)
 
-	if cto, exists := gs.Players[ctoID]; exists {
+	if cto, exists := gs.mutablePlayer(ctoID); exists {
 		cto.HasUsedAbility = true
 		cto.StatusMessage = "Servers overclocked"
 	}
 
-	if target, exists := gs.Players[targetID]; exists {
-		target.Tokens += int(tokensAwarded)
+	if cto, exists := gs.mutablePlayer(ctoID); exists {
+		cto.Tokens += payload.TokensAwarded
+	}
+
+	if target, exists := gs.mutablePlayer(payload.TargetID); exists {
+		target.Tokens += payload.TokensAwarded
 		target.StatusMessage = "Received bonus tokens"
 	}
 }
@@ -877,64 +976,78 @@ func (gs *GameState) applyOverclockServers(event Event) {
 func (gs *GameState) applyIsolateNode(event Event) {
 	// COO isolate ability - blocks target's night action
 	cooID := event.PlayerID
-	targetID, _ := event.Payload["target_id"].(string)
+	payload, err := DecodePayload[AbilityTargetPayload](event)
+	if err != nil {
+		return
+	}
 
-	if coo, exists := gs.Players[cooID]; exists {
+	// The AI faction's copy of a fizzled isolation only tells it why
+	if payload.AIFactionOnly {
+		return
+	}
+
+	if coo, exists := gs.mutablePlayer(cooID); exists {
 		coo.HasUsedAbility = true
 		coo.StatusMessage = "Node isolated"
 	}
 
-	if target, exists := gs.Players[targetID]; exists {
+	if target, exists := gs.mutablePlayer(payload.TargetID); exists {
 		target.StatusMessage = "Connection isolated"
 	}
 
-	// Track blocked players for night resolution
-	if gs.BlockedPlayersTonight == nil {
-		gs.BlockedPlayersTonight = make(map[string]bool)
+	// An isolation that fizzled looks public but never blocks its target.
+	// Blocks placed by other players stay in force.
+	if !payload.Fizzled {
+		gs.mutableBlockedPlayers()[payload.TargetID] = true
 	}
-	gs.BlockedPlayersTonight[targetID] = true
 }
 
 func (gs *GameState) applyPerformanceReview(event Event) {
 	// CEO performance review - forces target to perform specific action
 	ceoID := event.PlayerID
-	targetID, _ := event.Payload["target_id"].(string)
-	forcedAction, _ := event.Payload["forced_action"].(string)
+	payload, _ := DecodePayload[PerformanceReviewPayload](event)
 
-	if ceo, exists := gs.Players[ceoID]; exists {
+	if ceo, exists := gs.mutablePlayer(ceoID); exists {
 		ceo.HasUsedAbility = true
 		ceo.StatusMessage = "Performance review completed"
 	}
 
-	if target, exists := gs.Players[targetID]; exists {
-		target.StatusMessage = "Under performance review - " + forcedAction
+	if target, exists := gs.mutablePlayer(payload.TargetID); exists {
+		target.StatusMessage = "Under performance review - " + payload.ForcedAction
 	}
 
-	// The forced action is handled by the night resolution system
+	// The forced action replaces whatever the target submitted tonight
+	gs.mutableNightActions()[payload.TargetID] = &SubmittedNightAction{
+		PlayerID:  payload.TargetID,
+		Type:      payload.ForcedAction,
+		Timestamp: event.Timestamp,
+		Payload:   map[string]interface{}{"forced_by_ceo": true},
+	}
 }
 
 func (gs *GameState) applyReallocateBudget(event Event) {
 	// CFO budget reallocation - moves tokens between players
 	cfoID := event.PlayerID
-	fromPlayerID, _ := event.Payload["from_player"].(string)
-	toPlayerID, _ := event.Payload["to_player"].(string)
-	amount, _ := event.Payload["amount"].(float64)
+	payload, err := DecodePayload[ReallocateBudgetPayload](event)
+	if err != nil {
+		return
+	}
 
-	if cfo, exists := gs.Players[cfoID]; exists {
+	if cfo, exists := gs.mutablePlayer(cfoID); exists {
 		cfo.HasUsedAbility = true
 		cfo.StatusMessage = "Budget reallocated"
 	}
 
-	if fromPlayer, exists := gs.Players[fromPlayerID]; exists {
-		fromPlayer.Tokens -= int(amount)
+	if fromPlayer, exists := gs.mutablePlayer(payload.FromPlayer); exists {
+		fromPlayer.Tokens -= payload.Amount
 		if fromPlayer.Tokens < 0 {
 			fromPlayer.Tokens = 0
 		}
 		fromPlayer.StatusMessage = "Budget reduced"
 	}
 
-	if toPlayer, exists := gs.Players[toPlayerID]; exists {
-		toPlayer.Tokens += int(amount)
+	if toPlayer, exists := gs.mutablePlayer(payload.ToPlayer); exists {
+		toPlayer.Tokens += payload.Amount
 		toPlayer.StatusMessage = "Budget increased"
 	}
 }
@@ -942,76 +1055,67 @@ func (gs *GameState) applyReallocateBudget(event Event) {
 func (gs *GameState) applyPivot(event Event) {
 	// VP Platforms pivot - selects next day's crisis
 	vpID := event.PlayerID
-	selectedCrisis, _ := event.Payload["selected_crisis"].(string)
+	payload, _ := DecodePayload[PivotPayload](event)
 
-	if vp, exists := gs.Players[vpID]; exists {
+	if vp, exists := gs.mutablePlayer(vpID); exists {
 		vp.HasUsedAbility = true
 		vp.StatusMessage = "Strategy pivoted"
 	}
 
 	// Store the selected crisis for tomorrow's SITREP
-	if gs.CrisisEvent == nil {
-		gs.CrisisEvent = &CrisisEvent{Effects: make(map[string]interface{})}
-	}
-	if gs.CrisisEvent.Effects == nil {
-		gs.CrisisEvent.Effects = make(map[string]interface{})
-	}
-	gs.CrisisEvent.Effects["next_crisis"] = selectedCrisis
+	gs.mutableCrisisEvent().Effects["next_crisis"] = payload.SelectedCrisis
 }
 
 func (gs *GameState) applyDeployHotfix(event Event) {
 	// Ethics VP hotfix - redacts part of tomorrow's SITREP
 	ethicsID := event.PlayerID
-	redactionTarget, _ := event.Payload["redaction_target"].(string)
+	payload, _ := DecodePayload[DeployHotfixPayload](event)
 
-	if ethics, exists := gs.Players[ethicsID]; exists {
+	if ethics, exists := gs.mutablePlayer(ethicsID); exists {
 		ethics.HasUsedAbility = true
 		ethics.StatusMessage = "Hotfix deployed"
 	}
 
 	// Store the redaction target for tomorrow's SITREP
-	if gs.CrisisEvent == nil {
-		gs.CrisisEvent = &CrisisEvent{Effects: make(map[string]interface{})}
-	}
-	if gs.CrisisEvent.Effects == nil {
-		gs.CrisisEvent.Effects = make(map[string]interface{})
-	}
-	gs.CrisisEvent.Effects["sitrep_redaction"] = redactionTarget
+	gs.mutableCrisisEvent().Effects["sitrep_redaction"] = payload.RedactionTarget
 }
 
 // Status event handlers
 func (gs *GameState) applySlackStatusChanged(event Event) {
 	playerID := event.PlayerID
-	status, _ := event.Payload["status"].(string)
+	payload, _ := DecodePayload[StatusPayload](event)
 
-	if player, exists := gs.Players[playerID]; exists {
-		player.SlackStatus = status
+	if player, exists := gs.mutablePlayer(playerID); exists {
+		player.SlackStatus = payload.Status
 	}
 }
 
 func (gs *GameState) applyPartingShotSet(event Event) {
 	playerID := event.PlayerID
-	partingShot, _ := event.Payload["parting_shot"].(string)
+	payload, _ := DecodePayload[PartingShotSetPayload](event)
 
-	if player, exists := gs.Players[playerID]; exists {
-		player.PartingShot = partingShot
+	if player, exists := gs.mutablePlayer(playerID); exists {
+		player.PartingShot = payload.PartingShot
 	}
 }
 
 // KPI event handlers
 func (gs *GameState) applyKPIProgress(event Event) {
 	playerID := event.PlayerID
-	progress, _ := event.Payload["progress"].(float64)
+	payload, err := DecodePayload[KPIProgressPayload](event)
+	if err != nil {
+		return
+	}
 
-	if player, exists := gs.Players[playerID]; exists && player.PersonalKPI != nil {
-		player.PersonalKPI.Progress = int(progress)
+	if player, exists := gs.mutablePlayer(playerID); exists && player.PersonalKPI != nil {
+		player.PersonalKPI.Progress = payload.Progress
 	}
 }
 
 func (gs *GameState) applyKPICompleted(event Event) {
 	playerID := event.PlayerID
 
-	if player, exists := gs.Players[playerID]; exists && player.PersonalKPI != nil {
+	if player, exists := gs.mutablePlayer(playerID); exists && player.PersonalKPI != nil {
 		player.PersonalKPI.IsCompleted = true
 	}
 }
@@ -1019,15 +1123,16 @@ func (gs *GameState) applyKPICompleted(event Event) {
 // System shock event handlers
 func (gs *GameState) applySystemShockApplied(event Event) {
 	playerID := event.PlayerID
-	shockType, _ := event.Payload["shock_type"].(string)
-	description, _ := event.Payload["description"].(string)
-	durationHours, _ := event.Payload["duration_hours"].(float64)
+	payload, err := DecodePayload[SystemShockAppliedPayload](event)
+	if err != nil {
+		return
+	}
 
-	if player, exists := gs.Players[playerID]; exists {
+	if player, exists := gs.mutablePlayer(playerID); exists {
 		shock := SystemShock{
-			Type:        ShockType(shockType),
-			Description: description,
-			ExpiresAt:   time.Now().Add(time.Duration(durationHours) * time.Hour),
+			Type:        payload.ShockType,
+			Description: payload.Description,
+			ExpiresAt:   event.Timestamp.Add(time.Duration(payload.DurationHours) * time.Hour),
 			IsActive:    true,
 		}
 
@@ -1041,14 +1146,16 @@ func (gs *GameState) applySystemShockApplied(event Event) {
 // AI equity event handlers
 func (gs *GameState) applyAIEquityChanged(event Event) {
 	playerID := event.PlayerID
-	change, _ := event.Payload["ai_equity_change"].(float64)
-	newEquity, _ := event.Payload["new_ai_equity"].(float64)
+	payload, err := DecodePayload[AIEquityChangedPayload](event)
+	if err != nil {
+		return
+	}
 
-	if player, exists := gs.Players[playerID]; exists {
-		if change != 0 {
-			player.AIEquity += int(change)
-		} else if newEquity != 0 {
-			player.AIEquity = int(newEquity)
+	if player, exists := gs.mutablePlayer(playerID); exists {
+		if payload.AIEquityChange != 0 {
+			player.AIEquity += payload.AIEquityChange
+		} else if payload.NewAIEquity != 0 {
+			player.AIEquity = payload.NewAIEquity
 		}
 	}
 }
\ No newline at end of file
@@ -166,6 +166,36 @@ func TestApplyEvent_PlayerEliminated(t *testing.T) {
 	}
 }
 
+func TestApplyEvent_PlayerPresence(t *testing.T) {
+	gameState := NewGameState("test-game")
+	gameState.Players["player-1"] = &Player{ID: "player-1", Name: "Alice", IsAlive: true}
+
+	away := ApplyEvent(*gameState, Event{
+		ID:        "event-1",
+		Type:      EventPlayerDisconnected,
+		GameID:    "test-game",
+		PlayerID:  "player-1",
+		Timestamp: time.Now(),
+	})
+	if !away.Players["player-1"].IsAway {
+		t.Error("Expected player to be away after disconnecting")
+	}
+	if gameState.Players["player-1"].IsAway {
+		t.Error("Expected the original state to be unchanged")
+	}
+
+	back := ApplyEvent(away, Event{
+		ID:        "event-2",
+		Type:      EventPlayerReconnected,
+		GameID:    "test-game",
+		PlayerID:  "player-1",
+		Timestamp: time.Now(),
+	})
+	if back.Players["player-1"].IsAway {
+		t.Error("Expected player to be back after reconnecting")
+	}
+}
+
 func TestApplyEvent_RoleAssigned(t *testing.T) {
 	gameState := NewGameState("test-game")
 	gameState.Players["player-1"] = &Player{
@@ -0,0 +1,511 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// PayloadSchemaVersion is the version of the payload schema defined in this
+// file. Bump it whenever a payload changes shape and add an upgrade step to
+// payloadUpgrades so that stored streams remain replayable.
+const PayloadSchemaVersion = 1
+
+// Typed payloads for each EventType. Event.Payload stays a map on the wire;
+// these structs define its schema and are what ApplyEvent consumes.
+
+// GameCreatedPayload is the payload of EventGameCreated. The seed is written
+// as a string so it survives JSON's float64 numbers intact.
+type GameCreatedPayload struct {
+	Seed int64 `json:"seed,string"`
+}
+
+// PhaseChangedPayload is the payload of EventPhaseChanged
+type PhaseChangedPayload struct {
+	PhaseType     PhaseType `json:"phase_type"`
+	PreviousPhase PhaseType `json:"previous_phase,omitempty"`
+	Duration      int       `json:"duration"` // Seconds
+	DayNumber     int       `json:"day_number,omitempty"`
+}
+
+// DayStartedPayload is the payload of EventDayStarted
+type DayStartedPayload struct {
+	DayNumber int `json:"day_number"`
+}
+
+// PlayerJoinedPayload is the payload of EventPlayerJoined
+type PlayerJoinedPayload struct {
+	Name     string `json:"name"`
+	JobTitle string `json:"job_title"`
+}
+
+// PlayerLeftPayload is the payload of EventPlayerLeft
+type PlayerLeftPayload struct {
+	Reason string `json:"reason,omitempty"` // "left" or "kicked" in the lobby
+}
+
+// PlayerEliminatedPayload is the payload of EventPlayerEliminated
+type PlayerEliminatedPayload struct {
+	RoleType  RoleType `json:"role_type"`
+	Alignment string   `json:"alignment"`
+}
+
+// PlayerShockedPayload is the payload of EventPlayerShocked. It goes to the
+// shocked human, so it never names the converter.
+type PlayerShockedPayload struct {
+	ShockMessage string `json:"shock_message,omitempty"`
+	TargetID     string `json:"target_id,omitempty"`
+	Reason       string `json:"reason,omitempty"`
+}
+
+// StatusPayload is the payload of EventPlayerStatusChanged and EventSlackStatusChanged
+type StatusPayload struct {
+	Status string `json:"status"`
+}
+
+// RoleAssignedPayload is the payload of EventRoleAssigned
+type RoleAssignedPayload struct {
+	RoleType        RoleType `json:"role_type"`
+	RoleName        string   `json:"role_name"`
+	RoleDescription string   `json:"role_description"`
+	KPIType         KPIType  `json:"kpi_type,omitempty"`
+	KPIDescription  string   `json:"kpi_description,omitempty"`
+	KPITarget       int      `json:"kpi_target,omitempty"` // Defaults to 1
+	KPIReward       string   `json:"kpi_reward,omitempty"`
+	Alignment       string   `json:"alignment"`
+}
+
+// MandateActivatedPayload is the payload of EventMandateActivated
+type MandateActivatedPayload struct {
+	MandateType            MandateType            `json:"mandate_type"`
+	Name                   string                 `json:"name"`
+	Description            string                 `json:"description"`
+	Effects                map[string]interface{} `json:"effects,omitempty"`
+	StartingTokensModifier int                    `json:"starting_tokens_modifier,omitempty"`
+}
+
+// RoleAbilityUnlockedPayload is the payload of EventRoleAbilityUnlocked
+type RoleAbilityUnlockedPayload struct {
+	AbilityName        string `json:"ability_name"`
+	AbilityDescription string `json:"ability_description"`
+}
+
+// ProjectMilestonePayload is the payload of EventProjectMilestone
+type ProjectMilestonePayload struct {
+	Milestone int `json:"milestone"`
+}
+
+// VotePayload is the payload of EventVoteCast and EventVoteStarted
+type VotePayload struct {
+	TargetID string   `json:"target_id,omitempty"`
+	VoteType VoteType `json:"vote_type"`
+}
+
+// VoteCompletedPayload is the payload of EventVoteCompleted. Results are the
+// token-weighted totals per option, never who voted for what.
+type VoteCompletedPayload struct {
+	VoteType VoteType       `json:"vote_type"`
+	Results  map[string]int `json:"results"`
+	Outcome  string         `json:"outcome,omitempty"` // Nominated player, or YES/NO for a verdict
+}
+
+// PlayerNominatedPayload is the payload of EventPlayerNominated
+type PlayerNominatedPayload struct {
+	NominatedPlayer string `json:"nominated_player"`
+}
+
+// TokensPayload is the payload of EventTokensAwarded and EventTokensLost
+type TokensPayload struct {
+	Amount int `json:"amount"`
+}
+
+// MiningSuccessfulPayload is the payload of EventMiningSuccessful
+type MiningSuccessfulPayload struct {
+	Amount   *int   `json:"amount,omitempty"` // Defaults to 1 when absent
+	MinerID  string `json:"miner_id,omitempty"`
+	TargetID string `json:"target_id,omitempty"`
+}
+
+// MiningFailedPayload is the payload of EventMiningFailed
+type MiningFailedPayload struct {
+	TargetID string `json:"target_id,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// MiningPoolUpdatedPayload is the payload of EventMiningPoolUpdated
+type MiningPoolUpdatedPayload struct {
+	Difficulty *float64 `json:"difficulty,omitempty"`
+	BaseReward *float64 `json:"base_reward,omitempty"`
+}
+
+// TokensDistributedPayload is the payload of EventTokensDistributed
+type TokensDistributedPayload struct {
+	Distribution map[string]int `json:"distribution"`
+}
+
+// NightActionSubmittedPayload is the payload of EventNightActionSubmitted
+type NightActionSubmittedPayload struct {
+	ActionType string `json:"action_type"`
+	TargetID   string `json:"target_id,omitempty"`
+}
+
+// NightActionResult is one player's outcome in a NightActionsResolvedPayload.
+// Nil fields leave the player unchanged.
+type NightActionResult struct {
+	TokenChange   *int    `json:"token_change,omitempty"`
+	StatusMessage *string `json:"status_message,omitempty"`
+	Alignment     *string `json:"alignment,omitempty"`
+	AIEquity      *int    `json:"ai_equity,omitempty"`
+}
+
+// NightActionsResolvedPayload is the payload of EventNightActionsResolved
+type NightActionsResolvedPayload struct {
+	Results        map[string]NightActionResult `json:"results,omitempty"`
+	TotalActions   int                          `json:"total_actions"`
+	ResolvedEvents int                          `json:"resolved_events"`
+	PhaseEnd       bool                         `json:"phase_end,omitempty"`
+}
+
+// PlayerInvestigatedPayload is the payload of EventPlayerInvestigated, which
+// only the investigator sees
+type PlayerInvestigatedPayload struct {
+	InvestigatorID string `json:"investigator_id"`
+	TargetID       string `json:"target_id"`
+	TargetName     string `json:"target_name"`
+	Alignment      string `json:"alignment"`
+	Role           string `json:"role"` // RoleType, or UNKNOWN before roles are dealt
+}
+
+// PlayerBlockedPayload is the payload of EventPlayerBlocked
+type PlayerBlockedPayload struct {
+	BlockedBy string `json:"blocked_by,omitempty"`
+	TargetID  string `json:"target_id,omitempty"`
+}
+
+// PlayerProtectedPayload is the payload of EventPlayerProtected
+type PlayerProtectedPayload struct {
+	ProtectedBy string `json:"protected_by,omitempty"`
+	TargetID    string `json:"target_id,omitempty"`
+}
+
+// AIConversionAttemptPayload is the payload of EventAIConversionAttempt
+type AIConversionAttemptPayload struct {
+	TargetID string `json:"target_id"`
+	AIEquity int    `json:"ai_equity"`
+}
+
+// AIConversionSuccessPayload is the payload of EventAIConversionSuccess
+type AIConversionSuccessPayload struct {
+	ConverterID string `json:"converter_id"`
+	TargetID    string `json:"target_id"`
+}
+
+// AIConversionFailedPayload is the payload of EventAIConversionFailed
+type AIConversionFailedPayload struct {
+	ShockMessage string `json:"shock_message"`
+}
+
+// ChatMessagePayload is the payload of EventChatMessage and EventFactionMessage
+type ChatMessagePayload struct {
+	PlayerName string `json:"player_name"`
+	Message    string `json:"message"`
+	IsSystem   bool   `json:"is_system,omitempty"`
+}
+
+// FactionChatHistoryPayload is the payload of EventFactionChatHistory
+type FactionChatHistoryPayload struct {
+	Messages []ChatMessage `json:"messages"`
+}
+
+// SystemMessagePayload is the payload of EventSystemMessage
+type SystemMessagePayload struct {
+	Message string `json:"message"`
+}
+
+// CrisisTriggeredPayload is the payload of EventCrisisTriggered
+type CrisisTriggeredPayload struct {
+	CrisisType  string                 `json:"crisis_type"`
+	Title       string                 `json:"title"`
+	Description string                 `json:"description"`
+	Effects     map[string]interface{} `json:"effects,omitempty"`
+}
+
+// PulseCheckStartedPayload is the payload of EventPulseCheckStarted
+type PulseCheckStartedPayload struct {
+	Question string `json:"question"`
+}
+
+// PulseCheckSubmittedPayload is the payload of EventPulseCheckSubmitted
+type PulseCheckSubmittedPayload struct {
+	Response string `json:"response"`
+}
+
+// VictoryConditionPayload is the payload of EventVictoryCondition
+type VictoryConditionPayload struct {
+	Winner      string `json:"winner"`
+	Condition   string `json:"condition"`
+	Description string `json:"description"`
+}
+
+// GameEndedPayload is the payload of EventGameEnded
+type GameEndedPayload struct {
+	WinningFaction string `json:"winning_faction"`
+	Reason         string `json:"reason"`
+}
+
+// AbilityTargetPayload is the payload of role ability events that act on one player
+type AbilityTargetPayload struct {
+	TargetID      string `json:"target_id"`
+	Message       string `json:"message,omitempty"`
+	Fizzled       bool   `json:"fizzled,omitempty"` // The ability had no effect, known only to the AI faction; redacted from public copies
+	AIFactionOnly bool   `json:"ai_faction_only,omitempty"`
+}
+
+// RunAuditPayload is the payload of EventRunAudit. The public event carries
+// the result everyone sees, the AI faction's copy the true alignment.
+type RunAuditPayload struct {
+	TargetID      string `json:"target_id"`
+	Result        string `json:"result,omitempty"`
+	Message       string `json:"message,omitempty"`
+	TrueAlignment string `json:"true_alignment,omitempty"`
+	AIFactionOnly bool   `json:"ai_faction_only,omitempty"`
+}
+
+// OverclockServersPayload is the payload of EventOverclockServers
+type OverclockServersPayload struct {
+	TargetID      string `json:"target_id"`
+	TokensAwarded int    `json:"tokens_awarded,omitempty"`
+	Message       string `json:"message,omitempty"`
+}
+
+// PerformanceReviewPayload is the payload of EventPerformanceReview
+type PerformanceReviewPayload struct {
+	TargetID     string `json:"target_id"`
+	ForcedAction string `json:"forced_action"`
+	Message      string `json:"message,omitempty"`
+}
+
+// ReallocateBudgetPayload is the payload of EventReallocateBudget
+type ReallocateBudgetPayload struct {
+	FromPlayer string `json:"from_player"`
+	ToPlayer   string `json:"to_player"`
+	Amount     int    `json:"amount"`
+	Message    string `json:"message,omitempty"`
+}
+
+// PivotPayload is the payload of EventPivot
+type PivotPayload struct {
+	SelectedCrisis string `json:"selected_crisis"`
+	Message        string `json:"message,omitempty"`
+}
+
+// DeployHotfixPayload is the payload of EventDeployHotfix
+type DeployHotfixPayload struct {
+	RedactionTarget string `json:"redaction_target"`
+	Message         string `json:"message,omitempty"`
+}
+
+// PartingShotSetPayload is the payload of EventPartingShotSet
+type PartingShotSetPayload struct {
+	PartingShot string `json:"parting_shot"`
+}
+
+// KPIProgressPayload is the payload of EventKPIProgress
+type KPIProgressPayload struct {
+	Progress int `json:"progress"`
+}
+
+// SystemShockAppliedPayload is the payload of EventSystemShockApplied
+type SystemShockAppliedPayload struct {
+	ShockType     ShockType `json:"shock_type"`
+	Description   string    `json:"description"`
+	DurationHours float64   `json:"duration_hours"`
+}
+
+// AIEquityChangedPayload is the payload of EventAIEquityChanged
+type AIEquityChangedPayload struct {
+	AIEquityChange int    `json:"ai_equity_change,omitempty"`
+	NewAIEquity    int    `json:"new_ai_equity,omitempty"`
+	Source         string `json:"source,omitempty"`
+	AIFactionOnly  bool   `json:"ai_faction_only,omitempty"`
+}
+
+// ActionRejectedPayload is the payload of EventActionRejected
+type ActionRejectedPayload struct {
+	ActionType ActionType `json:"action_type"`
+	Code       ErrorCode  `json:"code"`
+	Message    string     `json:"message"`
+	Field      string     `json:"field,omitempty"`
+	RequestID  string     `json:"request_id,omitempty"` // Echoed from the rejected action
+}
+
+// ActionAckPayload is the payload of EventActionAck
+type ActionAckPayload struct {
+	ActionType ActionType `json:"action_type"`
+	RequestID  string     `json:"request_id"`
+	Sequence   int        `json:"sequence"` // Last event the action produced
+}
+
+// DecodePayload converts an event's payload map into its typed form. Numbers
+// are normalised by the JSON round trip, so int and float64 values both decode.
+func DecodePayload[T any](event Event) (T, error) {
+	var payload T
+
+	data, err := json.Marshal(event.Payload)
+	if err != nil {
+		return payload, fmt.Errorf("failed to marshal %s payload: %w", event.Type, err)
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return payload, fmt.Errorf("failed to decode %s payload: %w", event.Type, err)
+	}
+
+	return payload, nil
+}
+
+// EncodePayload converts a typed payload into the map carried on Event.Payload.
+// Payload structs contain only plain fields, so a failure is a programming error.
+func EncodePayload(payload interface{}) map[string]interface{} {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		panic(fmt.Sprintf("failed to encode payload %T: %v", payload, err))
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		panic(fmt.Sprintf("failed to encode payload %T: %v", payload, err))
+	}
+	return result
+}
+
+// EncodeEventPayload serialises an event payload for storage and returns the
+// schema version it was written with
+func EncodeEventPayload(event Event) ([]byte, int, error) {
+	data, err := json.Marshal(event.Payload)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to marshal %s payload: %w", event.Type, err)
+	}
+	return data, PayloadSchemaVersion, nil
+}
+
+// DecodeEventPayload deserialises a stored payload written with the given
+// schema version and upgrades it to the current schema. Entries written before
+// payloads were versioned have version 0.
+func DecodeEventPayload(eventType EventType, data []byte, version int) (map[string]interface{}, error) {
+	if version > PayloadSchemaVersion {
+		return nil, fmt.Errorf("unsupported payload schema version %d for %s", version, eventType)
+	}
+
+	var payload map[string]interface{}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s payload: %w", eventType, err)
+		}
+	}
+	if payload == nil {
+		payload = make(map[string]interface{})
+	}
+
+	for v := version; v < PayloadSchemaVersion; v++ {
+		payloadUpgrades[v](eventType, payload)
+	}
+
+	return payload, nil
+}
+
+// payloadUpgrades[v] rewrites a version v payload in place into version v+1
+var payloadUpgrades = []func(eventType EventType, payload map[string]interface{}){
+	upgradePayloadV0,
+}
+
+// upgradePayloadV0 renames the keys that producers wrote before the payload
+// schema was typed
+func upgradePayloadV0(eventType EventType, payload map[string]interface{}) {
+	switch eventType {
+	case EventPhaseChanged:
+		renamePayloadKey(payload, "next_phase", "phase_type")
+	case EventReallocateBudget:
+		renamePayloadKey(payload, "source_id", "from_player")
+		renamePayloadKey(payload, "target_id", "to_player")
+		if _, exists := payload["amount"]; !exists {
+			payload["amount"] = 1 // The CFO always moved a single token
+		}
+	case EventPivot:
+		renamePayloadKey(payload, "chosen_crisis", "selected_crisis")
+	case EventDeployHotfix:
+		renamePayloadKey(payload, "redacted_section", "redaction_target")
+	}
+}
+
+// renamePayloadKey moves a value to a new key unless the new key is already set
+func renamePayloadKey(payload map[string]interface{}, from, to string) {
+	value, exists := payload[from]
+	if !exists {
+		return
+	}
+	if _, taken := payload[to]; !taken {
+		payload[to] = value
+	}
+	delete(payload, from)
+}
+
+// EffectInt returns a numeric crisis effect as an int. Effects are carried in
+// CRISIS_TRIGGERED payloads, so after a JSON round trip numbers are float64.
+func (c *CrisisEvent) EffectInt(key string) (int, bool) {
+	if c == nil {
+		return 0, false
+	}
+	value, ok := numericEffect(c.Effects, key)
+	return int(value), ok
+}
+
+// EffectFloat returns a numeric crisis effect as a float64
+func (c *CrisisEvent) EffectFloat(key string) (float64, bool) {
+	if c == nil {
+		return 0, false
+	}
+	return numericEffect(c.Effects, key)
+}
+
+// EffectBool reports whether a boolean crisis effect is set
+func (c *CrisisEvent) EffectBool(key string) bool {
+	if c == nil {
+		return false
+	}
+	enabled, _ := c.Effects[key].(bool)
+	return enabled
+}
+
+// EffectInt returns a numeric mandate effect as an int
+func (m *CorporateMandate) EffectInt(key string) (int, bool) {
+	if m == nil {
+		return 0, false
+	}
+	value, ok := numericEffect(m.Effects, key)
+	return int(value), ok
+}
+
+// EffectFloat returns a numeric mandate effect as a float64
+func (m *CorporateMandate) EffectFloat(key string) (float64, bool) {
+	if m == nil {
+		return 0, false
+	}
+	return numericEffect(m.Effects, key)
+}
+
+// numericEffect reads a number from an effects map whether it was stored by a
+// producer as an int or decoded from JSON as a float64
+func numericEffect(effects map[string]interface{}, key string) (float64, bool) {
+	switch value := effects[key].(type) {
+	case int:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	case float64:
+		return value, true
+	case json.Number:
+		f, err := value.Float64()
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,226 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestEventPayloadCodec_RoundTrip(t *testing.T) {
+	event := Event{
+		Type: EventPhaseChanged,
+		Payload: EncodePayload(PhaseChangedPayload{
+			PhaseType:     PhaseNight,
+			PreviousPhase: PhaseVerdict,
+			Duration:      30,
+			DayNumber:     2,
+		}),
+	}
+
+	data, version, err := EncodeEventPayload(event)
+	if err != nil {
+		t.Fatalf("Failed to encode payload: %v", err)
+	}
+	if version != PayloadSchemaVersion {
+		t.Errorf("Expected schema version %d, got %d", PayloadSchemaVersion, version)
+	}
+
+	event.Payload, err = DecodeEventPayload(event.Type, data, version)
+	if err != nil {
+		t.Fatalf("Failed to decode payload: %v", err)
+	}
+
+	payload, err := DecodePayload[PhaseChangedPayload](event)
+	if err != nil {
+		t.Fatalf("Failed to decode typed payload: %v", err)
+	}
+	if payload.PhaseType != PhaseNight || payload.Duration != 30 || payload.DayNumber != 2 {
+		t.Errorf("Expected payload to survive the round trip, got %+v", payload)
+	}
+}
+
+func TestDecodeEventPayload_UpgradesLegacyPayloads(t *testing.T) {
+	testCases := []struct {
+		name      string
+		eventType EventType
+		stored    string
+		key       string
+		expected  interface{}
+	}{
+		{"phase changed", EventPhaseChanged, `{"next_phase":"NIGHT"}`, "phase_type", "NIGHT"},
+		{"reallocate source", EventReallocateBudget, `{"source_id":"a","target_id":"b"}`, "from_player", "a"},
+		{"reallocate target", EventReallocateBudget, `{"source_id":"a","target_id":"b"}`, "to_player", "b"},
+		{"reallocate amount", EventReallocateBudget, `{"source_id":"a","target_id":"b"}`, "amount", 1},
+		{"pivot", EventPivot, `{"chosen_crisis":"Press Leak"}`, "selected_crisis", "Press Leak"},
+		{"hotfix", EventDeployHotfix, `{"redacted_section":"mining_results"}`, "redaction_target", "mining_results"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			payload, err := DecodeEventPayload(tc.eventType, []byte(tc.stored), 0)
+			if err != nil {
+				t.Fatalf("Failed to decode legacy payload: %v", err)
+			}
+			if payload[tc.key] != tc.expected {
+				t.Errorf("Expected %s to be %v, got %v", tc.key, tc.expected, payload[tc.key])
+			}
+		})
+	}
+}
+
+func TestDecodeEventPayload_RejectsNewerVersion(t *testing.T) {
+	if _, err := DecodeEventPayload(EventPhaseChanged, []byte(`{}`), PayloadSchemaVersion+1); err == nil {
+		t.Error("Expected an error for a payload from a newer schema")
+	}
+}
+
+func TestApplyEvent_LegacyStreamReplays(t *testing.T) {
+	gameState := NewGameState("test-game")
+	gameState.Players["cfo"] = &Player{ID: "cfo", IsAlive: true}
+	gameState.Players["a"] = &Player{ID: "a", IsAlive: true, Tokens: 3}
+	gameState.Players["b"] = &Player{ID: "b", IsAlive: true, Tokens: 1}
+
+	stored := []struct {
+		eventType EventType
+		playerID  string
+		payload   string
+	}{
+		{EventPhaseChanged, "", `{"previous_phase":"LOBBY","next_phase":"SITREP","day_number":0}`},
+		{EventReallocateBudget, "cfo", `{"source_id":"a","target_id":"b","message":"moved"}`},
+	}
+
+	state := *gameState
+	for _, entry := range stored {
+		payload, err := DecodeEventPayload(entry.eventType, []byte(entry.payload), 0)
+		if err != nil {
+			t.Fatalf("Failed to decode %s: %v", entry.eventType, err)
+		}
+		state = ApplyEvent(state, Event{Type: entry.eventType, PlayerID: entry.playerID, Timestamp: time.Now(), Payload: payload})
+	}
+
+	if state.Phase.Type != PhaseSitrep || state.DayNumber != 1 {
+		t.Errorf("Expected legacy phase change to move to day 1 SITREP, got %s day %d", state.Phase.Type, state.DayNumber)
+	}
+	if state.Players["a"].Tokens != 2 || state.Players["b"].Tokens != 2 {
+		t.Errorf("Expected legacy reallocation to move one token, got a=%d b=%d", state.Players["a"].Tokens, state.Players["b"].Tokens)
+	}
+}
+
+func TestApplyEvent_NumericPayloadsAfterRoundTrip(t *testing.T) {
+	gameState := NewGameState("test-game")
+	gameState.Players["miner"] = &Player{ID: "miner", IsAlive: true}
+
+	event := Event{
+		Type:     EventMiningSuccessful,
+		PlayerID: "miner",
+		Payload:  map[string]interface{}{"amount": 2},
+	}
+
+	// Apply once with the producer's int, then again as it reads back from storage
+	newState := ApplyEvent(*gameState, event)
+
+	data, version, err := EncodeEventPayload(event)
+	if err != nil {
+		t.Fatalf("Failed to encode payload: %v", err)
+	}
+	event.Payload, err = DecodeEventPayload(event.Type, data, version)
+	if err != nil {
+		t.Fatalf("Failed to decode payload: %v", err)
+	}
+	newState = ApplyEvent(newState, event)
+
+	if newState.Players["miner"].Tokens != 4 {
+		t.Errorf("Expected int and float64 amounts to both apply, got %d tokens", newState.Players["miner"].Tokens)
+	}
+}
+
+func TestApplyEvent_GameCreatedSeedAfterRoundTrip(t *testing.T) {
+	// Larger than float64 can hold exactly
+	const seed int64 = 1<<62 + 1
+
+	event := Event{Type: EventGameCreated, Payload: EncodePayload(GameCreatedPayload{Seed: seed})}
+	data, version, err := EncodeEventPayload(event)
+	if err != nil {
+		t.Fatalf("Failed to encode payload: %v", err)
+	}
+	event.Payload, err = DecodeEventPayload(event.Type, data, version)
+	if err != nil {
+		t.Fatalf("Failed to decode payload: %v", err)
+	}
+
+	newState := ApplyEvent(*NewGameState("test-game"), event)
+	if newState.Seed != seed {
+		t.Errorf("Expected seed %d, got %d", seed, newState.Seed)
+	}
+}
+
+func TestApplyEvent_TypedPayloadsAfterRoundTrip(t *testing.T) {
+	gameState := NewGameState("test-game")
+	gameState.Players["ciso"] = &Player{ID: "ciso", Name: "Ciso", IsAlive: true}
+	gameState.Players["ai"] = &Player{ID: "ai", Name: "Ai", IsAlive: true, Alignment: "ALIGNED"}
+	gameState.Players["human"] = &Player{ID: "human", Name: "Human", IsAlive: true, Alignment: "HUMAN", AIEquity: 2}
+
+	events := []Event{
+		{Type: EventNightActionsResolved, Payload: EncodePayload(NightActionsResolvedPayload{TotalActions: 3, ResolvedEvents: 2, PhaseEnd: true})},
+		{Type: EventRunAudit, PlayerID: "ciso", Payload: EncodePayload(RunAuditPayload{TargetID: "ai", Result: "not_corrupt"})},
+		{Type: EventPlayerInvestigated, PlayerID: "ciso", Payload: EncodePayload(PlayerInvestigatedPayload{InvestigatorID: "ciso", TargetID: "ai", Alignment: "ALIGNED", Role: "UNKNOWN"})},
+		{Type: EventAIConversionSuccess, PlayerID: "human", Payload: EncodePayload(AIConversionSuccessPayload{ConverterID: "ai", TargetID: "human"})},
+		{Type: EventSystemMessage, Payload: EncodePayload(SystemMessagePayload{Message: "Night is over"})},
+	}
+
+	state := *gameState
+	for _, event := range events {
+		data, version, err := EncodeEventPayload(event)
+		if err != nil {
+			t.Fatalf("Failed to encode %s: %v", event.Type, err)
+		}
+		event.Payload, err = DecodeEventPayload(event.Type, data, version)
+		if err != nil {
+			t.Fatalf("Failed to decode %s: %v", event.Type, err)
+		}
+		state = ApplyEvent(state, event)
+	}
+
+	if ciso := state.Players["ciso"]; !ciso.HasUsedAbility || ciso.StatusMessage != "Audit completed" {
+		t.Errorf("Expected the audit to use the CISO's ability, got %+v", ciso)
+	}
+	if human := state.Players["human"]; human.Alignment != "ALIGNED" || human.AIEquity != 0 {
+		t.Errorf("Expected the target to be converted, got %+v", human)
+	}
+	if len(state.ChatMessages) != 1 || state.ChatMessages[0].Message != "Night is over" {
+		t.Errorf("Expected the system message in the chat log, got %+v", state.ChatMessages)
+	}
+
+	summary, err := DecodePayload[NightActionsResolvedPayload](events[0])
+	if err != nil || summary.TotalActions != 3 || summary.ResolvedEvents != 2 || !summary.PhaseEnd {
+		t.Errorf("Expected the night summary to decode, got %+v (%v)", summary, err)
+	}
+}
+
+func TestCrisisEvent_EffectInt(t *testing.T) {
+	crisis := &CrisisEvent{Effects: map[string]interface{}{"message_limit": 3}}
+	if limit, ok := crisis.EffectInt("message_limit"); !ok || limit != 3 {
+		t.Errorf("Expected int effect 3, got %d", limit)
+	}
+
+	data, err := json.Marshal(crisis)
+	if err != nil {
+		t.Fatalf("Failed to marshal crisis: %v", err)
+	}
+	var restored CrisisEvent
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatalf("Failed to unmarshal crisis: %v", err)
+	}
+	if limit, ok := restored.EffectInt("message_limit"); !ok || limit != 3 {
+		t.Errorf("Expected effect to survive a JSON round trip, got %d", limit)
+	}
+
+	if _, ok := restored.EffectInt("missing"); ok {
+		t.Error("Expected missing effect not to be found")
+	}
+
+	var none *CrisisEvent
+	if none.EffectBool("block_ai_conversions") {
+		t.Error("Expected no effects without an active crisis")
+	}
+}
@@ -0,0 +1,19 @@
+package core
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+)
+
+// Rand returns a random source for one decision in this game. It is derived
+// from the game's seed, the named stream and the number of events applied so
+// far, so the same seed and action log always produce the same draws, even
+// after the managers making them are recreated. Use a distinct stream for
+// each kind of decision so that draws made at the same point don't correlate.
+func (gs *GameState) Rand(stream string) *rand.Rand {
+	data := fmt.Sprintf("%d:%s:%d", gs.Seed, stream, gs.EventCount)
+	hash := sha256.Sum256([]byte(data))
+	return rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(hash[:8]))))
+}
@@ -0,0 +1,23 @@
+package core
+
+import "testing"
+
+func TestGameState_Rand(t *testing.T) {
+	draw := func(seed int64, eventCount int, stream string) int64 {
+		state := GameState{Seed: seed, EventCount: eventCount}
+		return state.Rand(stream).Int63()
+	}
+
+	if draw(42, 7, "crisis") != draw(42, 7, "crisis") {
+		t.Error("Expected the same seed, history and stream to draw the same value")
+	}
+	if draw(42, 7, "crisis") == draw(43, 7, "crisis") {
+		t.Error("Expected different seeds to draw different values")
+	}
+	if draw(42, 7, "crisis") == draw(42, 8, "crisis") {
+		t.Error("Expected later points in the game to draw different values")
+	}
+	if draw(42, 7, "crisis") == draw(42, 7, "pivot") {
+		t.Error("Expected different streams to draw different values")
+	}
+}
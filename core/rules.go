@@ -37,6 +37,9 @@ func CanPlayerVote(player Player, phase PhaseType) bool {
 	return phase == PhaseNomination || phase == PhaseVerdict || phase == PhaseExtension
 }
 
+// MaxChatMessageLength caps a public chat message, in characters
+const MaxChatMessageLength = 500
+
 // CanPlayerSendMessage checks if a player can send chat messages
 func CanPlayerSendMessage(player Player) bool {
 	if !player.IsAlive {
@@ -143,9 +146,9 @@ func CalculateMiningSuccess(player Player, difficulty float64, gameState GameSta
 		successRate = 0.9
 	}
 	
-	// Deterministic pseudo-random based on player ID hash and day number
+	// Deterministic pseudo-random based on the game seed, player ID and day number
 	// This ensures reproducible results for testing while maintaining randomness
-	hash := hashPlayerAction(player.ID, gameState.DayNumber, "MINE")
+	hash := hashPlayerAction(gameState.Seed, player.ID, gameState.DayNumber, "MINE")
 	random := float64(hash%10000) / 10000.0 // 0.0 to 0.9999
 	
 	return random < successRate
@@ -196,8 +199,8 @@ func CalculateAIConversionSuccess(target Player, aiEquity int, gameState GameSta
 		successRate = 0.8
 	}
 	
-	// Deterministic pseudo-random based on target player ID and day number
-	hash := hashPlayerAction(target.ID, gameState.DayNumber, "CONVERSION")
+	// Deterministic pseudo-random based on the game seed, target player ID and day number
+	hash := hashPlayerAction(gameState.Seed, target.ID, gameState.DayNumber, "CONVERSION")
 	random := float64(hash%10000) / 10000.0
 	
 	return random < successRate
@@ -347,9 +350,9 @@ func IsMessageCorrupted(player Player, messageContent string) bool {
 }
 
 // hashPlayerAction creates a deterministic hash for player actions
-// Used for reproducible "randomness" in testing while maintaining game balance
-func hashPlayerAction(playerID string, dayNumber int, action string) uint32 {
-	data := fmt.Sprintf("%s:%d:%s", playerID, dayNumber, action)
+// Salted with the game seed so each game rolls differently but replays identically
+func hashPlayerAction(seed int64, playerID string, dayNumber int, action string) uint32 {
+	data := fmt.Sprintf("%d:%s:%d:%s", seed, playerID, dayNumber, action)
 	hash := sha256.Sum256([]byte(data))
 	return binary.BigEndian.Uint32(hash[:4])
 }
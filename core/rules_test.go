@@ -793,24 +793,29 @@ func TestIsMessageCorrupted(t *testing.T) {
 
 func TestHashFunctions(t *testing.T) {
 	// Test that hash functions are deterministic
-	hash1 := hashPlayerAction("player-1", 1, "MINE")
-	hash2 := hashPlayerAction("player-1", 1, "MINE")
+	hash1 := hashPlayerAction(1, "player-1", 1, "MINE")
+	hash2 := hashPlayerAction(1, "player-1", 1, "MINE")
 	
 	if hash1 != hash2 {
 		t.Error("hashPlayerAction should be deterministic")
 	}
 
 	// Test that different inputs produce different hashes
-	hash3 := hashPlayerAction("player-2", 1, "MINE")
+	hash3 := hashPlayerAction(1, "player-2", 1, "MINE")
 	if hash1 == hash3 {
 		t.Error("Different player IDs should produce different hashes")
 	}
 
-	hash4 := hashPlayerAction("player-1", 2, "MINE")
+	hash4 := hashPlayerAction(1, "player-1", 2, "MINE")
 	if hash1 == hash4 {
 		t.Error("Different day numbers should produce different hashes")
 	}
 
+	hash5 := hashPlayerAction(2, "player-1", 1, "MINE")
+	if hash1 == hash5 {
+		t.Error("Different game seeds should produce different hashes")
+	}
+
 	// Test string hash function
 	stringHash1 := hashStringWithID("hello", "player-1")
 	stringHash2 := hashStringWithID("hello", "player-1")
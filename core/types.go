@@ -7,6 +7,7 @@ import (
 // Event represents a game event that changes state
 type Event struct {
 	ID        string                 `json:"id"`
+	Sequence  int                    `json:"sequence"` // Per-game position, assigned by the game actor
 	Type      EventType              `json:"type"`
 	GameID    string                 `json:"game_id"`
 	PlayerID  string                 `json:"player_id,omitempty"`
@@ -24,6 +25,9 @@ const (
 	EventGameEnded    EventType = "GAME_ENDED"
 	EventPhaseChanged EventType = "PHASE_CHANGED"
 
+	// Lobby events, broadcast before the game actor exists and never persisted
+	EventLobbyStateUpdated EventType = "LOBBY_STATE_UPDATED"
+
 	// Player events
 	EventPlayerJoined       EventType = "PLAYER_JOINED"
 	EventPlayerLeft         EventType = "PLAYER_LEFT"
@@ -62,6 +66,8 @@ const (
 	EventChatMessage         EventType = "CHAT_MESSAGE"
 	EventSystemMessage       EventType = "SYSTEM_MESSAGE"
 	EventPrivateNotification EventType = "PRIVATE_NOTIFICATION"
+	EventFactionMessage      EventType = "FACTION_MESSAGE"
+	EventFactionChatHistory  EventType = "FACTION_CHAT_HISTORY"
 
 	// Crisis and Special events
 	EventCrisisTriggered     EventType = "CRISIS_TRIGGERED"
@@ -71,6 +77,7 @@ const (
 	EventRoleAbilityUnlocked EventType = "ROLE_ABILITY_UNLOCKED"
 	EventProjectMilestone    EventType = "PROJECT_MILESTONE"
 	EventRoleAssigned        EventType = "ROLE_ASSIGNED"
+	EventSitrepGenerated     EventType = "SITREP_GENERATED"
 
 	// Mining and Economy events
 	EventMiningPoolUpdated EventType = "MINING_POOL_UPDATED"
@@ -88,6 +95,9 @@ const (
 	EventGameStateSnapshot   EventType = "GAME_STATE_SNAPSHOT"
 	EventPlayerReconnected   EventType = "PLAYER_RECONNECTED"
 	EventPlayerDisconnected  EventType = "PLAYER_DISCONNECTED"
+	EventSyncComplete        EventType = "SYNC_COMPLETE"
+	EventActionRejected      EventType = "ACTION_REJECTED" // Sent to the acting player only, never persisted
+	EventActionAck           EventType = "ACK"             // Sent to the acting player only, never persisted
 
 	// Win Condition events
 	EventVictoryCondition EventType = "VICTORY_CONDITION"
@@ -124,11 +134,13 @@ const (
 
 // Action represents a player action that can generate events
 type Action struct {
-	Type      ActionType             `json:"type"`
-	PlayerID  string                 `json:"player_id"`
-	GameID    string                 `json:"game_id"`
-	Timestamp time.Time              `json:"timestamp"`
-	Payload   map[string]interface{} `json:"payload"`
+	Type         ActionType             `json:"type"`
+	PlayerID     string                 `json:"player_id"`
+	GameID       string                 `json:"game_id"`
+	RequestID    string                 `json:"request_id,omitempty"` // Client correlation ID, echoed on ACK or ACTION_REJECTED
+	ConnectionID string                 `json:"-"`                    // Set by the server to the connection the action arrived on
+	Timestamp    time.Time              `json:"timestamp"`
+	Payload      map[string]interface{} `json:"payload"`
 }
 
 // ActionType represents different types of player actions
@@ -136,13 +148,16 @@ type ActionType string
 
 const (
 	// Lobby actions
-	ActionJoinGame  ActionType = "JOIN_GAME"
-	ActionLeaveGame ActionType = "LEAVE_GAME"
-	ActionStartGame ActionType = "START_GAME"
+	ActionJoinGame   ActionType = "JOIN_GAME"
+	ActionLeaveGame  ActionType = "LEAVE_GAME"
+	ActionStartGame  ActionType = "START_GAME"
+	ActionSetReady   ActionType = "SET_READY"
+	ActionKickPlayer ActionType = "KICK_PLAYER"
 
 	// Communication actions
-	ActionSendMessage      ActionType = "SEND_MESSAGE"
-	ActionSubmitPulseCheck ActionType = "SUBMIT_PULSE_CHECK"
+	ActionSendMessage        ActionType = "SEND_MESSAGE"
+	ActionSubmitPulseCheck   ActionType = "SUBMIT_PULSE_CHECK"
+	ActionSendFactionMessage ActionType = "SEND_FACTION_MESSAGE"
 
 	// Voting actions
 	ActionSubmitVote       ActionType = "SUBMIT_VOTE"
@@ -169,8 +184,27 @@ const (
 
 	// Meta actions
 	ActionReconnect ActionType = "RECONNECT"
+
+	// System actions, sent only by the server itself
+	ActionPhaseTransition ActionType = "PHASE_TRANSITION"
+	ActionPlayerAway      ActionType = "PLAYER_AWAY"
+	ActionPlayerBack      ActionType = "PLAYER_BACK"
+	ActionAwayTimeout     ActionType = "AWAY_TIMEOUT"
 )
 
+// SystemPlayerID is the PlayerID of system actions. Client IDs are generated
+// by the server, so no client can act as the system.
+const SystemPlayerID = "SYSTEM"
+
+// IsSystemAction reports whether actions of type t may only come from the server
+func IsSystemAction(t ActionType) bool {
+	switch t {
+	case ActionPhaseTransition, ActionPlayerAway, ActionPlayerBack, ActionAwayTimeout:
+		return true
+	}
+	return false
+}
+
 // Phase represents the current game phase
 type Phase struct {
 	Type      PhaseType     `json:"type"`
@@ -204,6 +238,7 @@ type Player struct {
 	ProjectMilestones int       `json:"project_milestones"`
 	StatusMessage     string    `json:"status_message"`
 	JoinedAt          time.Time `json:"joined_at"`
+	IsAway            bool      `json:"is_away,omitempty"` // All of the player's connections are closed
 
 	// Private fields (only visible to the player themselves)
 	Alignment       string       `json:"alignment,omitempty"` // "HUMAN" or "ALIGNED"
@@ -388,4 +423,4 @@ type SubmittedNightAction struct {
 	TargetID  string                 `json:"target_id"`
 	Payload   map[string]interface{} `json:"payload,omitempty"`
 	Timestamp time.Time              `json:"timestamp"`
-}
\ No newline at end of file
+}
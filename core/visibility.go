@@ -0,0 +1,178 @@
+package core
+
+// Visibility is the information tier of an event, as defined in
+// docs/api/03-information-visibility-model.md
+type Visibility string
+
+const (
+	VisibilityPublic    Visibility = "PUBLIC"    // Every player in the game
+	VisibilityPrivate   Visibility = "PRIVATE"   // Only the player the event is about
+	VisibilityFactional Visibility = "FACTIONAL" // Only members of the AI faction
+)
+
+// privateEventTypes are delivered only to the event's PlayerID
+var privateEventTypes = map[EventType]bool{
+	EventRoleAssigned:         true,
+	EventPrivateNotification:  true,
+	EventPlayerInvestigated:   true,
+	EventPlayerBlocked:        true, // Only the target learns who blocked them
+	EventPlayerProtected:      true,
+	EventPlayerShocked:        true,
+	EventSystemShockApplied:   true,
+	EventNightActionSubmitted: true,
+	EventMiningAttempted:      true,
+	EventMiningFailed:         true,
+	EventAIEquityChanged:      true,
+	EventKPIProgress:          true,
+	EventKPICompleted:         true,
+	EventFactionChatHistory:   true,
+	EventGameStateSnapshot:    true,
+	EventSyncComplete:         true,
+	EventActionRejected:       true,
+	EventActionAck:            true,
+	EventGameCreated:          true, // Carries the seed and has no PlayerID, so no client receives it
+}
+
+// factionalEventTypes are delivered only to the AI faction
+var factionalEventTypes = map[EventType]bool{
+	EventAIConversionAttempt: true,
+	EventAIConversionSuccess: true,
+	EventPlayerAligned:       true,
+	EventFactionMessage:      true,
+}
+
+// ClassifyEvent returns the visibility tier of an event
+func ClassifyEvent(event Event) Visibility {
+	if factionOnly, _ := event.Payload["ai_faction_only"].(bool); factionOnly {
+		return VisibilityFactional
+	}
+
+	if factionalEventTypes[event.Type] {
+		return VisibilityFactional
+	}
+
+	if privateEventTypes[event.Type] {
+		return VisibilityPrivate
+	}
+
+	// System messages addressed to a player are private feedback
+	if event.Type == EventSystemMessage && event.PlayerID != "" {
+		return VisibilityPrivate
+	}
+
+	return VisibilityPublic
+}
+
+// CanPlayerSeeEvent reports whether a player may receive an event, given the
+// state after the event was applied
+func CanPlayerSeeEvent(state GameState, event Event, playerID string) bool {
+	switch ClassifyEvent(event) {
+	case VisibilityPrivate:
+		return event.PlayerID == playerID
+	case VisibilityFactional:
+		return isAligned(state, playerID)
+	default:
+		return true
+	}
+}
+
+// RedactEvent returns the copy of a public event that is safe to broadcast,
+// with per-player details removed
+func RedactEvent(event Event) Event {
+	switch event.Type {
+	case EventVoteCast:
+		// Vote totals are public, who voted for whom is not
+		return withoutPayloadKeys(event, "target_id")
+	case EventIsolateNode:
+		// Only the AI faction may learn that an isolation fizzled
+		return withoutPayloadKeys(event, "fizzled")
+	}
+	return event
+}
+
+// ProjectStateForPlayer returns the view of the game state that a player is
+// allowed to see. Other players' secrets are stripped unless they have been
+// deactivated, and AI faction members can see each other's alignment.
+func ProjectStateForPlayer(state GameState, playerID string) GameState {
+	projected := state
+	viewerAligned := isAligned(state, playerID)
+
+	projected.Players = make(map[string]*Player, len(state.Players))
+	for id, player := range state.Players {
+		if id == playerID {
+			projected.Players[id] = player
+			continue
+		}
+
+		redacted := *player
+		redacted.Alignment = ""
+		redacted.Role = nil
+		redacted.PersonalKPI = nil
+		redacted.AIEquity = 0
+		redacted.HasUsedAbility = false
+		redacted.LastNightAction = nil
+		redacted.StatusMessage = ""
+		redacted.SystemShocks = nil
+
+		// A deactivated player's final role and alignment are public
+		if !player.IsAlive {
+			redacted.Role = player.Role
+			redacted.Alignment = player.Alignment
+		}
+
+		// The AI faction knows who its members are
+		if viewerAligned && player.Alignment == "ALIGNED" {
+			redacted.Alignment = player.Alignment
+		}
+
+		projected.Players[id] = &redacted
+	}
+
+	// Only the player's own night action is visible
+	projected.NightActions = make(map[string]*SubmittedNightAction)
+	if action, exists := state.NightActions[playerID]; exists {
+		projected.NightActions[playerID] = action
+	}
+
+	// Aggregate vote totals are public, individual votes are not
+	if state.VoteState != nil {
+		voteState := *state.VoteState
+		voteState.Votes = make(map[string]string)
+		if target, exists := state.VoteState.Votes[playerID]; exists {
+			voteState.Votes[playerID] = target
+		}
+		projected.VoteState = &voteState
+	}
+
+	if !viewerAligned {
+		projected.FactionChatMessages = nil
+	}
+
+	projected.BlockedPlayersTonight = nil
+	projected.ProtectedPlayersTonight = nil
+
+	// The seed would let a client predict every random draw
+	projected.Seed = 0
+
+	return projected
+}
+
+// isAligned reports whether a player is a member of the AI faction
+func isAligned(state GameState, playerID string) bool {
+	player, exists := state.Players[playerID]
+	return exists && player.Alignment == "ALIGNED"
+}
+
+// withoutPayloadKeys returns a copy of an event with the given payload keys removed
+func withoutPayloadKeys(event Event, keys ...string) Event {
+	payload := make(map[string]interface{}, len(event.Payload))
+	for key, value := range event.Payload {
+		payload[key] = value
+	}
+	for _, key := range keys {
+		delete(payload, key)
+	}
+
+	event.Payload = payload
+	return event
+}
@@ -0,0 +1,222 @@
+package core
+
+import (
+	"testing"
+)
+
+func newVisibilityTestState() GameState {
+	gameState := NewGameState("test-game")
+	gameState.Players["human"] = &Player{
+		ID:            "human",
+		Name:          "Alice",
+		IsAlive:       true,
+		Tokens:        3,
+		Alignment:     "HUMAN",
+		Role:          &Role{Type: RoleCISO, Name: "CISO"},
+		PersonalKPI:   &PersonalKPI{Type: KPIGuardian},
+		AIEquity:      2,
+		StatusMessage: "Protected by aligned",
+	}
+	gameState.Players["aligned"] = &Player{
+		ID:        "aligned",
+		Name:      "Bob",
+		IsAlive:   true,
+		Tokens:    2,
+		Alignment: "ALIGNED",
+		Role:      &Role{Type: RoleCTO, Name: "CTO"},
+	}
+	gameState.Players["fired"] = &Player{
+		ID:        "fired",
+		Name:      "Charlie",
+		IsAlive:   false,
+		Alignment: "HUMAN",
+		Role:      &Role{Type: RoleCFO, Name: "CFO"},
+	}
+	return *gameState
+}
+
+func TestClassifyEvent(t *testing.T) {
+	testCases := []struct {
+		name     string
+		event    Event
+		expected Visibility
+	}{
+		{"public join", Event{Type: EventPlayerJoined}, VisibilityPublic},
+		{"private role", Event{Type: EventRoleAssigned, PlayerID: "human"}, VisibilityPrivate},
+		{"factional conversion", Event{Type: EventAIConversionSuccess}, VisibilityFactional},
+		{"faction-only flag", Event{Type: EventRunAudit, Payload: map[string]interface{}{"ai_faction_only": true}}, VisibilityFactional},
+		{"public audit", Event{Type: EventRunAudit, Payload: map[string]interface{}{"result": "not_corrupt"}}, VisibilityPublic},
+		{"targeted system message", Event{Type: EventSystemMessage, PlayerID: "aligned"}, VisibilityPrivate},
+		{"game seed", Event{Type: EventGameCreated}, VisibilityPrivate},
+		{"block", Event{Type: EventPlayerBlocked, PlayerID: "human"}, VisibilityPrivate},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ClassifyEvent(tc.event); got != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestCanPlayerSeeEvent(t *testing.T) {
+	gameState := newVisibilityTestState()
+
+	roleEvent := Event{Type: EventRoleAssigned, PlayerID: "human"}
+	if !CanPlayerSeeEvent(gameState, roleEvent, "human") {
+		t.Error("Expected player to see their own role assignment")
+	}
+	if CanPlayerSeeEvent(gameState, roleEvent, "aligned") {
+		t.Error("Expected other players not to see a role assignment")
+	}
+
+	auditEvent := Event{
+		Type:    EventRunAudit,
+		Payload: map[string]interface{}{"true_alignment": "HUMAN", "ai_faction_only": true},
+	}
+	if !CanPlayerSeeEvent(gameState, auditEvent, "aligned") {
+		t.Error("Expected AI faction to see faction-only audit result")
+	}
+	if CanPlayerSeeEvent(gameState, auditEvent, "human") {
+		t.Error("Expected humans not to see faction-only audit result")
+	}
+}
+
+func TestRedactEvent_VoteCast(t *testing.T) {
+	event := Event{
+		Type:     EventVoteCast,
+		PlayerID: "human",
+		Payload:  map[string]interface{}{"target_id": "aligned", "vote_type": "NOMINATION"},
+	}
+
+	redacted := RedactEvent(event)
+
+	if _, exists := redacted.Payload["target_id"]; exists {
+		t.Error("Expected vote target to be removed from public vote event")
+	}
+	if redacted.Payload["vote_type"] != "NOMINATION" {
+		t.Error("Expected vote type to be kept")
+	}
+	if _, exists := event.Payload["target_id"]; !exists {
+		t.Error("Expected original event payload to be unchanged")
+	}
+}
+
+func TestProjectStateForPlayer_Human(t *testing.T) {
+	gameState := newVisibilityTestState()
+	gameState.VoteState = &VoteState{
+		Votes:   map[string]string{"human": "aligned", "aligned": "human"},
+		Results: map[string]int{"aligned": 3, "human": 2},
+	}
+	gameState.NightActions["aligned"] = &SubmittedNightAction{PlayerID: "aligned", Type: "CONVERT", TargetID: "human"}
+	gameState.Seed = 42
+
+	projected := ProjectStateForPlayer(gameState, "human")
+
+	if projected.Seed != 0 {
+		t.Error("Expected the game seed to be hidden")
+	}
+
+	self := projected.Players["human"]
+	if self.Role == nil || self.PersonalKPI == nil || self.AIEquity != 2 {
+		t.Error("Expected player to see their own secrets")
+	}
+
+	other := projected.Players["aligned"]
+	if other.Alignment != "" || other.Role != nil {
+		t.Errorf("Expected other player's alignment and role to be hidden, got %q", other.Alignment)
+	}
+	if other.Tokens != 2 {
+		t.Errorf("Expected public token count to be kept, got %d", other.Tokens)
+	}
+
+	fired := projected.Players["fired"]
+	if fired.Role == nil || fired.Alignment != "HUMAN" {
+		t.Error("Expected deactivated player's role and alignment to be public")
+	}
+
+	if len(projected.NightActions) != 0 {
+		t.Errorf("Expected other players' night actions to be hidden, got %d", len(projected.NightActions))
+	}
+
+	if len(projected.VoteState.Votes) != 1 || projected.VoteState.Votes["human"] != "aligned" {
+		t.Errorf("Expected only own vote to be visible, got %v", projected.VoteState.Votes)
+	}
+	if projected.VoteState.Results["aligned"] != 3 {
+		t.Error("Expected vote totals to be public")
+	}
+
+	// The source state must not be modified
+	if gameState.Players["aligned"].Alignment != "ALIGNED" || len(gameState.VoteState.Votes) != 2 {
+		t.Error("Expected projection not to modify the original state")
+	}
+}
+
+func TestProjectStateForPlayer_Aligned(t *testing.T) {
+	gameState := newVisibilityTestState()
+	gameState.Players["aligned2"] = &Player{ID: "aligned2", IsAlive: true, Alignment: "ALIGNED"}
+
+	projected := ProjectStateForPlayer(gameState, "aligned")
+
+	if projected.Players["aligned2"].Alignment != "ALIGNED" {
+		t.Error("Expected AI faction to see fellow aligned players")
+	}
+	if projected.Players["human"].Alignment != "" {
+		t.Error("Expected AI faction not to see human alignment")
+	}
+	if projected.Players["human"].StatusMessage != "" {
+		t.Error("Expected other players' status messages to be hidden")
+	}
+}
+
+func TestFactionChannel(t *testing.T) {
+	gameState := newVisibilityTestState()
+
+	newState := ApplyEvent(gameState, Event{
+		ID:       "faction-1",
+		Type:     EventFactionMessage,
+		PlayerID: "aligned",
+		Payload:  map[string]interface{}{"player_name": "Bob", "message": "hello"},
+	})
+
+	if len(newState.ChatMessages) != 0 {
+		t.Error("Expected faction message to stay out of the public chat")
+	}
+	if len(newState.FactionChatMessages) != 1 || newState.FactionChatMessages[0].Message != "hello" {
+		t.Fatalf("Expected faction message to be stored, got %v", newState.FactionChatMessages)
+	}
+
+	if len(ProjectStateForPlayer(newState, "human").FactionChatMessages) != 0 {
+		t.Error("Expected humans not to see the faction channel")
+	}
+
+	// Conversion brings the player into the channel with its full history
+	newState = ApplyEvent(newState, Event{ID: "convert-1", Type: EventAIConversionSuccess, PlayerID: "human"})
+
+	projected := ProjectStateForPlayer(newState, "human")
+	if len(projected.FactionChatMessages) != 2 {
+		t.Errorf("Expected converted player to see history and join notice, got %d messages", len(projected.FactionChatMessages))
+	}
+}
+
+func TestSystemMessageVisibility(t *testing.T) {
+	gameState := newVisibilityTestState()
+
+	newState := ApplyEvent(gameState, Event{
+		ID:      "system-1",
+		Type:    EventSystemMessage,
+		Payload: map[string]interface{}{"message": "The servers hum back to life."},
+	})
+	newState = ApplyEvent(newState, Event{
+		ID:       "system-2",
+		Type:     EventSystemMessage,
+		PlayerID: "aligned",
+		Payload:  map[string]interface{}{"message": "Conversion attempt blocked by protection"},
+	})
+
+	projected := ProjectStateForPlayer(newState, "human")
+	if len(projected.ChatMessages) != 1 || projected.ChatMessages[0].ID != "system-1" {
+		t.Errorf("Expected only the public system message in the chat log, got %v", projected.ChatMessages)
+	}
+}
@@ -7,12 +7,16 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
+	"time"
 
 	"github.com/xjhc/alignment/core"
 	"github.com/xjhc/alignment/server/internal/actors"
+	"github.com/xjhc/alignment/server/internal/ai"
 	"github.com/xjhc/alignment/server/internal/comms"
 	"github.com/xjhc/alignment/server/internal/game"
+	"github.com/xjhc/alignment/server/internal/mcp"
 	"github.com/xjhc/alignment/server/internal/store"
 	"github.com/google/uuid"
 )
@@ -21,8 +25,10 @@ import (
 type Server struct {
 	supervisor *actors.Supervisor
 	wsManager  *comms.WebSocketManager
+	mcpServer  *mcp.Server
 	datastore  *store.RedisDataStore
 	scheduler  *game.Scheduler
+	llmCost    *ai.CostCounter // nil when AI players do not chat
 }
 
 // NewServer creates a new server instance
@@ -41,36 +47,84 @@ func NewServer() (*Server, error) {
 		return nil, fmt.Errorf("failed to create datastore: %w", err)
 	}
 
-	// Create scheduler
-	scheduler := game.NewScheduler(nil) // Timer callback will be set later
+	// Session tokens are signed with SESSION_SECRET so they survive restarts
+	sessionSecret := []byte(os.Getenv("SESSION_SECRET"))
+	if len(sessionSecret) == 0 {
+		log.Println("SESSION_SECRET not set, generating a random secret; sessions will not survive a restart")
+		sessionSecret, err = comms.GenerateSessionSecret()
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	// Create WebSocket manager with action handler
 	actionHandler := &ActionHandler{}
-	wsManager := comms.NewWebSocketManager(actionHandler)
+	wsManager := comms.NewWebSocketManager(actionHandler, comms.NewSessionTokens(sessionSecret))
 
 	// Create supervisor
 	supervisor := actors.NewSupervisor(datastore, wsManager)
 
 	// Wire dependencies
 	actionHandler.supervisor = supervisor
+	wsManager.SetPresenceListener(actionHandler)
 
-	// Set scheduler callback
-	scheduler = game.NewScheduler(func(timer game.Timer) {
-		if supervisor != nil {
-			handleTimerExpired(supervisor, timer)
-		}
+	// Phase timers are scheduled by game actors and routed back through the supervisor
+	scheduler := game.NewScheduler(func(timer game.Timer) {
+		handleTimerExpired(supervisor, timer)
+	})
+	supervisor.SetScheduler(scheduler)
+
+	// Seats of disconnected players are covered once their grace period ends
+	supervisor.SetAwayPolicy(actors.AwayPolicy{
+		GracePeriod: envDuration("AWAY_GRACE_PERIOD", 90*time.Second),
+		Takeover:    os.Getenv("AWAY_TAKEOVER") != "",
 	})
 
+	// AI players chat only when a language model is configured
+	var llmCost *ai.CostCounter
+	if baseURL := os.Getenv("LLM_BASE_URL"); baseURL != "" {
+		llmCost = ai.NewCostCounter(
+			envFloat("LLM_PROMPT_PRICE_PER_1K"),
+			envFloat("LLM_COMPLETION_PRICE_PER_1K"),
+			envFloat("LLM_BUDGET_USD"),
+		)
+		client := ai.NewOpenAIClient(baseURL, os.Getenv("LLM_API_KEY"), os.Getenv("LLM_MODEL"))
+		supervisor.SetLanguageModel(client, ai.DefaultChatLimits(), llmCost)
+		log.Printf("AI chat enabled with model %q at %s", os.Getenv("LLM_MODEL"), baseURL)
+	}
+
 	server := &Server{
 		supervisor: supervisor,
 		wsManager:  wsManager,
+		mcpServer:  mcp.NewServer(supervisor),
 		datastore:  datastore,
 		scheduler:  scheduler,
+		llmCost:    llmCost,
 	}
 
 	return server, nil
 }
 
+// envFloat reads an optional numeric setting, treating a missing or
+// malformed value as zero
+func envFloat(name string) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(name), 64)
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+// envDuration reads an optional duration setting such as "90s", falling back
+// to def when it is missing or malformed
+func envDuration(name string, def time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(name))
+	if err != nil {
+		return def
+	}
+	return value
+}
+
 // Start starts all server components
 func (s *Server) Start() {
 	log.Println("Starting Alignment game server...")
@@ -106,10 +160,13 @@ func (ah *ActionHandler) HandleAction(action core.Action) error {
 	switch action.Type {
 	case core.ActionJoinGame:
 		return ah.handleJoinGame(action)
-	case core.ActionStartGame:
-		return ah.handleStartGame(action)
 	default:
-		// Forward to game actor
+		// Games that have not started are run by their lobby
+		if lobby, exists := ah.supervisor.GetLobby(action.GameID); exists {
+			lobby.SendAction(action)
+			return nil
+		}
+		// Forward to game actor; for RECONNECT it replays missed events and sends SYNC_COMPLETE
 		if actor, exists := ah.supervisor.GetActor(action.GameID); exists {
 			actor.SendAction(action)
 			return nil
@@ -118,32 +175,43 @@ func (ah *ActionHandler) HandleAction(action core.Action) error {
 	}
 }
 
+// PlayerConnected tells a running game that a player has come back. Lobbies
+// track their roster themselves, so they are not told.
+func (ah *ActionHandler) PlayerConnected(gameID, playerID string) {
+	if actor, exists := ah.supervisor.GetActor(gameID); exists {
+		actor.PlayerConnected(playerID)
+	}
+}
+
+// PlayerDisconnected tells a running game that a player's last connection closed
+func (ah *ActionHandler) PlayerDisconnected(gameID, playerID string) {
+	if actor, exists := ah.supervisor.GetActor(gameID); exists {
+		actor.PlayerDisconnected(playerID)
+	}
+}
+
 func (ah *ActionHandler) handleJoinGame(action core.Action) error {
 	gameID := action.GameID
 
-	// Create game if it doesn't exist
-	if _, exists := ah.supervisor.GetActor(gameID); !exists {
-		err := ah.supervisor.CreateGame(gameID)
-		if err != nil {
-			return fmt.Errorf("failed to create game: %w", err)
-		}
+	// Players join through the lobby; once the game has started they can only reconnect
+	if _, exists := ah.supervisor.GetActor(gameID); exists {
+		return fmt.Errorf("game %s has already started", gameID)
 	}
 
-	// Forward action to game actor
-	if actor, exists := ah.supervisor.GetActor(gameID); exists {
-		actor.SendAction(action)
-		return nil
+	// Create the lobby if it doesn't exist
+	if _, exists := ah.supervisor.GetLobby(gameID); !exists {
+		err := ah.supervisor.CreateLobby(gameID)
+		if err != nil && err != actors.ErrGameAlreadyExists {
+			return fmt.Errorf("failed to create lobby: %w", err)
+		}
 	}
 
-	return fmt.Errorf("failed to get game actor after creation")
-}
-
-func (ah *ActionHandler) handleStartGame(action core.Action) error {
-	if actor, exists := ah.supervisor.GetActor(action.GameID); exists {
-		actor.SendAction(action)
+	if lobby, exists := ah.supervisor.GetLobby(gameID); exists {
+		lobby.SendAction(action)
 		return nil
 	}
-	return fmt.Errorf("game %s not found", action.GameID)
+
+	return fmt.Errorf("failed to get lobby after creation")
 }
 
 // HTTP handlers
@@ -153,6 +221,7 @@ func (s *Server) setupRoutes() {
 	http.HandleFunc("/api/games", s.gamesHandler)
 	http.HandleFunc("/api/games/create", s.createGameHandler)
 	http.HandleFunc("/api/stats", s.statsHandler)
+	http.Handle("/mcp", s.mcpServer)
 }
 
 func (s *Server) healthHandler(w http.ResponseWriter, r *http.Request) {
@@ -193,8 +262,8 @@ func (s *Server) createGameHandler(w http.ResponseWriter, r *http.Request) {
 	// Generate new game ID
 	gameID := uuid.New().String()
 
-	// Create game actor
-	err := s.supervisor.CreateGame(gameID)
+	// Games start in a lobby until the host starts them
+	err := s.supervisor.CreateLobby(gameID)
 	if err != nil {
 		http.Error(w, "Failed to create game", http.StatusInternalServerError)
 		return
@@ -214,6 +283,9 @@ func (s *Server) statsHandler(w http.ResponseWriter, r *http.Request) {
 		"supervisor": s.supervisor.GetStats(),
 		"scheduler":  len(s.scheduler.GetActiveTimers()),
 	}
+	if s.llmCost != nil {
+		stats["llm"] = s.llmCost.Totals()
+	}
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(stats)
@@ -227,7 +299,7 @@ func handleTimerExpired(supervisor *actors.Supervisor, timer game.Timer) {
 	action := core.Action{
 		Type:     core.ActionType(timer.Action.Type),
 		GameID:   timer.GameID,
-		PlayerID: "SYSTEM",
+		PlayerID: core.SystemPlayerID,
 		Payload:  timer.Action.Payload,
 	}
 
@@ -255,6 +327,18 @@ func main() {
 	// Start server components
 	server.Start()
 
+	// With MCP_STDIO set, a local model or test harness can also speak MCP over
+	// stdin and stdout, so everything else printed goes to stderr
+	out := os.Stdout
+	if os.Getenv("MCP_STDIO") != "" {
+		out = os.Stderr
+		go func() {
+			if err := server.mcpServer.ServeStdio(os.Stdin, os.Stdout); err != nil {
+				log.Printf("MCP stdio stopped: %v", err)
+			}
+		}()
+	}
+
 	// Setup graceful shutdown
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
@@ -266,13 +350,14 @@ func main() {
 		os.Exit(0)
 	}()
 
-	fmt.Printf("Alignment server starting on port %s\n", port)
-	fmt.Println("WebSocket endpoint: /ws")
-	fmt.Println("API endpoints:")
-	fmt.Println("  GET  /health")
-	fmt.Println("  GET  /api/games")
-	fmt.Println("  POST /api/games/create")
-	fmt.Println("  GET  /api/stats")
+	fmt.Fprintf(out, "Alignment server starting on port %s\n", port)
+	fmt.Fprintln(out, "WebSocket endpoint: /ws")
+	fmt.Fprintln(out, "API endpoints:")
+	fmt.Fprintln(out, "  GET  /health")
+	fmt.Fprintln(out, "  GET  /api/games")
+	fmt.Fprintln(out, "  POST /api/games/create")
+	fmt.Fprintln(out, "  GET  /api/stats")
+	fmt.Fprintln(out, "  POST /mcp (read-only MCP resources)")
 
 	log.Printf("Server listening on :%s", port)
 	if err := http.ListenAndServe(":"+port, nil); err != nil {
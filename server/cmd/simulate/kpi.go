@@ -0,0 +1,88 @@
+package main
+
+import "github.com/xjhc/alignment/core"
+
+// kpiTracker judges personal KPIs from the events of a game. The server only
+// marks a KPI complete when a KPI_COMPLETED event says so, and no manager
+// emits one yet, so the simulator checks each objective itself.
+type kpiTracker struct {
+	nominationVotes map[string]string // Voter -> target in the running nomination
+	correctVotes    map[string]int    // Voter -> nominations of a player who was then deactivated
+	scapegoats      map[string]bool   // Players deactivated by a unanimous nomination
+	cisoAliveOnDay  int               // Latest day the CISO was seen alive
+}
+
+func newKPITracker() *kpiTracker {
+	return &kpiTracker{
+		nominationVotes: make(map[string]string),
+		correctVotes:    make(map[string]int),
+		scapegoats:      make(map[string]bool),
+	}
+}
+
+// observe records what an event means for the KPIs. state is the game after
+// the action that produced the event.
+func (k *kpiTracker) observe(state *core.GameState, event core.Event) {
+	switch event.Type {
+	case core.EventVoteStarted:
+		payload, _ := core.DecodePayload[core.VotePayload](event)
+		if payload.VoteType == core.VoteNomination {
+			k.nominationVotes = make(map[string]string)
+		}
+	case core.EventVoteCast:
+		payload, _ := core.DecodePayload[core.VotePayload](event)
+		if payload.VoteType == core.VoteNomination {
+			k.nominationVotes[event.PlayerID] = payload.TargetID
+		}
+	case core.EventPlayerEliminated:
+		for voterID, targetID := range k.nominationVotes {
+			if targetID == event.PlayerID {
+				k.correctVotes[voterID]++
+			}
+		}
+		if player, exists := state.Players[event.PlayerID]; exists {
+			k.scapegoats[event.PlayerID] = core.CheckScapegoatKPI(*player, core.VoteState{Votes: k.nominationVotes})
+		}
+	}
+
+	for _, player := range state.Players {
+		if player.IsAlive && player.Role != nil && player.Role.Type == core.RoleCISO {
+			k.cisoAliveOnDay = state.DayNumber
+		}
+	}
+}
+
+// completed reports whether a player met their KPI by the end of the game,
+// following the objectives in the game design document
+func (k *kpiTracker) completed(state *core.GameState, player *core.Player) bool {
+	kpi := player.PersonalKPI
+	if kpi.IsCompleted {
+		return true
+	}
+
+	switch kpi.Type {
+	case core.KPICapitalist:
+		for _, other := range state.Players {
+			if other.ID != player.ID && other.Tokens >= player.Tokens {
+				return false
+			}
+		}
+		return true
+	case core.KPIGuardian:
+		return k.cisoAliveOnDay >= kpi.Target
+	case core.KPIInquisitor:
+		return k.correctVotes[player.ID] >= kpi.Target
+	case core.KPISuccessionPlanner:
+		// Judged as CheckWinCondition does, which ends the game on it
+		humans := 0
+		for _, other := range state.Players {
+			if other.IsAlive && other.Alignment != "ALIGNED" {
+				humans++
+			}
+		}
+		return player.IsAlive && player.Alignment != "ALIGNED" && humans == 2
+	case core.KPIScapegoat:
+		return k.scapegoats[player.ID]
+	}
+	return false
+}
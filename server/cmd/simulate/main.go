@@ -0,0 +1,111 @@
+// Command simulate plays complete games in-process with bots in every seat
+// and reports how they turned out, for tuning the game's numbers. It uses the
+// real game actor and managers with no Redis or WebSocket behind them.
+//
+//	go run ./cmd/simulate -games 5000 -players 8 -bots suspicious-voter,ai-converter -csv games.csv
+//
+// Seats take the -bots strategies in turn, so a mix can be compared within
+// the same games. Game i is played with seed -seed+i, so a run with the same
+// flags always produces the same results.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/xjhc/alignment/server/internal/ai"
+)
+
+func main() {
+	games := flag.Int("games", 1000, "number of games to play")
+	players := flag.Int("players", 6, "bots per game")
+	bots := flag.String("bots", "ai-converter", "comma-separated strategies seats take in turn: "+strings.Join(ai.StrategyNames(), ", "))
+	seed := flag.Int64("seed", 1, "seed of the first game; later games count up from it")
+	workers := flag.Int("workers", runtime.NumCPU(), "games to play in parallel")
+	csvPath := flag.String("csv", "", "write one row per player per game to this CSV file")
+	jsonPath := flag.String("json", "", "write the summary and every game to this JSON file")
+	verbose := flag.Bool("v", false, "keep the game actors' logs")
+	flag.Parse()
+
+	if *games < 1 || *players < 1 || *workers < 1 {
+		log.Fatal("-games, -players and -workers must be positive")
+	}
+	if !*verbose {
+		log.SetOutput(io.Discard)
+	}
+
+	results, err := simulateGames(*seed, *games, *players, strings.Split(*bots, ","), *workers)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	summary := summarize(results, *players)
+	writeText(os.Stdout, summary)
+
+	if *csvPath != "" {
+		if err := writeFile(*csvPath, func(w io.Writer) error { return writeCSV(w, results) }); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+	if *jsonPath != "" {
+		if err := writeFile(*jsonPath, func(w io.Writer) error { return writeJSON(w, summary, results) }); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+}
+
+// simulateGames plays games seed, seed+1, ... across workers goroutines and
+// returns the results in seed order
+func simulateGames(seed int64, games, players int, strategies []string, workers int) ([]GameResult, error) {
+	results := make([]GameResult, games)
+	errs := make([]error, games)
+	next := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				results[i], errs[i] = simulateGame(seed+int64(i), players, strategies)
+			}
+		}()
+	}
+
+	for i := 0; i < games; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// writeFile creates path and fills it with write
+func writeFile(path string, write func(io.Writer) error) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if err := write(file); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to close %s: %w", path, err)
+	}
+	return nil
+}
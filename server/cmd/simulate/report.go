@@ -0,0 +1,259 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// Summary aggregates a batch of simulated games
+type Summary struct {
+	Games       int                    `json:"games"`
+	Players     int                    `json:"players"`
+	WinRates    map[string]float64     `json:"win_rates"`  // Winner -> share of games; "" for games that hit maxDays
+	Conditions  map[string]float64     `json:"conditions"` // Win condition -> share of games
+	AverageDays float64                `json:"average_days"`
+	Eliminated  float64                `json:"average_eliminated"`
+	Conversions float64                `json:"average_conversions"`
+	KPIs        map[string]KPISummary  `json:"kpis"`
+	Tokens      map[string]Percentiles `json:"tokens"` // Final alignment -> token distribution
+	Strategies  map[string]SeatSummary `json:"strategies"`
+}
+
+// SeatSummary is how often seats played by one strategy ended on the winning side
+type SeatSummary struct {
+	Seats   int     `json:"seats"`
+	Won     int     `json:"won"`
+	WinRate float64 `json:"win_rate"`
+}
+
+// KPISummary is how often one KPI was dealt and completed
+type KPISummary struct {
+	Dealt          int     `json:"dealt"`
+	Completed      int     `json:"completed"`
+	CompletionRate float64 `json:"completion_rate"`
+}
+
+// Percentiles describes a distribution of token counts
+type Percentiles struct {
+	Count int     `json:"count"`
+	Mean  float64 `json:"mean"`
+	Min   int     `json:"min"`
+	P25   int     `json:"p25"`
+	P50   int     `json:"p50"`
+	P75   int     `json:"p75"`
+	P90   int     `json:"p90"`
+	Max   int     `json:"max"`
+}
+
+// summarize aggregates the results of a batch
+func summarize(results []GameResult, players int) Summary {
+	summary := Summary{
+		Games:      len(results),
+		Players:    players,
+		WinRates:   make(map[string]float64),
+		Conditions: make(map[string]float64),
+		KPIs:       make(map[string]KPISummary),
+		Tokens:     make(map[string]Percentiles),
+		Strategies: make(map[string]SeatSummary),
+	}
+	if len(results) == 0 {
+		return summary
+	}
+
+	tokens := make(map[string][]int)
+	for _, result := range results {
+		summary.WinRates[result.Winner]++
+		summary.Conditions[result.Condition]++
+		summary.AverageDays += float64(result.Days)
+		summary.Eliminated += float64(result.Eliminated)
+		summary.Conversions += float64(result.Conversions)
+
+		for _, player := range result.Players {
+			tokens[player.Alignment] = append(tokens[player.Alignment], player.Tokens)
+			tokens["ALL"] = append(tokens["ALL"], player.Tokens)
+
+			seat := summary.Strategies[player.Strategy]
+			seat.Seats++
+			if wonGame(result.Winner, player.Alignment) {
+				seat.Won++
+			}
+			summary.Strategies[player.Strategy] = seat
+
+			if player.KPI == "" {
+				continue
+			}
+			kpi := summary.KPIs[string(player.KPI)]
+			kpi.Dealt++
+			if player.KPICompleted {
+				kpi.Completed++
+			}
+			summary.KPIs[string(player.KPI)] = kpi
+		}
+	}
+
+	games := float64(len(results))
+	for winner := range summary.WinRates {
+		summary.WinRates[winner] /= games
+	}
+	for condition := range summary.Conditions {
+		summary.Conditions[condition] /= games
+	}
+	summary.AverageDays /= games
+	summary.Eliminated /= games
+	summary.Conversions /= games
+
+	for kpiType, kpi := range summary.KPIs {
+		kpi.CompletionRate = float64(kpi.Completed) / float64(kpi.Dealt)
+		summary.KPIs[kpiType] = kpi
+	}
+	for strategy, seat := range summary.Strategies {
+		seat.WinRate = float64(seat.Won) / float64(seat.Seats)
+		summary.Strategies[strategy] = seat
+	}
+	for alignment, counts := range tokens {
+		summary.Tokens[alignment] = percentiles(counts)
+	}
+	return summary
+}
+
+// wonGame reports whether a player who finished with alignment was on the winning side
+func wonGame(winner, alignment string) bool {
+	if alignment == "ALIGNED" {
+		return winner == "AI"
+	}
+	return winner == "HUMANS"
+}
+
+// percentiles describes counts using the nearest-rank method
+func percentiles(counts []int) Percentiles {
+	sort.Ints(counts)
+	rank := func(p float64) int {
+		index := int(p*float64(len(counts))+0.5) - 1
+		if index < 0 {
+			index = 0
+		}
+		return counts[index]
+	}
+
+	total := 0
+	for _, count := range counts {
+		total += count
+	}
+	return Percentiles{
+		Count: len(counts),
+		Mean:  float64(total) / float64(len(counts)),
+		Min:   counts[0],
+		P25:   rank(0.25),
+		P50:   rank(0.50),
+		P75:   rank(0.75),
+		P90:   rank(0.90),
+		Max:   counts[len(counts)-1],
+	}
+}
+
+// writeText prints a summary for a person to read
+func writeText(w io.Writer, summary Summary) {
+	fmt.Fprintf(w, "%d games, %d players each\n\n", summary.Games, summary.Players)
+
+	fmt.Fprintln(w, "Win rates:")
+	for _, winner := range sortedKeys(summary.WinRates) {
+		label := winner
+		if label == "" {
+			label = "(no winner)"
+		}
+		fmt.Fprintf(w, "  %-12s %6.1f%%\n", label, 100*summary.WinRates[winner])
+	}
+	for _, condition := range sortedKeys(summary.Conditions) {
+		if condition != "" {
+			fmt.Fprintf(w, "  by %-22s %6.1f%%\n", condition, 100*summary.Conditions[condition])
+		}
+	}
+
+	fmt.Fprintf(w, "\nAverage length: %.2f days, %.2f deactivations, %.2f conversions\n",
+		summary.AverageDays, summary.Eliminated, summary.Conversions)
+
+	fmt.Fprintln(w, "\nKPI completion:")
+	for _, kpiType := range sortedKeys(summary.KPIs) {
+		kpi := summary.KPIs[kpiType]
+		fmt.Fprintf(w, "  %-20s %6.1f%% (%d of %d)\n", kpiType, 100*kpi.CompletionRate, kpi.Completed, kpi.Dealt)
+	}
+
+	fmt.Fprintln(w, "\nSeats on the winning side, by strategy:")
+	for _, strategy := range sortedKeys(summary.Strategies) {
+		seat := summary.Strategies[strategy]
+		fmt.Fprintf(w, "  %-20s %6.1f%% (%d of %d)\n", strategy, 100*seat.WinRate, seat.Won, seat.Seats)
+	}
+
+	fmt.Fprintln(w, "\nFinal tokens:")
+	fmt.Fprintf(w, "  %-8s %6s %6s %4s %4s %4s %4s %4s %4s\n", "", "count", "mean", "min", "p25", "p50", "p75", "p90", "max")
+	for _, alignment := range sortedKeys(summary.Tokens) {
+		p := summary.Tokens[alignment]
+		fmt.Fprintf(w, "  %-8s %6d %6.2f %4d %4d %4d %4d %4d %4d\n", alignment, p.Count, p.Mean, p.Min, p.P25, p.P50, p.P75, p.P90, p.Max)
+	}
+}
+
+// writeJSON writes the summary together with every game's result
+func writeJSON(w io.Writer, summary Summary, results []GameResult) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(struct {
+		Summary Summary      `json:"summary"`
+		Games   []GameResult `json:"games"`
+	}{summary, results}); err != nil {
+		return fmt.Errorf("failed to encode results: %w", err)
+	}
+	return nil
+}
+
+// csvHeader names the columns of writeCSV, one row per player per game
+var csvHeader = []string{"seed", "winner", "condition", "days", "events", "eliminated", "conversions",
+	"player_id", "strategy", "role", "alignment", "alive", "tokens", "kpi", "kpi_completed"}
+
+// writeCSV writes one row per player per game, ready for a spreadsheet
+func writeCSV(w io.Writer, results []GameResult) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, result := range results {
+		for _, player := range result.Players {
+			row := []string{
+				strconv.FormatInt(result.Seed, 10),
+				result.Winner,
+				result.Condition,
+				strconv.Itoa(result.Days),
+				strconv.Itoa(result.Events),
+				strconv.Itoa(result.Eliminated),
+				strconv.Itoa(result.Conversions),
+				player.ID,
+				player.Strategy,
+				string(player.Role),
+				player.Alignment,
+				strconv.FormatBool(player.Alive),
+				strconv.Itoa(player.Tokens),
+				string(player.KPI),
+				strconv.FormatBool(player.KPICompleted),
+			}
+			if err := writer.Write(row); err != nil {
+				return fmt.Errorf("failed to write CSV row: %w", err)
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/xjhc/alignment/core"
+	"github.com/xjhc/alignment/server/internal/actors"
+	"github.com/xjhc/alignment/server/internal/ai"
+)
+
+// maxDays stops a game that the win conditions somehow never end
+const maxDays = 30
+
+// dayPhases are the phases a day moves through after SITREP
+var dayPhases = []core.PhaseType{
+	core.PhasePulseCheck, core.PhaseDiscussion, core.PhaseExtension, core.PhaseNomination,
+	core.PhaseTrial, core.PhaseVerdict, core.PhaseNight, core.PhaseSitrep,
+}
+
+// GameResult summarises one simulated game
+type GameResult struct {
+	Seed        int64          `json:"seed,string"`
+	Winner      string         `json:"winner"` // HUMANS, AI, or empty if the game hit maxDays
+	Condition   string         `json:"condition"`
+	Days        int            `json:"days"`
+	Events      int            `json:"events"`
+	Eliminated  int            `json:"eliminated"`
+	Conversions int            `json:"conversions"`
+	Players     []PlayerResult `json:"players"`
+}
+
+// PlayerResult is one player's position at the end of a game
+type PlayerResult struct {
+	ID           string        `json:"id"`
+	Strategy     string        `json:"strategy"`
+	Role         core.RoleType `json:"role"`
+	Alignment    string        `json:"alignment"`
+	Alive        bool          `json:"alive"`
+	Tokens       int           `json:"tokens"`
+	KPI          core.KPIType  `json:"kpi,omitempty"`
+	KPICompleted bool          `json:"kpi_completed"`
+}
+
+// simulation plays one game by driving a GameActor synchronously, with every
+// seat taken by a bot
+type simulation struct {
+	actor  *actors.GameActor
+	seats  map[string]ai.Strategy
+	result GameResult
+	kpis   *kpiTracker
+}
+
+// simulateGame plays a complete game with the given seed and number of bots.
+// Seats take the named strategies in turn. The same seed always plays out the
+// same way.
+func simulateGame(seed int64, players int, strategies []string) (GameResult, error) {
+	actor := actors.NewGameActor(fmt.Sprintf("sim-%d", seed), discardStore{}, discardBroadcaster{})
+	sim := &simulation{
+		actor:  actor,
+		seats:  make(map[string]ai.Strategy, players),
+		result: GameResult{Seed: seed},
+		kpis:   newKPITracker(),
+	}
+	actor.RecordSeed(seed)
+
+	// Each bot's choices derive from the game seed
+	seatSeeds := rand.New(rand.NewSource(seed))
+	for i := 1; i <= players; i++ {
+		playerID := fmt.Sprintf("bot-%d", i)
+		strategy, err := ai.NewStrategy(strategies[(i-1)%len(strategies)], seatSeeds.Int63())
+		if err != nil {
+			return GameResult{}, fmt.Errorf("failed to seat %s: %w", playerID, err)
+		}
+		sim.seats[playerID] = strategy
+		sim.act(core.ActionJoinGame, playerID, map[string]interface{}{"name": fmt.Sprintf("Bot %d", i)})
+	}
+	sim.act(core.ActionStartGame, "bot-1", nil)
+
+	for !sim.over() && sim.state().DayNumber <= maxDays {
+		sim.playDay()
+	}
+
+	sim.finish()
+	return sim.result, nil
+}
+
+func (s *simulation) state() *core.GameState {
+	return s.actor.State()
+}
+
+func (s *simulation) over() bool {
+	return s.state().Phase.Type == core.PhaseGameOver
+}
+
+// act submits an action and feeds the events it produced to the statistics
+func (s *simulation) act(actionType core.ActionType, playerID string, payload map[string]interface{}) {
+	if payload == nil {
+		payload = make(map[string]interface{})
+	}
+	events := s.actor.Step(core.Action{
+		Type:      actionType,
+		PlayerID:  playerID,
+		GameID:    s.state().ID,
+		Timestamp: time.Now(),
+		Payload:   payload,
+	})
+	for _, event := range events {
+		s.observe(event)
+	}
+}
+
+// transition ends the current phase the way its timer would
+func (s *simulation) transition(next core.PhaseType) {
+	s.act(core.ActionPhaseTransition, core.SystemPlayerID, map[string]interface{}{
+		"current_phase": string(s.state().Phase.Type),
+		"next_phase":    string(next),
+	})
+}
+
+// playDay runs one day from SITREP through the night, stopping early if the game ends
+func (s *simulation) playDay() {
+	for _, next := range dayPhases {
+		if s.over() {
+			return
+		}
+		s.transition(next)
+		s.takeTurns()
+	}
+}
+
+// takeTurns asks every living bot for its actions in the new phase. Each bot
+// sees the game as its own client would, including earlier bots' actions.
+func (s *simulation) takeTurns() {
+	for _, id := range s.ids() {
+		if !s.state().Players[id].IsAlive {
+			continue
+		}
+		for _, action := range s.seats[id].Act(ai.NewView(*s.state(), id)) {
+			s.act(action.Type, action.PlayerID, action.Payload)
+		}
+	}
+}
+
+// observe updates the running statistics with an applied event
+func (s *simulation) observe(event core.Event) {
+	s.result.Events++
+	state := s.state()
+
+	switch event.Type {
+	case core.EventPlayerEliminated:
+		s.result.Eliminated++
+	case core.EventAIConversionSuccess:
+		s.result.Conversions++
+	case core.EventVictoryCondition:
+		payload, _ := core.DecodePayload[core.VictoryConditionPayload](event)
+		s.result.Winner = payload.Winner
+		s.result.Condition = payload.Condition
+	}
+	s.kpis.observe(state, event)
+}
+
+// finish records the final position of every player
+func (s *simulation) finish() {
+	state := s.state()
+	s.result.Days = state.DayNumber
+
+	for _, id := range s.ids() {
+		player := state.Players[id]
+		result := PlayerResult{
+			ID:        id,
+			Strategy:  s.seats[id].Name(),
+			Alignment: player.Alignment,
+			Alive:     player.IsAlive,
+			Tokens:    player.Tokens,
+		}
+		if player.Role != nil {
+			result.Role = player.Role.Type
+		}
+		if player.PersonalKPI != nil {
+			result.KPI = player.PersonalKPI.Type
+			result.KPICompleted = s.kpis.completed(state, player)
+		}
+		s.result.Players = append(s.result.Players, result)
+	}
+}
+
+// ids lists every player in a stable order, so bot choices depend only on the seed
+func (s *simulation) ids() []string {
+	ids := make([]string, 0, len(s.state().Players))
+	for id := range s.state().Players {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// discardStore satisfies the actor's persistence without storing anything
+type discardStore struct{}
+
+func (discardStore) AppendEvent(gameID string, event core.Event) error       { return nil }
+func (discardStore) SaveSnapshot(gameID string, state *core.GameState) error { return nil }
+func (discardStore) LoadEvents(gameID string, afterSequence int) ([]core.Event, error) {
+	return nil, nil
+}
+func (discardStore) LoadSnapshot(gameID string) (*core.GameState, error) { return nil, nil }
+
+// discardBroadcaster satisfies the actor's delivery; bots read the state directly
+type discardBroadcaster struct{}
+
+func (discardBroadcaster) BroadcastToGame(gameID string, event core.Event) error        { return nil }
+func (discardBroadcaster) SendToPlayer(gameID, playerID string, event core.Event) error { return nil }
+func (discardBroadcaster) SendToConnection(gameID, connectionID string, event core.Event) error {
+	return nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"reflect"
+	"testing"
+
+	"github.com/xjhc/alignment/server/internal/ai"
+)
+
+// TestSimulateGame tests that bots play a game to the end and that the seed
+// alone decides how it plays out
+func TestSimulateGame(t *testing.T) {
+	first, err := simulateGame(42, 6, ai.StrategyNames())
+	if err != nil {
+		t.Fatalf("Failed to simulate: %v", err)
+	}
+
+	if first.Winner == "" {
+		t.Errorf("Expected the game to end with a winner within %d days, got day %d", maxDays, first.Days)
+	}
+	if len(first.Players) != 6 {
+		t.Fatalf("Expected 6 players in the result, got %d", len(first.Players))
+	}
+	aligned := 0
+	for _, player := range first.Players {
+		if player.Role == "" {
+			t.Errorf("Expected %s to have been dealt a role", player.ID)
+		}
+		if player.Alignment == "ALIGNED" {
+			aligned++
+		}
+	}
+	if aligned == 0 {
+		t.Error("Expected the AI faction to be represented")
+	}
+
+	if second, _ := simulateGame(42, 6, ai.StrategyNames()); !reflect.DeepEqual(first, second) {
+		t.Errorf("Expected the same seed to produce the same game\nfirst:  %+v\nsecond: %+v", first, second)
+	}
+}
+
+// TestSummarize tests the aggregate rates and token distribution
+func TestSummarize(t *testing.T) {
+	results := []GameResult{
+		{Winner: "HUMANS", Condition: "CONTAINMENT", Days: 3, Players: []PlayerResult{
+			{ID: "a", Alignment: "HUMAN", Tokens: 4, KPI: "CAPITALIST", KPICompleted: true},
+			{ID: "b", Alignment: "ALIGNED", Tokens: 2},
+		}},
+		{Winner: "AI", Condition: "SINGULARITY", Days: 5, Players: []PlayerResult{
+			{ID: "a", Alignment: "HUMAN", Tokens: 1, KPI: "CAPITALIST"},
+			{ID: "b", Alignment: "ALIGNED", Tokens: 6},
+		}},
+	}
+
+	summary := summarize(results, 2)
+
+	if summary.WinRates["HUMANS"] != 0.5 || summary.WinRates["AI"] != 0.5 {
+		t.Errorf("Expected even win rates, got %v", summary.WinRates)
+	}
+	if summary.AverageDays != 4 {
+		t.Errorf("Expected an average of 4 days, got %v", summary.AverageDays)
+	}
+	if kpi := summary.KPIs["CAPITALIST"]; kpi.Dealt != 2 || kpi.Completed != 1 || kpi.CompletionRate != 0.5 {
+		t.Errorf("Expected CAPITALIST completed 1 of 2, got %+v", kpi)
+	}
+	if tokens := summary.Tokens["ALL"]; tokens.Count != 4 || tokens.Min != 1 || tokens.Max != 6 || tokens.Mean != 3.25 {
+		t.Errorf("Unexpected token distribution: %+v", tokens)
+	}
+	if tokens := summary.Tokens["ALIGNED"]; tokens.Count != 2 || tokens.P50 != 2 {
+		t.Errorf("Unexpected aligned token distribution: %+v", tokens)
+	}
+}
+
+// TestWriteCSV tests that every player of every game gets a row
+func TestWriteCSV(t *testing.T) {
+	results, err := simulateGames(1, 3, 6, []string{"random"}, 2)
+	if err != nil {
+		t.Fatalf("Failed to simulate: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := writeCSV(&buf, results); err != nil {
+		t.Fatalf("Failed to write CSV: %v", err)
+	}
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to read CSV back: %v", err)
+	}
+	if len(rows) != 1+3*6 {
+		t.Fatalf("Expected a header and 18 rows, got %d rows", len(rows))
+	}
+	if !reflect.DeepEqual(rows[0], csvHeader) {
+		t.Errorf("Expected header %v, got %v", csvHeader, rows[0])
+	}
+	if rows[1][0] != "1" || rows[len(rows)-1][0] != "3" {
+		t.Errorf("Expected rows in seed order, got seeds %s..%s", rows[1][0], rows[len(rows)-1][0])
+	}
+}
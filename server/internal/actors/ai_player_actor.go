@@ -0,0 +1,391 @@
+package actors
+
+import (
+	"context"
+	"log"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/xjhc/alignment/core"
+	"github.com/xjhc/alignment/server/internal/ai"
+)
+
+// Bounds of the pause before an AI seat acts in a phase, so it does not
+// answer with inhuman speed the moment the phase begins
+const (
+	DefaultAIMinDelay = 2 * time.Second
+	DefaultAIMaxDelay = 12 * time.Second
+)
+
+// ActionSender accepts actions for a game, as GameActor.SendAction does
+type ActionSender interface {
+	SendAction(action core.Action)
+}
+
+// AIPlayerActor plays one seat of a game. It receives the same event feed a
+// client in that seat would, rebuilds the seat's view of the game from it,
+// and when a phase calls for a decision it asks its strategy and submits the
+// answer through the game's mailbox after a human-like pause. Its actions are
+// validated exactly like a human's.
+type AIPlayerActor struct {
+	gameID   string
+	playerID string
+	strategy ai.Strategy
+	brain    *ai.LanguageBrain // Optional; without it the seat never chats
+	game     ActionSender
+
+	// Owned by the processing loop
+	state    *core.GameState // The seat's view, nil until the catch-up snapshot arrives
+	decide   *time.Timer     // Pending decision for the current phase
+	phase    core.Phase      // The phase the pending decision is for
+	chat     *time.Timer     // Pending chat turn
+	chatting bool            // A language model call is in flight
+	rng      *rand.Rand
+
+	inbox     chan core.Event
+	resyncing atomic.Bool // An event was dropped and no snapshot has been queued since
+	chatDone  chan struct{}
+	shutdown  chan struct{}
+	stopOnce  sync.Once
+	ctx       context.Context // Cancelled on Stop, aborting a language model call
+	cancel    context.CancelFunc
+
+	minDelay time.Duration
+	maxDelay time.Duration
+}
+
+// NewAIPlayerActor creates an AI player for a seat. It does nothing until a
+// GameActor adopts it with AddAIPlayer.
+func NewAIPlayerActor(gameID, playerID string, strategy ai.Strategy, game ActionSender) *AIPlayerActor {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &AIPlayerActor{
+		gameID:   gameID,
+		playerID: playerID,
+		strategy: strategy,
+		game:     game,
+		rng:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		inbox:    make(chan core.Event, 100),
+		chatDone: make(chan struct{}),
+		shutdown: make(chan struct{}),
+		ctx:      ctx,
+		cancel:   cancel,
+		minDelay: DefaultAIMinDelay,
+		maxDelay: DefaultAIMaxDelay,
+	}
+}
+
+// SetActionDelay changes the bounds of the pause before acting. Must be
+// called before Start.
+func (a *AIPlayerActor) SetActionDelay(min, max time.Duration) {
+	a.minDelay = min
+	a.maxDelay = max
+}
+
+// SetLanguageBrain lets the seat chat. Must be called before Start.
+func (a *AIPlayerActor) SetLanguageBrain(brain *ai.LanguageBrain) {
+	a.brain = brain
+}
+
+// PlayerID returns the seat the actor plays
+func (a *AIPlayerActor) PlayerID() string {
+	return a.playerID
+}
+
+// reseat creates a fresh AI player for the same seat, strategy and pacing,
+// playing through game, e.g. after the game's actor has been restarted
+func (a *AIPlayerActor) reseat(game ActionSender) *AIPlayerActor {
+	player := NewAIPlayerActor(a.gameID, a.playerID, a.strategy, game)
+	player.SetActionDelay(a.minDelay, a.maxDelay)
+	player.SetLanguageBrain(a.brain)
+	return player
+}
+
+// Start begins processing the seat's event feed
+func (a *AIPlayerActor) Start() {
+	log.Printf("AIPlayerActor %s/%s: Starting with strategy %s", a.gameID, a.playerID, a.strategy.Name())
+	go a.processLoop()
+}
+
+// Stop ends the actor; pending decisions are dropped
+func (a *AIPlayerActor) Stop() {
+	a.stopOnce.Do(func() {
+		log.Printf("AIPlayerActor %s/%s: Stopping", a.gameID, a.playerID)
+		a.cancel()
+		close(a.shutdown)
+	})
+}
+
+// Deliver hands the actor an event from its seat's feed. It never blocks the
+// game's event loop; if the actor has fallen that far behind, the event is
+// dropped and the actor asks the game for a snapshot to repair its view.
+func (a *AIPlayerActor) Deliver(event core.Event) {
+	select {
+	case a.inbox <- event:
+		// Events after the snapshot are applied on top of it
+		if event.Type == core.EventGameStateSnapshot {
+			a.resyncing.Store(false)
+		}
+	default:
+		log.Printf("AIPlayerActor %s/%s: Inbox full, dropping event %s", a.gameID, a.playerID, event.Type)
+
+		// One request at a time, unless the snapshot answering it was dropped too
+		if a.resyncing.CompareAndSwap(false, true) || event.Type == core.EventGameStateSnapshot {
+			a.requestResync()
+		}
+	}
+}
+
+// requestResync asks the game for the seat's view as a state snapshot, which
+// is what a reconnect without a last event gets
+func (a *AIPlayerActor) requestResync() {
+	log.Printf("AIPlayerActor %s/%s: Requesting a state snapshot", a.gameID, a.playerID)
+	a.game.SendAction(core.Action{
+		Type:      core.ActionReconnect,
+		PlayerID:  a.playerID,
+		GameID:    a.gameID,
+		Timestamp: time.Now(),
+		Payload:   make(map[string]interface{}),
+	})
+}
+
+// processLoop applies the feed and fires decisions, one at a time
+func (a *AIPlayerActor) processLoop() {
+	for {
+		var decide, chat <-chan time.Time
+		if a.decide != nil {
+			decide = a.decide.C
+		}
+		if a.chat != nil {
+			chat = a.chat.C
+		}
+
+		select {
+		case event := <-a.inbox:
+			a.handleEvent(event)
+		case <-decide:
+			a.decide = nil
+			a.act()
+		case <-chat:
+			a.chat = nil
+			a.speak()
+		case <-a.chatDone:
+			a.chatting = false
+		case <-a.shutdown:
+			if a.decide != nil {
+				a.decide.Stop()
+			}
+			if a.chat != nil {
+				a.chat.Stop()
+			}
+			return
+		}
+	}
+}
+
+// handleEvent updates the seat's view and schedules a decision when a new
+// phase begins
+func (a *AIPlayerActor) handleEvent(event core.Event) {
+	switch event.Type {
+	case core.EventGameStateSnapshot:
+		payload, err := core.DecodePayload[struct {
+			State core.GameState `json:"state"`
+		}](event)
+		if err != nil {
+			log.Printf("AIPlayerActor %s/%s: Failed to read state snapshot: %v", a.gameID, a.playerID, err)
+			return
+		}
+		a.state = &payload.State
+		a.schedule()
+		return
+	case core.EventSyncComplete, core.EventActionAck:
+		return
+	case core.EventActionRejected:
+		log.Printf("AIPlayerActor %s/%s: Action rejected: %v", a.gameID, a.playerID, event.Payload["message"])
+		return
+	}
+
+	// Until the snapshot arrives the events it will cover are ignored
+	if a.state == nil {
+		return
+	}
+
+	newState := core.ApplyEvent(*a.state, event)
+	a.state = &newState
+	switch {
+	case event.Type == core.EventPhaseChanged:
+		a.schedule()
+		if a.state.Phase.Type == core.PhaseDiscussion {
+			a.scheduleChat()
+		}
+	case event.Type == core.EventChatMessage && event.PlayerID != a.playerID:
+		a.scheduleChat()
+	}
+}
+
+// schedule plans one decision for the current phase, replacing any pending
+// decision for an earlier phase
+func (a *AIPlayerActor) schedule() {
+	if a.decide != nil {
+		a.decide.Stop()
+		a.decide = nil
+	}
+
+	switch a.state.Phase.Type {
+	case core.PhaseNomination, core.PhaseVerdict, core.PhaseNight:
+	default:
+		return
+	}
+
+	a.phase = a.state.Phase
+	a.decide = time.NewTimer(a.actionDelay(a.phase.Duration))
+}
+
+// actionDelay picks a pause between the bounds, leaving at least half of the
+// phase for the action to land in
+func (a *AIPlayerActor) actionDelay(phaseDuration time.Duration) time.Duration {
+	max := a.maxDelay
+	if phaseDuration > 0 && max > phaseDuration/2 {
+		max = phaseDuration / 2
+	}
+	min := a.minDelay
+	if min > max {
+		min = max
+	}
+	if max == min {
+		return min
+	}
+	return min + time.Duration(a.rng.Int63n(int64(max-min)))
+}
+
+// act asks the strategy what to do now and submits its actions
+func (a *AIPlayerActor) act() {
+	// A decision planned for a phase that has ended is stale
+	if a.state.Phase.Type != a.phase.Type || !a.state.Phase.StartTime.Equal(a.phase.StartTime) {
+		return
+	}
+
+	for _, action := range a.strategy.Act(ai.NewView(*a.state, a.playerID)) {
+		action.GameID = a.gameID
+		action.PlayerID = a.playerID
+		log.Printf("AIPlayerActor %s/%s: Submitting %s", a.gameID, a.playerID, action.Type)
+		a.game.SendAction(action)
+	}
+}
+
+// scheduleChat plans a chat turn after a typing-length pause, unless one is
+// already planned or under way. The brain's limits decide whether the turn
+// actually reaches the language model.
+func (a *AIPlayerActor) scheduleChat() {
+	if a.brain == nil || a.chat != nil || a.chatting || !ai.IsChatPhase(a.state.Phase.Type) {
+		return
+	}
+	a.chat = time.NewTimer(a.actionDelay(a.state.Phase.Duration))
+}
+
+// speak asks the language brain for a message without blocking the feed;
+// the call can take seconds
+func (a *AIPlayerActor) speak() {
+	if !ai.IsChatPhase(a.state.Phase.Type) {
+		return
+	}
+
+	view := ai.NewView(*a.state, a.playerID)
+	a.chatting = true
+	go func() {
+		defer func() {
+			select {
+			case a.chatDone <- struct{}{}:
+			case <-a.shutdown:
+			}
+		}()
+
+		action, ok, err := a.brain.Chat(a.ctx, view, time.Now())
+		if err != nil {
+			log.Printf("AIPlayerActor %s/%s: Failed to chat: %v", a.gameID, a.playerID, err)
+			return
+		}
+		if !ok || a.ctx.Err() != nil {
+			return
+		}
+		action.GameID = a.gameID
+		action.PlayerID = a.playerID
+		a.game.SendAction(action)
+	}()
+}
+
+// aiSeats wraps a GameActor's broadcaster so the seats played by AI receive
+// exactly the feed a client in the seat would: every broadcast, and the
+// private events sent to that player
+type aiSeats struct {
+	Broadcaster
+	mutex   sync.RWMutex
+	players map[string]*AIPlayerActor
+}
+
+func newAISeats(broadcaster Broadcaster) *aiSeats {
+	return &aiSeats{
+		Broadcaster: broadcaster,
+		players:     make(map[string]*AIPlayerActor),
+	}
+}
+
+// BroadcastToGame sends the event to every client and every AI seat
+func (s *aiSeats) BroadcastToGame(gameID string, event core.Event) error {
+	s.mutex.RLock()
+	for _, player := range s.players {
+		player.Deliver(event)
+	}
+	s.mutex.RUnlock()
+
+	return s.Broadcaster.BroadcastToGame(gameID, event)
+}
+
+// SendToPlayer sends the event to an AI seat, or else to the player's client
+func (s *aiSeats) SendToPlayer(gameID, playerID string, event core.Event) error {
+	s.mutex.RLock()
+	player, exists := s.players[playerID]
+	s.mutex.RUnlock()
+
+	if exists {
+		player.Deliver(event)
+		return nil
+	}
+	return s.Broadcaster.SendToPlayer(gameID, playerID, event)
+}
+
+// add seats player, returning the AI it replaced, if any
+func (s *aiSeats) add(player *AIPlayerActor) *AIPlayerActor {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	replaced := s.players[player.playerID]
+	s.players[player.playerID] = player
+	return replaced
+}
+
+// remove unseats the AI playing playerID, returning it if there was one
+func (s *aiSeats) remove(playerID string) *AIPlayerActor {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	player, exists := s.players[playerID]
+	if !exists {
+		return nil
+	}
+	delete(s.players, playerID)
+	return player
+}
+
+// all lists the AI players
+func (s *aiSeats) all() []*AIPlayerActor {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	players := make([]*AIPlayerActor, 0, len(s.players))
+	for _, player := range s.players {
+		players = append(players, player)
+	}
+	return players
+}
@@ -0,0 +1,277 @@
+package actors
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/xjhc/alignment/core"
+	"github.com/xjhc/alignment/server/internal/ai"
+	"github.com/xjhc/alignment/server/internal/ai/prompts"
+)
+
+// recordingSender collects the actions an AI player submits
+type recordingSender struct {
+	mutex   sync.Mutex
+	actions []core.Action
+}
+
+func (r *recordingSender) SendAction(action core.Action) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.actions = append(r.actions, action)
+}
+
+func (r *recordingSender) Actions() []core.Action {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]core.Action(nil), r.actions...)
+}
+
+// snapshotEvent is the catch-up snapshot a seat receives on reconnect
+func snapshotEvent(state core.GameState, playerID string) core.Event {
+	return core.Event{
+		ID:       "sync_state",
+		Type:     core.EventGameStateSnapshot,
+		GameID:   state.ID,
+		PlayerID: playerID,
+		Payload:  map[string]interface{}{"state": core.ProjectStateForPlayer(state, playerID)},
+	}
+}
+
+// TestAIPlayerActor_ResyncAfterOverflow tests that an AI whose inbox
+// overflows asks for a snapshot, once per gap in its feed
+func TestAIPlayerActor_ResyncAfterOverflow(t *testing.T) {
+	sender := &recordingSender{}
+	player := NewAIPlayerActor("test-game", "ai", ai.NewRulesEngine(1), sender)
+	chat := core.Event{Type: core.EventChatMessage, GameID: "test-game"}
+	snapshot := snapshotEvent(*core.NewGameState("test-game"), "ai")
+
+	// Not started, so nothing drains the inbox
+	for i := 0; i < cap(player.inbox)+3; i++ {
+		player.Deliver(chat)
+	}
+	if actions := sender.Actions(); len(actions) != 1 || actions[0].Type != core.ActionReconnect || actions[0].PlayerID != "ai" {
+		t.Fatalf("Expected one reconnect for the dropped events, got %v", actions)
+	}
+
+	// The snapshot answering it is dropped as well, so it is asked for again
+	player.Deliver(snapshot)
+	if actions := sender.Actions(); len(actions) != 2 {
+		t.Fatalf("Expected another reconnect for the dropped snapshot, got %d actions", len(actions))
+	}
+
+	// Once a snapshot is queued, a new gap needs a new one
+	<-player.inbox
+	player.Deliver(snapshot)
+	player.Deliver(chat)
+	if actions := sender.Actions(); len(actions) != 3 {
+		t.Errorf("Expected a reconnect for the gap after the snapshot, got %d actions", len(actions))
+	}
+}
+
+// TestAIPlayerActor_VotesFromFeed tests that the AI acts only on a phase it
+// has seen begin, and not before it has been caught up
+func TestAIPlayerActor_VotesFromFeed(t *testing.T) {
+	state := core.NewGameState("test-game")
+	state.Phase = core.Phase{Type: core.PhaseDiscussion, StartTime: time.Now()}
+	for _, id := range []string{"ai", "human-1", "human-2"} {
+		state.Players[id] = &core.Player{ID: id, Name: id, IsAlive: true, Alignment: "HUMAN", Tokens: 1}
+	}
+	state.Players["ai"].Alignment = "ALIGNED"
+	state.Players["human-2"].Tokens = 5
+
+	sender := &recordingSender{}
+	player := NewAIPlayerActor("test-game", "ai", ai.NewRulesEngine(1), sender)
+	player.SetActionDelay(0, 0)
+	player.Start()
+	defer player.Stop()
+
+	nomination := core.Event{
+		Type:    core.EventPhaseChanged,
+		GameID:  "test-game",
+		Payload: core.EncodePayload(core.PhaseChangedPayload{PhaseType: core.PhaseNomination, PreviousPhase: core.PhaseDiscussion}),
+	}
+
+	// Events before the snapshot are already covered by it
+	player.Deliver(nomination)
+	time.Sleep(20 * time.Millisecond)
+	if actions := sender.Actions(); len(actions) != 0 {
+		t.Fatalf("Expected no action before catch-up, got %v", actions)
+	}
+
+	player.Deliver(snapshotEvent(*state, "ai"))
+	time.Sleep(20 * time.Millisecond)
+	if actions := sender.Actions(); len(actions) != 0 {
+		t.Fatalf("Expected no action during discussion, got %v", actions)
+	}
+
+	player.Deliver(nomination)
+	time.Sleep(20 * time.Millisecond)
+
+	actions := sender.Actions()
+	if len(actions) != 1 {
+		t.Fatalf("Expected one nomination vote, got %v", actions)
+	}
+	vote := actions[0]
+	if vote.Type != core.ActionSubmitVote || vote.PlayerID != "ai" || vote.GameID != "test-game" {
+		t.Errorf("Expected a vote from ai in test-game, got %+v", vote)
+	}
+	if vote.Payload["target_id"] != "human-2" {
+		t.Errorf("Expected the biggest threat human-2 to be nominated, got %v", vote.Payload["target_id"])
+	}
+}
+
+// TestAIPlayerActor_StaleDecision tests that a decision planned for a phase
+// that has ended is dropped
+func TestAIPlayerActor_StaleDecision(t *testing.T) {
+	state := core.NewGameState("test-game")
+	state.Phase = core.Phase{Type: core.PhaseNomination, StartTime: time.Now()}
+	for _, id := range []string{"ai", "human-1"} {
+		state.Players[id] = &core.Player{ID: id, IsAlive: true, Alignment: "HUMAN", Tokens: 1}
+	}
+
+	sender := &recordingSender{}
+	player := NewAIPlayerActor("test-game", "ai", ai.NewRulesEngine(1), sender)
+	player.SetActionDelay(50*time.Millisecond, 50*time.Millisecond)
+	player.Start()
+	defer player.Stop()
+
+	player.Deliver(snapshotEvent(*state, "ai"))
+	player.Deliver(core.Event{
+		Type:    core.EventPhaseChanged,
+		GameID:  "test-game",
+		Payload: core.EncodePayload(core.PhaseChangedPayload{PhaseType: core.PhaseTrial, PreviousPhase: core.PhaseNomination}),
+	})
+	time.Sleep(100 * time.Millisecond)
+
+	if actions := sender.Actions(); len(actions) != 0 {
+		t.Errorf("Expected the nomination decision to be dropped, got %v", actions)
+	}
+}
+
+// TestGameActor_AIPlayerSeat tests that an AI seat plays through the mailbox
+// and that its vote is validated and recorded like a human's
+func TestGameActor_AIPlayerSeat(t *testing.T) {
+	datastore := NewMockDataStore()
+	broadcaster := NewMockBroadcaster()
+
+	actor := NewGameActor("test-game", datastore, broadcaster)
+	actor.Start()
+	defer actor.Stop()
+
+	for i := 0; i < actor.state.Settings.MinPlayers; i++ {
+		actor.SendAction(core.Action{
+			Type:     core.ActionJoinGame,
+			PlayerID: fmt.Sprintf("player-%d", i),
+			GameID:   "test-game",
+			Payload:  map[string]interface{}{"name": fmt.Sprintf("Player%d", i)},
+		})
+	}
+
+	player := NewAIPlayerActor("test-game", "player-5", ai.NewRulesEngine(1), actor)
+	player.SetActionDelay(0, 0)
+	actor.AddAIPlayer(player)
+
+	actor.SendAction(core.Action{Type: core.ActionStartGame, PlayerID: "player-0", GameID: "test-game"})
+	actor.SendAction(core.Action{
+		Type:     core.ActionPhaseTransition,
+		PlayerID: core.SystemPlayerID,
+		GameID:   "test-game",
+		Payload:  map[string]interface{}{"next_phase": string(core.PhaseNomination)},
+	})
+	time.Sleep(100 * time.Millisecond)
+
+	var vote *core.Event
+	for _, event := range datastore.GetEvents() {
+		if event.Type == core.EventVoteCast && event.PlayerID == "player-5" {
+			vote = &event
+		}
+	}
+	if vote == nil {
+		t.Fatal("Expected the AI seat to cast a nomination vote")
+	}
+	if target, _ := vote.Payload["target_id"].(string); target == "" || target == "player-5" {
+		t.Errorf("Expected the AI to nominate another player, got %q", target)
+	}
+
+	// The seat's private feed goes to the AI, not to a client
+	if received := broadcaster.GetPlayerEvents("player-5"); len(received) != 0 {
+		t.Errorf("Expected no client events for the AI seat, got %v", received)
+	}
+	if len(broadcaster.GetPlayerEvents("player-0")) == 0 {
+		t.Error("Expected human seats to keep receiving their private events")
+	}
+
+	if !actor.RemoveAIPlayer("player-5") || actor.RemoveAIPlayer("player-5") {
+		t.Error("Expected the AI seat to be removed exactly once")
+	}
+}
+
+// TestGameActor_ChatMessages tests that only players who may speak can chat
+func TestGameActor_ChatMessages(t *testing.T) {
+	actor := NewGameActor("test-game", NewMockDataStore(), NewMockBroadcaster())
+	actor.state.Phase = core.Phase{Type: core.PhaseDiscussion, StartTime: time.Now()}
+	actor.state.Players["alive"] = &core.Player{ID: "alive", Name: "Alive", IsAlive: true}
+	actor.state.Players["gone"] = &core.Player{ID: "gone", Name: "Gone", IsAlive: false}
+
+	send := func(playerID, message string) []core.Event {
+		return actor.Step(core.Action{Type: core.ActionSendMessage, PlayerID: playerID, GameID: "test-game",
+			Payload: map[string]interface{}{"message": message}})
+	}
+
+	if events := send("alive", "  hello  "); len(events) != 1 || events[0].Type != core.EventChatMessage {
+		t.Fatalf("Expected a chat message, got %v", events)
+	}
+	if messages := actor.state.ChatMessages; len(messages) != 1 || messages[0].Message != "hello" || messages[0].PlayerName != "Alive" {
+		t.Errorf("Expected the trimmed message in the chat log, got %+v", messages)
+	}
+
+	for name, events := range map[string][]core.Event{
+		"deactivated": send("gone", "boo"),
+		"unknown":     send("stranger", "hi"),
+		"empty":       send("alive", "   "),
+		"too long":    send("alive", strings.Repeat("a", core.MaxChatMessageLength+1)),
+	} {
+		if len(events) != 0 {
+			t.Errorf("Expected the %s message to be rejected, got %v", name, events)
+		}
+	}
+}
+
+// TestAIPlayerActor_Chats tests that an AI seat with a language brain answers
+// a human's message through the mailbox
+func TestAIPlayerActor_Chats(t *testing.T) {
+	state := core.NewGameState("test-game")
+	state.Phase = core.Phase{Type: core.PhaseDiscussion, StartTime: time.Now()}
+	state.Players["ai"] = &core.Player{ID: "ai", Name: "Alex", IsAlive: true, Alignment: "ALIGNED"}
+	state.Players["bob"] = &core.Player{ID: "bob", Name: "Bob", IsAlive: true, Alignment: "HUMAN"}
+
+	client := ai.NewFakeLLMClient(`{"action": "wasn't me -_-"}`)
+	sender := &recordingSender{}
+	player := NewAIPlayerActor("test-game", "ai", ai.NewRulesEngine(1), sender)
+	player.SetActionDelay(0, 0)
+	player.SetLanguageBrain(ai.NewLanguageBrain(prompts.MillennialLean{}, client, ai.ChatLimits{}, nil))
+	player.Start()
+	defer player.Stop()
+
+	player.Deliver(snapshotEvent(*state, "ai"))
+	player.Deliver(core.Event{
+		ID:       "chat-1",
+		Type:     core.EventChatMessage,
+		GameID:   "test-game",
+		PlayerID: "bob",
+		Payload:  core.EncodePayload(core.ChatMessagePayload{PlayerName: "Bob", Message: "Alex mined nobody"}),
+	})
+	time.Sleep(50 * time.Millisecond)
+
+	actions := sender.Actions()
+	if len(actions) != 1 || actions[0].Type != core.ActionSendMessage || actions[0].Payload["message"] != "wasn't me -_-" {
+		t.Fatalf("Expected one chat reply, got %v", actions)
+	}
+	if prompts := client.Prompts(); len(prompts) != 1 || !strings.Contains(prompts[0], "Bob: Alex mined nobody") {
+		t.Errorf("Expected the prompt to quote Bob, got %v", prompts)
+	}
+}
@@ -1,9 +1,14 @@
 package actors
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
+	"sort"
+	"strings"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
 	"github.com/xjhc/alignment/core"
 	"github.com/xjhc/alignment/server/internal/game"
@@ -12,6 +17,9 @@ import (
 // Manager interfaces for better testability
 type VotingManager interface {
 	HandleVoteAction(action core.Action) ([]core.Event, error)
+	CreateVoteStartedEvent(voteType core.VoteType) core.Event
+	ResolveNomination() []core.Event
+	ResolveVerdict() ([]core.Event, string)
 }
 
 type MiningManager interface {
@@ -26,19 +34,67 @@ type RoleAbilityManager interface {
 type GameActor struct {
 	gameID   string
 	state    *core.GameState
+	latest   atomic.Pointer[core.GameState] // Copy of state after the last event, safe to read from any goroutine
 	mailbox  chan core.Action
-	events   chan core.Event
+	events   chan outboxEntry
 	shutdown chan struct{}
+	failed   chan struct{} // Closed when processLoop exits on a panic
+	flushed  chan struct{} // Closed when eventLoop has emptied the outbox and exited
+
+	// Snapshot policy: every snapshotInterval events, plus phase changes and game end
+	snapshotInterval    int
+	eventsSinceSnapshot int
 
 	// Dependencies (interfaces for testing)
 	datastore   DataStore
 	broadcaster Broadcaster
 
+	// Seats played by AIPlayerActors; wraps the broadcaster so they get their feed
+	aiSeats *aiSeats
+
 	// Game managers (domain experts)
 	votingManager      VotingManager
 	miningManager      MiningManager
 	roleAbilityManager RoleAbilityManager
 	eliminationManager *game.EliminationManager
+
+	// Phase managers run by handlePhaseTransition
+	crisisManager          *game.CrisisEventManager
+	sitrepGenerator        *game.SitrepGenerator
+	nightResolutionManager *game.NightResolutionManager
+
+	// Roles, KPIs and original AIs dealt when the game starts
+	startDecks game.StartDecks
+
+	// Schedules automatic phase transitions; nil when the actor has no scheduler
+	phaseManager *game.PhaseManager
+
+	// Players whose connections have all closed, and how their seats are covered
+	awayPolicy AwayPolicy
+	away       map[string]*awaySeat
+}
+
+// DefaultSnapshotInterval is the number of events between periodic snapshots
+const DefaultSnapshotInterval = 50
+
+// outboxEntry is an event queued for persistence and broadcast. When snapshot
+// is set it holds the state after the event, saved once the event is persisted.
+type outboxEntry struct {
+	event      core.Event
+	recipients []string    // Players allowed to see a non-public event
+	followUp   *core.Event // Derived private event sent after this one, never persisted
+	snapshot   *core.GameState
+	catchUp    *catchUpRequest // Set instead of event for a reconnecting player
+	reply      *core.Event     // Set instead of event for an ACK or ACTION_REJECTED to the acting player
+}
+
+// catchUpRequest asks the event loop to replay what a reconnecting player missed.
+// It is queued behind earlier events so they are persisted before the replay.
+type catchUpRequest struct {
+	playerID     string
+	connectionID string // The reconnecting connection, empty for an AI seat
+	lastSequence int    // Preferred resume point
+	lastEventID  string // Fallback for clients that only track event IDs
 }
 
 // DataStore interface for persistence
@@ -53,32 +109,58 @@ type DataStore interface {
 type Broadcaster interface {
 	BroadcastToGame(gameID string, event core.Event) error
 	SendToPlayer(gameID, playerID string, event core.Event) error
+	SendToConnection(gameID, connectionID string, event core.Event) error
 }
 
 // NewGameActor creates a new game actor
 func NewGameActor(gameID string, datastore DataStore, broadcaster Broadcaster) *GameActor {
-	state := core.NewGameState(gameID)
-	return &GameActor{
-		gameID:      gameID,
+	return NewGameActorFromState(core.NewGameState(gameID), datastore, broadcaster)
+}
+
+// NewGameActorFromState creates a game actor around an existing state,
+// such as one recovered from persistence after a crash
+func NewGameActorFromState(state *core.GameState, datastore DataStore, broadcaster Broadcaster) *GameActor {
+	seats := newAISeats(broadcaster)
+	ga := &GameActor{
+		gameID:      state.ID,
 		state:       state,
 		mailbox:     make(chan core.Action, 100), // Buffered channel
-		events:      make(chan core.Event, 100),
+		events:      make(chan outboxEntry, 100),
 		shutdown:    make(chan struct{}),
+		failed:      make(chan struct{}),
+		flushed:     make(chan struct{}),
 		datastore:   datastore,
-		broadcaster: broadcaster,
+		broadcaster: seats,
+		aiSeats:     seats,
 
-		// Initialize managers with shared state
-		votingManager:      game.NewVotingManager(state),
-		miningManager:      game.NewMiningManager(state),
-		roleAbilityManager: game.NewRoleAbilityManager(state),
-		eliminationManager: game.NewEliminationManager(state),
+		snapshotInterval: DefaultSnapshotInterval,
+		startDecks:       game.DefaultStartDecks(),
+		away:             make(map[string]*awaySeat),
 	}
+	ga.bindManagers()
+	ga.publishState()
+	return ga
+}
+
+// bindManagers (re)creates the game managers against the actor's current state
+func (ga *GameActor) bindManagers() {
+	ga.votingManager = game.NewVotingManager(ga.state)
+	ga.miningManager = game.NewMiningManager(ga.state)
+	ga.roleAbilityManager = game.NewRoleAbilityManager(ga.state)
+	ga.eliminationManager = game.NewEliminationManager(ga.state)
+	ga.crisisManager = game.NewCrisisEventManager(ga.state)
+	ga.sitrepGenerator = game.NewSitrepGenerator(ga.state)
+	ga.nightResolutionManager = game.NewNightResolutionManager(ga.state)
 }
 
 // Start begins the actor's main processing loop
 func (ga *GameActor) Start() {
 	log.Printf("GameActor %s: Starting", ga.gameID)
 
+	// Re-arm the current phase's timer, e.g. after a restart from persistence
+	ga.armPhaseTimer()
+	ga.armAwayTimers()
+
 	// Start the main processing loop in a goroutine
 	go ga.processLoop()
 
@@ -89,9 +171,93 @@ func (ga *GameActor) Start() {
 // Stop gracefully shuts down the actor
 func (ga *GameActor) Stop() {
 	log.Printf("GameActor %s: Stopping", ga.gameID)
+	if ga.phaseManager != nil {
+		ga.phaseManager.CancelPhaseTransitions()
+	}
+	for _, player := range ga.aiSeats.all() {
+		player.Stop()
+	}
 	close(ga.shutdown)
 }
 
+// AddAIPlayer hands a seat to an AI player, replacing any AI already in it.
+// The player is sent a catch-up for the seat through the mailbox, so a seat
+// that does not exist in the game is rejected like any unknown reconnect.
+func (ga *GameActor) AddAIPlayer(player *AIPlayerActor) {
+	if replaced := ga.aiSeats.add(player); replaced != nil {
+		replaced.Stop()
+	}
+	player.Start()
+
+	log.Printf("GameActor %s: AI player seated as %s", ga.gameID, player.playerID)
+	ga.SendAction(core.Action{
+		Type:      core.ActionReconnect,
+		PlayerID:  player.playerID,
+		GameID:    ga.gameID,
+		Timestamp: time.Now(),
+		Payload:   make(map[string]interface{}),
+	})
+}
+
+// RemoveAIPlayer stops the AI playing playerID, if any. Events for the seat
+// go to the player's client again.
+func (ga *GameActor) RemoveAIPlayer(playerID string) bool {
+	player := ga.aiSeats.remove(playerID)
+	if player == nil {
+		return false
+	}
+	player.Stop()
+	log.Printf("GameActor %s: AI player removed from %s", ga.gameID, playerID)
+	return true
+}
+
+// AIPlayers lists the AI players seated in the game
+func (ga *GameActor) AIPlayers() []*AIPlayerActor {
+	return ga.aiSeats.all()
+}
+
+// RecordSeed makes GAME_CREATED, carrying the seed every random draw in the
+// game derives from, the first event of a new game. Replaying the same seed
+// and action log reproduces the game exactly. The event has no PlayerID, so
+// it is persisted but never sent to a client. Must be called before Start.
+func (ga *GameActor) RecordSeed(seed int64) {
+	ga.applyAndBroadcast([]core.Event{{
+		ID:        fmt.Sprintf("game_created_%s", ga.gameID),
+		Type:      core.EventGameCreated,
+		GameID:    ga.gameID,
+		Timestamp: time.Now(),
+		Payload:   core.EncodePayload(core.GameCreatedPayload{Seed: seed}),
+	}})
+}
+
+// SetScheduler lets the actor schedule its own phase transitions. Timers are
+// delivered back as PHASE_TRANSITION actions. Must be called before Start.
+func (ga *GameActor) SetScheduler(scheduler *game.Scheduler) {
+	ga.phaseManager = game.NewPhaseManager(scheduler, ga.gameID, ga.state.Settings)
+}
+
+// armPhaseTimer replaces any pending phase timer with one that ends the
+// current phase at Phase.StartTime + Phase.Duration
+func (ga *GameActor) armPhaseTimer() {
+	if ga.phaseManager == nil {
+		return
+	}
+
+	ga.phaseManager.CancelPhaseTransitions()
+	if ga.state.Phase.Type == core.PhaseLobby || ga.state.Phase.Type == core.PhaseGameOver {
+		return
+	}
+	ga.phaseManager.ScheduleNextTransition(ga.state.Phase)
+}
+
+// updatePhaseTimer keeps the phase timer in step with an applied event
+func (ga *GameActor) updatePhaseTimer(event core.Event) {
+	switch event.Type {
+	case core.EventGameStarted, core.EventPhaseChanged, core.EventVictoryCondition, core.EventGameEnded:
+		ga.armPhaseTimer()
+	}
+}
+
 // SendAction sends an action to the actor's mailbox
 func (ga *GameActor) SendAction(action core.Action) {
 	select {
@@ -107,7 +273,7 @@ func (ga *GameActor) HandleTimer(timer game.Timer) {
 	// Convert timer action to game action
 	action := core.Action{
 		Type:      core.ActionType(timer.Action.Type),
-		PlayerID:  "", // System action
+		PlayerID:  core.SystemPlayerID,
 		GameID:    ga.gameID,
 		Timestamp: time.Now(),
 		Payload:   timer.Action.Payload,
@@ -121,7 +287,8 @@ func (ga *GameActor) processLoop() {
 	defer func() {
 		if r := recover(); r != nil {
 			log.Printf("GameActor %s: Panic recovered: %v", ga.gameID, r)
-			// The supervisor will detect this actor has stopped and can restart it
+			// Signal the supervisor, which restarts the actor from persistence
+			close(ga.failed)
 		}
 	}()
 
@@ -138,29 +305,111 @@ func (ga *GameActor) processLoop() {
 
 // eventLoop handles event persistence and broadcasting
 func (ga *GameActor) eventLoop() {
+	defer close(ga.flushed)
+
 	for {
 		select {
-		case event := <-ga.events:
-			// Persist the event
-			if err := ga.datastore.AppendEvent(ga.gameID, event); err != nil {
-				log.Printf("GameActor %s: Failed to persist event: %v", ga.gameID, err)
-			}
-
-			// Broadcast to clients
-			if err := ga.broadcaster.BroadcastToGame(ga.gameID, event); err != nil {
-				log.Printf("GameActor %s: Failed to broadcast event: %v", ga.gameID, err)
-			}
-
+		case entry := <-ga.events:
+			ga.processOutboxEntry(entry)
 		case <-ga.shutdown:
+			// Queued events are already in the state, so they must reach the store
+			ga.flushOutbox()
+			return
+		}
+	}
+}
+
+// flushOutbox processes the entries left in the outbox without waiting for more
+func (ga *GameActor) flushOutbox() {
+	for {
+		select {
+		case entry := <-ga.events:
+			ga.processOutboxEntry(entry)
+		default:
 			return
 		}
 	}
 }
 
+// processOutboxEntry persists and delivers one queued event, or serves a
+// queued catch-up request
+func (ga *GameActor) processOutboxEntry(entry outboxEntry) {
+	if entry.catchUp != nil {
+		ga.sendCatchUp(*entry.catchUp)
+		return
+	}
+	if entry.reply != nil {
+		if err := ga.broadcaster.SendToPlayer(ga.gameID, entry.reply.PlayerID, *entry.reply); err != nil {
+			log.Printf("GameActor %s: Failed to send %s to player %s: %v", ga.gameID, entry.reply.Type, entry.reply.PlayerID, err)
+		}
+		return
+	}
+
+	event := entry.event
+
+	// Persist the event
+	if err := ga.datastore.AppendEvent(ga.gameID, event); err != nil {
+		log.Printf("GameActor %s: Failed to persist event: %v", ga.gameID, err)
+	}
+
+	// Snapshot only after the events it covers are in the stream
+	if entry.snapshot != nil {
+		if err := ga.datastore.SaveSnapshot(ga.gameID, entry.snapshot); err != nil {
+			log.Printf("GameActor %s: Failed to save snapshot: %v", ga.gameID, err)
+		}
+	}
+
+	ga.deliver(entry)
+}
+
+// Step handles one action synchronously: the action and every event it
+// produces are processed before Step returns, which returns those events.
+// It lets an in-process driver such as the simulator play a game without
+// goroutines or timers. Must not be used on an actor that has been started.
+func (ga *GameActor) Step(action core.Action) []core.Event {
+	ga.handleAction(action)
+
+	var events []core.Event
+	for len(ga.events) > 0 {
+		entry := <-ga.events
+		if entry.catchUp == nil && entry.reply == nil {
+			events = append(events, entry.event)
+		}
+		ga.processOutboxEntry(entry)
+	}
+	return events
+}
+
+// State returns the actor's live game state. Only a driver using Step may
+// read it; a started actor's state belongs to its goroutine.
+func (ga *GameActor) State() *core.GameState {
+	return ga.state
+}
+
+// LatestState returns the state as of the last applied event. Unlike State it
+// is safe to call while the actor is running: ApplyEvent never writes to a
+// state it has been given, so the published copy is never modified.
+func (ga *GameActor) LatestState() core.GameState {
+	return *ga.latest.Load()
+}
+
+// publishState makes the current state visible to LatestState
+func (ga *GameActor) publishState() {
+	latest := *ga.state
+	ga.latest.Store(&latest)
+}
+
 // handleAction processes a single action and generates events
 func (ga *GameActor) handleAction(action core.Action) {
 	log.Printf("GameActor %s: Processing action %s from player %s", ga.gameID, action.Type, action.PlayerID)
 
+	// Only the server itself may move the game along
+	if core.IsSystemAction(action.Type) && action.PlayerID != core.SystemPlayerID {
+		log.Printf("GameActor %s: Ignoring system action %s from player %s", ga.gameID, action.Type, action.PlayerID)
+		ga.reject(action, core.ActionErrorf(core.CodeInvalidAction, "", "only the server may send %s", action.Type))
+		return
+	}
+
 	var events []core.Event
 
 	switch action.Type {
@@ -168,32 +417,83 @@ func (ga *GameActor) handleAction(action core.Action) {
 		events = ga.handleJoinGame(action)
 	case core.ActionLeaveGame:
 		events = ga.handleLeaveGame(action)
+	case core.ActionStartGame:
+		events = ga.handleStartGame(action)
 	case core.ActionSubmitVote:
 		events = ga.handleSubmitVote(action)
 	case core.ActionSubmitNightAction:
 		events = ga.handleSubmitNightAction(action)
 	case core.ActionMineTokens:
 		events = ga.handleMineTokens(action)
-	case core.ActionType("PHASE_TRANSITION"):
-		events = ga.handlePhaseTransition(action)
+	case core.ActionSendMessage:
+		events = ga.handleSendMessage(action)
+	case core.ActionSendFactionMessage:
+		events = ga.handleSendFactionMessage(action)
+	case core.ActionReconnect:
+		ga.handleReconnect(action)
+		return
+	case core.ActionPhaseTransition:
+		ga.handlePhaseTransition(action)
+		return
+	case core.ActionPlayerAway:
+		ga.handlePlayerAway(action)
+		return
+	case core.ActionPlayerBack:
+		ga.handlePlayerBack(action)
+		return
+	case core.ActionAwayTimeout:
+		ga.handleAwayTimeout(action)
+		return
 	default:
 		log.Printf("GameActor %s: Unknown action type: %s", ga.gameID, action.Type)
+		ga.reject(action, core.ActionErrorf(core.CodeUnknownAction, "", "unknown action type %s", action.Type))
 		return
 	}
 
 	// Apply events to state and send to event loop
 	ga.applyAndBroadcast(events)
+
+	// GAME_STARTED opens day 1's SITREP, set up like any phase entered later
+	if action.Type == core.ActionStartGame && len(events) > 0 {
+		ga.applyAndBroadcast(ga.enterPhaseEvents(ga.state.Phase.Type))
+	}
+
+	// A client that tagged the action is told it was accepted
+	if action.RequestID != "" && len(events) > 0 {
+		ga.acknowledge(action)
+	}
 }
 
 // applyAndBroadcast applies events to state and queues them for persistence/broadcast
 func (ga *GameActor) applyAndBroadcast(events []core.Event) {
 	for _, event := range events {
+		// The actor is the only writer, so the event count gives a gap-free sequence
+		event.Sequence = ga.state.EventCount + 1
+
+		// Update in place so the managers bound to ga.state see the change
 		newState := core.ApplyEvent(*ga.state, event)
-		ga.state = &newState
+		*ga.state = newState
+		ga.publishState()
+		ga.updatePhaseTimer(event)
+
+		entry := outboxEntry{event: event, recipients: ga.eventRecipients(event)}
+		if event.Type == core.EventAIConversionSuccess {
+			history := ga.factionChatHistory(event.PlayerID)
+			entry.followUp = &history
+		}
+		if ga.shouldSnapshot(event) {
+			snapshot, err := cloneState(ga.state)
+			if err != nil {
+				log.Printf("GameActor %s: Failed to copy state for snapshot: %v", ga.gameID, err)
+			} else {
+				entry.snapshot = snapshot
+				ga.eventsSinceSnapshot = 0
+			}
+		}
 
 		// Send to event loop for persistence and broadcasting
 		select {
-		case ga.events <- event:
+		case ga.events <- entry:
 			// Event queued successfully
 		default:
 			log.Printf("GameActor %s: Event queue full, dropping event", ga.gameID)
@@ -201,18 +501,101 @@ func (ga *GameActor) applyAndBroadcast(events []core.Event) {
 	}
 }
 
+// eventRecipients lists the players who may see a private or factional event,
+// evaluated against the state the event produced
+func (ga *GameActor) eventRecipients(event core.Event) []string {
+	if core.ClassifyEvent(event) == core.VisibilityPublic {
+		return nil
+	}
+
+	var recipients []string
+	for playerID := range ga.state.Players {
+		if core.CanPlayerSeeEvent(*ga.state, event, playerID) {
+			recipients = append(recipients, playerID)
+		}
+	}
+	sort.Strings(recipients)
+	return recipients
+}
+
+// deliver sends an event to the clients allowed to see it. Public events are
+// redacted and broadcast, everything else goes only to its recipients.
+func (ga *GameActor) deliver(entry outboxEntry) {
+	if core.ClassifyEvent(entry.event) == core.VisibilityPublic {
+		if err := ga.broadcaster.BroadcastToGame(ga.gameID, core.RedactEvent(entry.event)); err != nil {
+			log.Printf("GameActor %s: Failed to broadcast event: %v", ga.gameID, err)
+		}
+		return
+	}
+
+	for _, playerID := range entry.recipients {
+		if err := ga.broadcaster.SendToPlayer(ga.gameID, playerID, entry.event); err != nil {
+			log.Printf("GameActor %s: Failed to send event to player %s: %v", ga.gameID, playerID, err)
+		}
+	}
+
+	if entry.followUp != nil {
+		if err := ga.broadcaster.SendToPlayer(ga.gameID, entry.followUp.PlayerID, *entry.followUp); err != nil {
+			log.Printf("GameActor %s: Failed to send event to player %s: %v", ga.gameID, entry.followUp.PlayerID, err)
+		}
+	}
+}
+
+// factionChatHistory builds the private catch-up event that gives a newly
+// converted player the faction channel's history
+func (ga *GameActor) factionChatHistory(playerID string) core.Event {
+	messages := make([]core.ChatMessage, len(ga.state.FactionChatMessages))
+	copy(messages, ga.state.FactionChatMessages)
+
+	return core.Event{
+		ID:        fmt.Sprintf("faction_history_%s_%d", playerID, time.Now().UnixNano()),
+		Type:      core.EventFactionChatHistory,
+		GameID:    ga.gameID,
+		PlayerID:  playerID,
+		Timestamp: time.Now(),
+		Payload:   core.EncodePayload(core.FactionChatHistoryPayload{Messages: messages}),
+	}
+}
+
+// shouldSnapshot applies the snapshot policy to an event that was just applied
+func (ga *GameActor) shouldSnapshot(event core.Event) bool {
+	ga.eventsSinceSnapshot++
+
+	switch event.Type {
+	case core.EventPhaseChanged, core.EventGameEnded:
+		return true
+	}
+	return ga.snapshotInterval > 0 && ga.eventsSinceSnapshot >= ga.snapshotInterval
+}
+
+// cloneState deep-copies the state so a queued snapshot is unaffected by later events
+func cloneState(state *core.GameState) (*core.GameState, error) {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal game state: %w", err)
+	}
+
+	var clone core.GameState
+	if err := json.Unmarshal(data, &clone); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal game state: %w", err)
+	}
+	return &clone, nil
+}
+
 func (ga *GameActor) handleJoinGame(action core.Action) []core.Event {
 	playerName, _ := action.Payload["name"].(string)
 	jobTitle, _ := action.Payload["job_title"].(string)
 
-	// Check if game is full
-	if len(ga.state.Players) >= ga.state.Settings.MaxPlayers {
-		return nil // Game full, ignore join request
-	}
-
 	// Check if player already joined
 	if _, exists := ga.state.Players[action.PlayerID]; exists {
-		return nil // Player already in game
+		ga.reject(action, core.ActionErrorf(core.CodeAlreadyJoined, "", "player already in game"))
+		return nil
+	}
+
+	// Check if game is full
+	if len(ga.state.Players) >= ga.state.Settings.MaxPlayers {
+		ga.reject(action, core.ActionErrorf(core.CodeGameFull, "", "game is full"))
+		return nil
 	}
 
 	// Auto-assign job title if not provided
@@ -231,10 +614,10 @@ func (ga *GameActor) handleJoinGame(action core.Action) []core.Event {
 		GameID:    ga.gameID,
 		PlayerID:  action.PlayerID,
 		Timestamp: time.Now(),
-		Payload: map[string]interface{}{
-			"name":      playerName,
-			"job_title": jobTitle,
-		},
+		Payload: core.EncodePayload(core.PlayerJoinedPayload{
+			Name:     playerName,
+			JobTitle: jobTitle,
+		}),
 	}
 
 	return []core.Event{event}
@@ -243,7 +626,8 @@ func (ga *GameActor) handleJoinGame(action core.Action) []core.Event {
 func (ga *GameActor) handleLeaveGame(action core.Action) []core.Event {
 	// Check if player is in game
 	if _, exists := ga.state.Players[action.PlayerID]; !exists {
-		return nil // Player not in game
+		ga.reject(action, core.ActionErrorf(core.CodePlayerNotFound, "", "player not found"))
+		return nil
 	}
 
 	event := core.Event{
@@ -258,15 +642,41 @@ func (ga *GameActor) handleLeaveGame(action core.Action) []core.Event {
 	return []core.Event{event}
 }
 
+// handleStartGame moves the game out of the lobby once the roster has joined.
+// Host checks happen in the LobbyActor, which sends this after the roster.
+func (ga *GameActor) handleStartGame(action core.Action) []core.Event {
+	if ga.state.Phase.Type != core.PhaseLobby {
+		ga.reject(action, core.ActionErrorf(core.CodeWrongPhase, "", "game has already started"))
+		return nil
+	}
+
+	if len(ga.state.Players) < ga.state.Settings.MinPlayers {
+		log.Printf("GameActor %s: Cannot start with %d players, need %d", ga.gameID, len(ga.state.Players), ga.state.Settings.MinPlayers)
+		ga.reject(action, core.ActionErrorf(core.CodeNotEnoughPlayers, "", "need %d players to start, have %d", ga.state.Settings.MinPlayers, len(ga.state.Players)))
+		return nil
+	}
+
+	events, err := game.NewGameStartManager(ga.state, ga.startDecks).StartGame()
+	if err != nil {
+		log.Printf("GameActor %s: Failed to start game: %v", ga.gameID, err)
+		ga.reject(action, err)
+		return nil
+	}
+
+	// The host who started the game is recorded on GAME_STARTED
+	events[0].PlayerID = action.PlayerID
+	return events
+}
+
 func (ga *GameActor) handleSubmitVote(action core.Action) []core.Event {
 	// Delegate to VotingManager for complex business logic
 	events, err := ga.votingManager.HandleVoteAction(action)
 	if err != nil {
 		log.Printf("GameActor %s: Invalid vote action from player %s: %v", ga.gameID, action.PlayerID, err)
-		// Could send a private error event back to the player here
+		ga.reject(action, err)
 		return nil
 	}
-	
+
 	return events
 }
 
@@ -275,10 +685,10 @@ func (ga *GameActor) handleSubmitNightAction(action core.Action) []core.Event {
 	events, err := ga.roleAbilityManager.HandleNightAction(action)
 	if err != nil {
 		log.Printf("GameActor %s: Invalid night action from player %s: %v", ga.gameID, action.PlayerID, err)
-		// Could send a private error event back to the player here
+		ga.reject(action, err)
 		return nil
 	}
-	
+
 	return events
 }
 
@@ -287,39 +697,358 @@ func (ga *GameActor) handleMineTokens(action core.Action) []core.Event {
 	events, err := ga.miningManager.HandleMineAction(action)
 	if err != nil {
 		log.Printf("GameActor %s: Mining action error from player %s: %v", ga.gameID, action.PlayerID, err)
+		ga.reject(action, err)
 		return nil
 	}
-	
+
 	return events
 }
 
-func (ga *GameActor) handlePhaseTransition(action core.Action) []core.Event {
+// reject sends the player an ACTION_REJECTED explaining why their action was
+// refused
+func (ga *GameActor) reject(action core.Action, err error) {
+	ga.reply(action, core.EventActionRejected, rejectionPayload(action, err))
+}
+
+// rejectionPayload builds the ACTION_REJECTED payload for a refused action
+func rejectionPayload(action core.Action, err error) map[string]interface{} {
+	actionErr := core.AsActionError(err)
+	return core.EncodePayload(core.ActionRejectedPayload{
+		ActionType: action.Type,
+		Code:       actionErr.Code,
+		Message:    actionErr.Message,
+		Field:      actionErr.Field,
+		RequestID:  action.RequestID,
+	})
+}
+
+// acknowledge sends the player an ACK for an action that produced events
+func (ga *GameActor) acknowledge(action core.Action) {
+	ga.reply(action, core.EventActionAck, core.EncodePayload(core.ActionAckPayload{
+		ActionType: action.Type,
+		RequestID:  action.RequestID,
+		Sequence:   ga.state.EventCount,
+	}))
+}
+
+// reply queues a private answer to the acting player behind the events already
+// produced, so it cannot overtake them. Like a catch-up it is neither applied
+// nor persisted.
+func (ga *GameActor) reply(action core.Action, eventType core.EventType, payload map[string]interface{}) {
+	event := core.Event{
+		ID:        fmt.Sprintf("%s_%s_%d", strings.ToLower(string(eventType)), action.PlayerID, time.Now().UnixNano()),
+		Type:      eventType,
+		GameID:    ga.gameID,
+		PlayerID:  action.PlayerID,
+		Timestamp: time.Now(),
+		Payload:   payload,
+	}
+
+	select {
+	case ga.events <- outboxEntry{reply: &event}:
+	default:
+		log.Printf("GameActor %s: Event queue full, dropping %s for %s", ga.gameID, eventType, action.PlayerID)
+	}
+}
+
+func (ga *GameActor) handleSendMessage(action core.Action) []core.Event {
+	message, _ := action.Payload["message"].(string)
+	message = strings.TrimSpace(message)
+
+	// Deactivated and silenced players cannot speak
+	player, exists := ga.state.Players[action.PlayerID]
+	if !exists {
+		ga.reject(action, core.ActionErrorf(core.CodePlayerNotFound, "", "player not found"))
+		return nil
+	}
+	if !core.CanPlayerSendMessage(*player) {
+		log.Printf("GameActor %s: Rejected chat message from player %s", ga.gameID, action.PlayerID)
+		ga.reject(action, core.ActionErrorf(core.CodeActionBlocked, "", "player cannot send messages"))
+		return nil
+	}
+
+	if message == "" || utf8.RuneCountInString(message) > core.MaxChatMessageLength {
+		log.Printf("GameActor %s: Rejected chat message of %d characters from player %s", ga.gameID, utf8.RuneCountInString(message), action.PlayerID)
+		ga.reject(action, core.ActionErrorf(core.CodeInvalidValue, "message", "message must be 1 to %d characters", core.MaxChatMessageLength))
+		return nil
+	}
+
+	event := core.Event{
+		ID:        fmt.Sprintf("chat_message_%s_%d", action.PlayerID, time.Now().UnixNano()),
+		Type:      core.EventChatMessage,
+		GameID:    ga.gameID,
+		PlayerID:  action.PlayerID,
+		Timestamp: time.Now(),
+		Payload: core.EncodePayload(core.ChatMessagePayload{
+			PlayerName: player.Name,
+			Message:    message,
+		}),
+	}
+
+	return []core.Event{event}
+}
+
+func (ga *GameActor) handleSendFactionMessage(action core.Action) []core.Event {
+	message, _ := action.Payload["message"].(string)
+	message = strings.TrimSpace(message)
+
+	// Only living members of the AI faction can use its channel
+	player, exists := ga.state.Players[action.PlayerID]
+	if !exists {
+		ga.reject(action, core.ActionErrorf(core.CodePlayerNotFound, "", "player not found"))
+		return nil
+	}
+	if !player.IsAlive || player.Alignment != "ALIGNED" {
+		log.Printf("GameActor %s: Rejected faction message from player %s", ga.gameID, action.PlayerID)
+		ga.reject(action, core.ActionErrorf(core.CodeActionBlocked, "", "player cannot use the faction channel"))
+		return nil
+	}
+
+	if message == "" || utf8.RuneCountInString(message) > core.MaxChatMessageLength {
+		log.Printf("GameActor %s: Rejected faction message of %d characters from player %s", ga.gameID, utf8.RuneCountInString(message), action.PlayerID)
+		ga.reject(action, core.ActionErrorf(core.CodeInvalidValue, "message", "message must be 1 to %d characters", core.MaxChatMessageLength))
+		return nil
+	}
+
+	event := core.Event{
+		ID:        fmt.Sprintf("faction_message_%s_%d", action.PlayerID, time.Now().UnixNano()),
+		Type:      core.EventFactionMessage,
+		GameID:    ga.gameID,
+		PlayerID:  action.PlayerID,
+		Timestamp: time.Now(),
+		Payload: core.EncodePayload(core.ChatMessagePayload{
+			PlayerName: player.Name,
+			Message:    message,
+		}),
+	}
+
+	return []core.Event{event}
+}
+
+func (ga *GameActor) handleReconnect(action core.Action) {
+	lastEventID, _ := action.Payload["last_event_id"].(string)
+	lastSequence, _ := action.Payload["last_sequence"].(float64) // JSON numbers decode as float64
+
+	if _, exists := ga.state.Players[action.PlayerID]; !exists {
+		log.Printf("GameActor %s: Rejected reconnect from unknown player %s", ga.gameID, action.PlayerID)
+		ga.reject(action, core.ActionErrorf(core.CodePlayerNotFound, "", "player not found"))
+		return
+	}
+
+	select {
+	case ga.events <- outboxEntry{catchUp: &catchUpRequest{
+		playerID:     action.PlayerID,
+		connectionID: action.ConnectionID,
+		lastSequence: int(lastSequence),
+		lastEventID:  lastEventID,
+	}}:
+	default:
+		log.Printf("GameActor %s: Event queue full, dropping reconnect for %s", ga.gameID, action.PlayerID)
+	}
+}
+
+// sendCatchUp replays the persisted events a player has not seen, filtered for
+// their visibility, and then signals SYNC_COMPLETE. Replay starts at the latest
+// snapshot, so a player whose last event is unknown or older than it gets a
+// projected state snapshot instead.
+func (ga *GameActor) sendCatchUp(request catchUpRequest) {
+	state, err := ga.datastore.LoadSnapshot(ga.gameID)
+	if err != nil || state == nil {
+		state = core.NewGameState(ga.gameID)
+	}
+
+	events, err := ga.datastore.LoadEvents(ga.gameID, state.EventCount)
+	if err != nil {
+		log.Printf("GameActor %s: Failed to load events for catch-up: %v", ga.gameID, err)
+		return
+	}
+
+	// Replay from the snapshot so visibility is judged against the state at each event
+	found := request.lastSequence > 0 && request.lastSequence == state.EventCount
+	var missed []core.Event
+	for _, event := range events {
+		newState := core.ApplyEvent(*state, event)
+		state = &newState
+
+		if !found {
+			if request.lastSequence > 0 {
+				found = event.Sequence == request.lastSequence
+			} else {
+				found = event.ID == request.lastEventID
+			}
+			continue
+		}
+
+		if !core.CanPlayerSeeEvent(*state, event, request.playerID) {
+			continue
+		}
+		if core.ClassifyEvent(event) == core.VisibilityPublic {
+			event = core.RedactEvent(event)
+		}
+		missed = append(missed, event)
+	}
+
+	if !found {
+		missed = []core.Event{{
+			ID:        fmt.Sprintf("sync_state_%s_%d", request.playerID, time.Now().UnixNano()),
+			Type:      core.EventGameStateSnapshot,
+			GameID:    ga.gameID,
+			PlayerID:  request.playerID,
+			Timestamp: time.Now(),
+			Payload: map[string]interface{}{
+				"state": core.ProjectStateForPlayer(*state, request.playerID),
+			},
+		}}
+	}
+
+	for _, event := range missed {
+		if err := ga.sendCatchUpEvent(request, event); err != nil {
+			log.Printf("GameActor %s: Failed to send catch-up to player %s: %v", ga.gameID, request.playerID, err)
+			return
+		}
+	}
+
+	syncEvent := core.Event{
+		ID:        fmt.Sprintf("sync_complete_%s_%d", request.playerID, time.Now().UnixNano()),
+		Type:      core.EventSyncComplete,
+		GameID:    ga.gameID,
+		PlayerID:  request.playerID,
+		Timestamp: time.Now(),
+		Payload: map[string]interface{}{
+			"events_replayed": len(missed),
+		},
+	}
+	if err := ga.sendCatchUpEvent(request, syncEvent); err != nil {
+		log.Printf("GameActor %s: Failed to send sync complete to player %s: %v", ga.gameID, request.playerID, err)
+	}
+}
+
+// sendCatchUpEvent sends a catch-up event to the reconnecting connection
+// only, leaving the player's other connections alone
+func (ga *GameActor) sendCatchUpEvent(request catchUpRequest, event core.Event) error {
+	if request.connectionID == "" {
+		return ga.broadcaster.SendToPlayer(ga.gameID, request.playerID, event)
+	}
+	return ga.broadcaster.SendToConnection(ga.gameID, request.connectionID, event)
+}
+
+// handlePhaseTransition ends the current phase and starts the next one. Each
+// step is applied before the next is built, so the SITREP sees the night's
+// results and the win check sees the verdict.
+func (ga *GameActor) handlePhaseTransition(action core.Action) {
 	nextPhase, _ := action.Payload["next_phase"].(string)
+	duration, _ := action.Payload["duration"].(float64)
 
-	var events []core.Event
+	// Timers name the phase they end; drop one that fires after the phase moved on
+	if currentPhase, ok := action.Payload["current_phase"].(string); ok && core.PhaseType(currentPhase) != ga.state.Phase.Type {
+		log.Printf("GameActor %s: Ignoring stale transition from %s, phase is %s", ga.gameID, currentPhase, ga.state.Phase.Type)
+		return
+	}
 
-	// If we're transitioning FROM night phase, resolve night actions first
-	if ga.state.Phase.Type == core.PhaseNight {
-		// Simplified night resolution - in full implementation would use game package
-		// Clear temporary night resolution state
-		ga.state.BlockedPlayersTonight = nil
-		ga.state.ProtectedPlayersTonight = nil
+	// Leaving the lobby only changes the phase; the game rules start afterwards
+	inGame := ga.state.Phase.Type != core.PhaseLobby
+
+	if inGame {
+		ga.applyAndBroadcast(ga.leavePhaseEvents(ga.state.Phase.Type))
 	}
 
-	// Create phase transition event
-	phaseEvent := core.Event{
+	ga.applyAndBroadcast([]core.Event{{
 		ID:        fmt.Sprintf("phase_transition_%s_%d", nextPhase, time.Now().UnixNano()),
 		Type:      core.EventPhaseChanged,
 		GameID:    ga.gameID,
 		PlayerID:  "",
 		Timestamp: time.Now(),
-		Payload: map[string]interface{}{
-			"previous_phase": string(ga.state.Phase.Type),
-			"next_phase":     nextPhase,
-			"day_number":     ga.state.DayNumber,
-		},
+		Payload: core.EncodePayload(core.PhaseChangedPayload{
+			PhaseType:     core.PhaseType(nextPhase),
+			PreviousPhase: ga.state.Phase.Type,
+			Duration:      int(duration),
+			DayNumber:     ga.state.DayNumber,
+		}),
+	}})
+
+	if inGame {
+		ga.applyAndBroadcast(ga.enterPhaseEvents(ga.state.Phase.Type))
+		ga.applyAndBroadcast(ga.winConditionEvents())
+		ga.coverAwayNightActions()
 	}
-	events = append(events, phaseEvent)
+}
 
-	return events
+// leavePhaseEvents resolves what happened during the phase that is ending
+func (ga *GameActor) leavePhaseEvents(phase core.PhaseType) []core.Event {
+	switch phase {
+	case core.PhaseNight:
+		return ga.nightResolutionManager.ResolveNightActions()
+	case core.PhaseNomination:
+		return ga.votingManager.ResolveNomination()
+	case core.PhaseVerdict:
+		events, eliminatedID := ga.votingManager.ResolveVerdict()
+		if eliminatedID == "" {
+			return events
+		}
+		elimination, err := ga.eliminationManager.CreateEliminationEvent(eliminatedID)
+		if err != nil {
+			log.Printf("GameActor %s: Failed to eliminate %s: %v", ga.gameID, eliminatedID, err)
+			return events
+		}
+		return append(events, elimination)
+	}
+	return nil
+}
+
+// enterPhaseEvents sets up the phase that just started. In SITREP the report
+// covers the night before the day's crisis replaces the outgoing one.
+func (ga *GameActor) enterPhaseEvents(phase core.PhaseType) []core.Event {
+	switch phase {
+	case core.PhaseSitrep:
+		events := []core.Event{ga.sitrepGenerator.CreateSitrepEvent()}
+		crisis, err := ga.crisisManager.CreateCrisisTriggeredEvent(ga.crisisManager.NextCrisisType())
+		if err != nil {
+			log.Printf("GameActor %s: Failed to trigger crisis: %v", ga.gameID, err)
+			return events
+		}
+		return append(events, crisis)
+	case core.PhaseNomination:
+		return []core.Event{ga.votingManager.CreateVoteStartedEvent(core.VoteNomination)}
+	case core.PhaseVerdict:
+		return []core.Event{ga.votingManager.CreateVoteStartedEvent(core.VoteVerdict)}
+	}
+	return nil
+}
+
+// winConditionEvents ends the game once either faction has won
+func (ga *GameActor) winConditionEvents() []core.Event {
+	if ga.state.Phase.Type == core.PhaseGameOver {
+		return nil
+	}
+
+	winCondition := core.CheckWinCondition(*ga.state)
+	if winCondition == nil {
+		return nil
+	}
+
+	log.Printf("GameActor %s: %s win by %s", ga.gameID, winCondition.Winner, winCondition.Condition)
+	now := time.Now()
+	return []core.Event{
+		{
+			ID:        fmt.Sprintf("victory_%s", ga.gameID),
+			Type:      core.EventVictoryCondition,
+			GameID:    ga.gameID,
+			Timestamp: now,
+			Payload: core.EncodePayload(core.VictoryConditionPayload{
+				Winner:      winCondition.Winner,
+				Condition:   winCondition.Condition,
+				Description: winCondition.Description,
+			}),
+		},
+		{
+			ID:        fmt.Sprintf("game_ended_%s", ga.gameID),
+			Type:      core.EventGameEnded,
+			GameID:    ga.gameID,
+			Timestamp: now,
+			Payload: core.EncodePayload(core.GameEndedPayload{
+				WinningFaction: winCondition.Winner,
+				Reason:         winCondition.Condition,
+			}),
+		},
+	}
 }
@@ -1,11 +1,14 @@
 package actors
 
 import (
+	"fmt"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/xjhc/alignment/core"
+	"github.com/xjhc/alignment/server/internal/game"
 )
 
 // MockDataStore implements DataStore interface for testing
@@ -73,15 +76,17 @@ func (m *MockDataStore) GetEvents() []core.Event {
 
 // MockBroadcaster implements Broadcaster interface for testing
 type MockBroadcaster struct {
-	gameEvents   []core.Event
-	playerEvents map[string][]core.Event
-	mutex        sync.RWMutex
+	gameEvents       []core.Event
+	playerEvents     map[string][]core.Event
+	connectionEvents map[string][]core.Event
+	mutex            sync.RWMutex
 }
 
 func NewMockBroadcaster() *MockBroadcaster {
 	return &MockBroadcaster{
-		gameEvents:   make([]core.Event, 0),
-		playerEvents: make(map[string][]core.Event),
+		gameEvents:       make([]core.Event, 0),
+		playerEvents:     make(map[string][]core.Event),
+		connectionEvents: make(map[string][]core.Event),
 	}
 }
 
@@ -103,6 +108,19 @@ func (m *MockBroadcaster) SendToPlayer(gameID, playerID string, event core.Event
 	return nil
 }
 
+func (m *MockBroadcaster) SendToConnection(gameID, connectionID string, event core.Event) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.connectionEvents[connectionID] = append(m.connectionEvents[connectionID], event)
+	return nil
+}
+
+func (m *MockBroadcaster) GetConnectionEvents(connectionID string) []core.Event {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return append([]core.Event(nil), m.connectionEvents[connectionID]...)
+}
+
 func (m *MockBroadcaster) GetGameEvents() []core.Event {
 	m.mutex.RLock()
 	defer m.mutex.RUnlock()
@@ -487,6 +505,7 @@ func TestGameActor_InvalidVotePhase(t *testing.T) {
 		Type:      core.ActionSubmitVote,
 		PlayerID:  "player-1",
 		GameID:    "test-game",
+		RequestID: "req-1",
 		Timestamp: time.Now(),
 		Payload: map[string]interface{}{
 			"target_id": "player-2",
@@ -507,6 +526,26 @@ func TestGameActor_InvalidVotePhase(t *testing.T) {
 	if actor.state.VoteState != nil {
 		t.Error("Expected vote state to remain nil for invalid vote")
 	}
+
+	// The voter alone is told why, with their request ID echoed back
+	var rejection *core.Event
+	for _, event := range broadcaster.GetPlayerEvents("player-1") {
+		if event.Type == core.EventActionRejected {
+			rejection = &event
+		}
+	}
+	if rejection == nil {
+		t.Fatal("Expected the voter to receive ACTION_REJECTED")
+	}
+	payload, _ := core.DecodePayload[core.ActionRejectedPayload](*rejection)
+	if payload.ActionType != core.ActionSubmitVote || payload.Code != core.CodeWrongPhase || payload.RequestID != "req-1" || payload.Message == "" {
+		t.Errorf("Unexpected rejection %+v", payload)
+	}
+	for _, event := range broadcaster.GetGameEvents() {
+		if event.Type == core.EventActionRejected {
+			t.Error("Expected the rejection not to be broadcast")
+		}
+	}
 }
 
 // TestGameActor_MiningTokens tests token mining mechanics with selfless mining
@@ -676,3 +715,796 @@ func TestGameActor_ConcurrentActions(t *testing.T) {
 		t.Errorf("Expected %d events after concurrent joins, got %d", playerCount, len(events))
 	}
 }
+
+// TestGameActor_SnapshotPolicy tests periodic and phase-boundary snapshots
+func TestGameActor_SnapshotPolicy(t *testing.T) {
+	datastore := NewMockDataStore()
+	broadcaster := NewMockBroadcaster()
+
+	actor := NewGameActor("test-game", datastore, broadcaster)
+	actor.snapshotInterval = 3
+	actor.Start()
+	defer actor.Stop()
+
+	for i := 0; i < 4; i++ {
+		actor.SendAction(core.Action{
+			Type:      core.ActionJoinGame,
+			PlayerID:  fmt.Sprintf("player-%d", i),
+			GameID:    "test-game",
+			Timestamp: time.Now(),
+			Payload:   map[string]interface{}{"name": fmt.Sprintf("Player%d", i)},
+		})
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	snapshot, err := datastore.LoadSnapshot("test-game")
+	if err != nil {
+		t.Fatalf("Expected a periodic snapshot, got error: %v", err)
+	}
+	if snapshot.EventCount != 3 {
+		t.Errorf("Expected snapshot at event 3, got %d", snapshot.EventCount)
+	}
+	if len(snapshot.Players) != 3 {
+		t.Errorf("Expected snapshot to be unaffected by later events, got %d players", len(snapshot.Players))
+	}
+
+	// A phase change snapshots regardless of the interval
+	actor.SendAction(core.Action{
+		Type:      core.ActionPhaseTransition,
+		PlayerID:  core.SystemPlayerID,
+		GameID:    "test-game",
+		Timestamp: time.Now(),
+		Payload:   map[string]interface{}{"next_phase": string(core.PhaseSitrep)},
+	})
+
+	time.Sleep(100 * time.Millisecond)
+
+	snapshot, err = datastore.LoadSnapshot("test-game")
+	if err != nil {
+		t.Fatalf("Expected a phase snapshot, got error: %v", err)
+	}
+	if snapshot.EventCount != 5 {
+		t.Errorf("Expected snapshot at event 5, got %d", snapshot.EventCount)
+	}
+}
+
+// TestGameActor_EventVisibility tests that private and factional events reach only their audience
+func TestGameActor_EventVisibility(t *testing.T) {
+	datastore := NewMockDataStore()
+	broadcaster := NewMockBroadcaster()
+
+	actor := NewGameActor("test-game", datastore, broadcaster)
+	actor.state.Players["human"] = &core.Player{ID: "human", IsAlive: true, Alignment: "HUMAN"}
+	actor.state.Players["aligned"] = &core.Player{ID: "aligned", IsAlive: true, Alignment: "ALIGNED"}
+
+	// Deliver synchronously instead of through the event loop
+	actor.applyAndBroadcast([]core.Event{
+		{
+			ID:       "audit_private",
+			Type:     core.EventRunAudit,
+			GameID:   "test-game",
+			PlayerID: "human",
+			Payload:  map[string]interface{}{"true_alignment": "HUMAN", "ai_faction_only": true},
+		},
+		{
+			ID:       "role",
+			Type:     core.EventRoleAssigned,
+			GameID:   "test-game",
+			PlayerID: "human",
+			Payload:  map[string]interface{}{"role_type": "CISO"},
+		},
+		{
+			ID:       "vote",
+			Type:     core.EventVoteCast,
+			GameID:   "test-game",
+			PlayerID: "human",
+			Payload:  map[string]interface{}{"target_id": "aligned", "vote_type": "NOMINATION"},
+		},
+	})
+	for i := 0; i < 3; i++ {
+		actor.deliver(<-actor.events)
+	}
+
+	alignedEvents := broadcaster.GetPlayerEvents("aligned")
+	if len(alignedEvents) != 1 || alignedEvents[0].ID != "audit_private" {
+		t.Errorf("Expected aligned player to receive only the faction audit, got %v", alignedEvents)
+	}
+
+	humanEvents := broadcaster.GetPlayerEvents("human")
+	if len(humanEvents) != 1 || humanEvents[0].ID != "role" {
+		t.Errorf("Expected human to receive only their role, got %v", humanEvents)
+	}
+
+	gameEvents := broadcaster.GetGameEvents()
+	if len(gameEvents) != 1 {
+		t.Fatalf("Expected only the vote to be broadcast, got %d events", len(gameEvents))
+	}
+	if _, exists := gameEvents[0].Payload["target_id"]; exists {
+		t.Error("Expected broadcast vote to hide its target")
+	}
+}
+
+// TestGameActor_FactionChannel tests that faction chat reaches only aligned players
+// and that newly converted players receive its history
+func TestGameActor_FactionChannel(t *testing.T) {
+	datastore := NewMockDataStore()
+	broadcaster := NewMockBroadcaster()
+
+	actor := NewGameActor("test-game", datastore, broadcaster)
+	actor.state.Players["ai"] = &core.Player{ID: "ai", Name: "Bob", IsAlive: true, Alignment: "ALIGNED"}
+	actor.state.Players["human"] = &core.Player{ID: "human", Name: "Alice", IsAlive: true, Alignment: "HUMAN"}
+
+	// Humans cannot post to the faction channel
+	actor.handleAction(core.Action{
+		Type:     core.ActionSendFactionMessage,
+		PlayerID: "human",
+		GameID:   "test-game",
+		Payload:  map[string]interface{}{"message": "let me in"},
+	})
+	actor.handleAction(core.Action{
+		Type:     core.ActionSendFactionMessage,
+		PlayerID: "ai",
+		GameID:   "test-game",
+		Payload:  map[string]interface{}{"message": "target Alice tonight"},
+	})
+	for len(actor.events) > 0 {
+		actor.processOutboxEntry(<-actor.events)
+	}
+
+	if len(actor.state.FactionChatMessages) != 1 {
+		t.Fatalf("Expected 1 faction message, got %d", len(actor.state.FactionChatMessages))
+	}
+	if len(broadcaster.GetGameEvents()) != 0 {
+		t.Error("Expected faction message not to be broadcast")
+	}
+	if types := eventTypes(broadcaster.GetPlayerEvents("human")); fmt.Sprint(types) != fmt.Sprint([]core.EventType{core.EventActionRejected}) {
+		t.Errorf("Expected human to receive only the rejection, got %v", types)
+	}
+	if len(broadcaster.GetPlayerEvents("ai")) != 1 {
+		t.Errorf("Expected aligned player to receive faction message, got %d", len(broadcaster.GetPlayerEvents("ai")))
+	}
+
+	// Convert the human; they should now get the channel history
+	actor.applyAndBroadcast([]core.Event{{
+		ID:       "convert",
+		Type:     core.EventAIConversionSuccess,
+		GameID:   "test-game",
+		PlayerID: "human",
+		Payload:  map[string]interface{}{"converter_id": "ai", "target_id": "human"},
+	}})
+	actor.deliver(<-actor.events)
+
+	humanEvents := broadcaster.GetPlayerEvents("human")[1:]
+	if len(humanEvents) != 2 {
+		t.Fatalf("Expected converted player to receive conversion and history, got %d events", len(humanEvents))
+	}
+	history := humanEvents[1]
+	if history.Type != core.EventFactionChatHistory {
+		t.Fatalf("Expected faction chat history, got %s", history.Type)
+	}
+	payload, err := core.DecodePayload[core.FactionChatHistoryPayload](history)
+	if err != nil {
+		t.Fatalf("Failed to decode faction chat history: %v", err)
+	}
+	messages := payload.Messages
+	if len(messages) != 2 || messages[0].Message != "target Alice tonight" {
+		t.Errorf("Expected history with earlier message and join notice, got %v", messages)
+	}
+}
+
+// TestGameActor_FactionMessageLimits tests that faction messages are trimmed
+// and held to the same length limit as public chat
+func TestGameActor_FactionMessageLimits(t *testing.T) {
+	actor := NewGameActor("test-game", NewMockDataStore(), NewMockBroadcaster())
+	actor.state.Players["ai"] = &core.Player{ID: "ai", Name: "Bob", IsAlive: true, Alignment: "ALIGNED"}
+
+	send := func(message string) []core.Event {
+		return actor.Step(core.Action{Type: core.ActionSendFactionMessage, PlayerID: "ai", GameID: "test-game",
+			Payload: map[string]interface{}{"message": message}})
+	}
+
+	if events := send("  target Alice  "); len(events) != 1 || events[0].Type != core.EventFactionMessage {
+		t.Fatalf("Expected a faction message, got %v", events)
+	}
+	if messages := actor.state.FactionChatMessages; len(messages) != 1 || messages[0].Message != "target Alice" {
+		t.Errorf("Expected the trimmed message in the faction log, got %+v", messages)
+	}
+
+	for name, events := range map[string][]core.Event{
+		"empty":    send("   "),
+		"too long": send(strings.Repeat("a", core.MaxChatMessageLength+1)),
+	} {
+		if len(events) != 0 {
+			t.Errorf("Expected the %s faction message to be rejected, got %v", name, events)
+		}
+	}
+}
+
+// TestGameActor_ReconnectCatchUp tests replay of missed events followed by SYNC_COMPLETE
+func TestGameActor_ReconnectCatchUp(t *testing.T) {
+	datastore := NewMockDataStore()
+	broadcaster := NewMockBroadcaster()
+
+	actor := NewGameActor("test-game", datastore, broadcaster)
+	actor.Start()
+	defer actor.Stop()
+
+	for _, id := range []string{"player-1", "player-2"} {
+		actor.SendAction(core.Action{
+			Type:      core.ActionJoinGame,
+			PlayerID:  id,
+			GameID:    "test-game",
+			Timestamp: time.Now(),
+			Payload:   map[string]interface{}{"name": id},
+		})
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	events := datastore.GetEvents()
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 persisted events, got %d", len(events))
+	}
+
+	// A private event for player-2 must not be replayed to player-1
+	datastore.AppendEvent("test-game", core.Event{
+		ID:       "role-2",
+		Type:     core.EventRoleAssigned,
+		GameID:   "test-game",
+		PlayerID: "player-2",
+		Payload:  map[string]interface{}{"role_type": "CTO"},
+	})
+
+	actor.SendAction(core.Action{
+		Type:     core.ActionReconnect,
+		PlayerID: "player-1",
+		GameID:   "test-game",
+		Payload:  map[string]interface{}{"last_event_id": events[0].ID},
+	})
+	time.Sleep(50 * time.Millisecond)
+
+	received := broadcaster.GetPlayerEvents("player-1")
+	if len(received) != 2 {
+		t.Fatalf("Expected 1 missed event and SYNC_COMPLETE, got %d events", len(received))
+	}
+	if received[0].ID != events[1].ID {
+		t.Errorf("Expected missed join event %s, got %s", events[1].ID, received[0].ID)
+	}
+	if received[1].Type != core.EventSyncComplete {
+		t.Errorf("Expected SYNC_COMPLETE last, got %s", received[1].Type)
+	}
+
+	// Without a known last event the player gets a state snapshot
+	actor.SendAction(core.Action{
+		Type:     core.ActionReconnect,
+		PlayerID: "player-2",
+		GameID:   "test-game",
+		Payload:  map[string]interface{}{},
+	})
+	time.Sleep(50 * time.Millisecond)
+
+	received = broadcaster.GetPlayerEvents("player-2")
+	if len(received) != 2 || received[0].Type != core.EventGameStateSnapshot {
+		t.Fatalf("Expected state snapshot and SYNC_COMPLETE, got %v", received)
+	}
+	state, _ := received[0].Payload["state"].(core.GameState)
+	if state.Players["player-2"].Role == nil {
+		t.Error("Expected snapshot to include the player's own role")
+	}
+}
+
+// loadRecordingDataStore records where each LoadEvents call starts
+type loadRecordingDataStore struct {
+	*MockDataStore
+	loads []int
+}
+
+func (d *loadRecordingDataStore) LoadEvents(gameID string, afterSequence int) ([]core.Event, error) {
+	d.loads = append(d.loads, afterSequence)
+	return d.MockDataStore.LoadEvents(gameID, afterSequence)
+}
+
+// TestGameActor_CatchUpFromSnapshot tests that catch-up replays only the
+// events after the latest snapshot
+func TestGameActor_CatchUpFromSnapshot(t *testing.T) {
+	datastore := &loadRecordingDataStore{MockDataStore: NewMockDataStore()}
+	broadcaster := NewMockBroadcaster()
+	actor := NewGameActor("test-game", datastore, broadcaster)
+
+	join := func(playerID string) {
+		actor.Step(core.Action{Type: core.ActionJoinGame, PlayerID: playerID, GameID: "test-game", Payload: map[string]interface{}{"name": playerID}})
+	}
+	join("player-1")
+	join("player-2")
+	snapshot, err := cloneState(actor.state)
+	if err != nil {
+		t.Fatalf("Failed to copy state: %v", err)
+	}
+	datastore.SaveSnapshot("test-game", snapshot)
+	join("player-3")
+	join("player-4")
+
+	actor.Step(core.Action{Type: core.ActionReconnect, PlayerID: "player-1", GameID: "test-game", Payload: map[string]interface{}{"last_sequence": float64(3)}})
+	if len(datastore.loads) != 1 || datastore.loads[0] != 2 {
+		t.Errorf("Expected one load after the snapshot's 2 events, got %v", datastore.loads)
+	}
+	received := broadcaster.GetPlayerEvents("player-1")
+	if len(received) != 2 || received[0].Sequence != 4 || received[1].Type != core.EventSyncComplete {
+		t.Errorf("Expected event 4 followed by SYNC_COMPLETE, got %v", received)
+	}
+
+	// A player who last saw an event older than the snapshot gets the state instead
+	actor.Step(core.Action{Type: core.ActionReconnect, PlayerID: "player-2", GameID: "test-game", Payload: map[string]interface{}{"last_sequence": float64(1)}})
+	received = broadcaster.GetPlayerEvents("player-2")
+	if len(received) != 2 || received[0].Type != core.EventGameStateSnapshot {
+		t.Fatalf("Expected state snapshot and SYNC_COMPLETE, got %v", received)
+	}
+	state, _ := received[0].Payload["state"].(core.GameState)
+	if len(state.Players) != 4 {
+		t.Errorf("Expected the snapshot to hold all 4 players, got %d", len(state.Players))
+	}
+}
+
+// TestGameActor_CatchUpToReconnectingConnection tests that a replay goes to
+// the connection that asked for it, not the player's other connections
+func TestGameActor_CatchUpToReconnectingConnection(t *testing.T) {
+	broadcaster := NewMockBroadcaster()
+	actor := NewGameActor("test-game", NewMockDataStore(), broadcaster)
+	actor.Step(core.Action{Type: core.ActionJoinGame, PlayerID: "player-1", GameID: "test-game", Payload: map[string]interface{}{"name": "Alice"}})
+	actor.Step(core.Action{Type: core.ActionJoinGame, PlayerID: "player-2", GameID: "test-game", Payload: map[string]interface{}{"name": "Bob"}})
+
+	actor.Step(core.Action{
+		Type:         core.ActionReconnect,
+		PlayerID:     "player-1",
+		GameID:       "test-game",
+		ConnectionID: "tab-2",
+		Payload:      map[string]interface{}{"last_sequence": float64(1)},
+	})
+
+	received := broadcaster.GetConnectionEvents("tab-2")
+	if len(received) != 2 || received[0].Sequence != 2 || received[1].Type != core.EventSyncComplete {
+		t.Errorf("Expected event 2 and SYNC_COMPLETE on the reconnecting tab, got %v", received)
+	}
+	for _, event := range broadcaster.GetPlayerEvents("player-1") {
+		if event.Type == core.EventSyncComplete || event.Sequence == 2 {
+			t.Errorf("Expected the player's other connections not to be caught up, got %s", event.Type)
+		}
+	}
+}
+
+// TestGameActor_EventSequencing tests that the actor numbers events without gaps
+func TestGameActor_EventSequencing(t *testing.T) {
+	datastore := NewMockDataStore()
+	broadcaster := NewMockBroadcaster()
+
+	actor := NewGameActor("test-game", datastore, broadcaster)
+	actor.Start()
+	defer actor.Stop()
+
+	for i := 0; i < 3; i++ {
+		actor.SendAction(core.Action{
+			Type:      core.ActionJoinGame,
+			PlayerID:  fmt.Sprintf("player-%d", i),
+			GameID:    "test-game",
+			Timestamp: time.Now(),
+			Payload:   map[string]interface{}{"name": fmt.Sprintf("Player%d", i)},
+		})
+	}
+	time.Sleep(50 * time.Millisecond)
+
+	events := datastore.GetEvents()
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 events, got %d", len(events))
+	}
+	for i, event := range events {
+		if event.Sequence != i+1 {
+			t.Errorf("Expected event %d to have sequence %d, got %d", i, i+1, event.Sequence)
+		}
+	}
+
+	// Reconnecting by sequence replays only later events
+	actor.SendAction(core.Action{
+		Type:     core.ActionReconnect,
+		PlayerID: "player-0",
+		GameID:   "test-game",
+		Payload:  map[string]interface{}{"last_sequence": float64(1)},
+	})
+	time.Sleep(50 * time.Millisecond)
+
+	received := broadcaster.GetPlayerEvents("player-0")
+	if len(received) != 3 || received[0].Sequence != 2 || received[1].Sequence != 3 {
+		t.Errorf("Expected events 2 and 3 followed by SYNC_COMPLETE, got %v", received)
+	}
+}
+
+// TestGameActor_StartGame tests that starting a game deals each player a private
+// role and that replaying the start events reproduces the deal
+func TestGameActor_StartGame(t *testing.T) {
+	datastore := NewMockDataStore()
+	broadcaster := NewMockBroadcaster()
+
+	actor := NewGameActor("test-game", datastore, broadcaster)
+	for i := 0; i < actor.state.Settings.MinPlayers; i++ {
+		playerID := fmt.Sprintf("player-%d", i)
+		actor.state.Players[playerID] = &core.Player{ID: playerID, IsAlive: true, Alignment: "HUMAN", Tokens: 1}
+	}
+	lobbyState, err := cloneState(actor.state)
+	if err != nil {
+		t.Fatalf("Failed to copy state: %v", err)
+	}
+
+	// Deliver synchronously instead of through the event loop
+	actor.handleAction(core.Action{Type: core.ActionStartGame, PlayerID: "player-0", GameID: "test-game"})
+	var events []core.Event
+	for len(actor.events) > 0 {
+		entry := <-actor.events
+		events = append(events, entry.event)
+		actor.deliver(entry)
+	}
+
+	if len(events) != 4+len(lobbyState.Players) {
+		t.Fatalf("Expected GAME_STARTED, MANDATE_ACTIVATED, one role per player, then the SITREP and crisis, got %d events", len(events))
+	}
+	if events[0].Type != core.EventGameStarted || events[1].Type != core.EventMandateActivated {
+		t.Errorf("Expected game start then mandate, got %s then %s", events[0].Type, events[1].Type)
+	}
+
+	// Day 1's SITREP opens like every later one
+	dayOne := events[len(events)-2:]
+	if dayOne[0].Type != core.EventSitrepGenerated || dayOne[1].Type != core.EventCrisisTriggered {
+		t.Errorf("Expected the SITREP and crisis after the roles, got %s then %s", dayOne[0].Type, dayOne[1].Type)
+	}
+	if actor.state.CrisisEvent == nil {
+		t.Error("Expected a crisis on day 1")
+	}
+
+	gameEvents := broadcaster.GetGameEvents()
+	if len(gameEvents) != 4 {
+		t.Errorf("Expected only the start, mandate, SITREP and crisis to be broadcast, got %d events", len(gameEvents))
+	}
+
+	aligned := 0
+	for playerID, player := range actor.state.Players {
+		received := broadcaster.GetPlayerEvents(playerID)
+		if len(received) != 1 || received[0].Type != core.EventRoleAssigned || received[0].PlayerID != playerID {
+			t.Errorf("Expected %s to receive only their own role, got %v", playerID, received)
+		}
+		if player.Role == nil {
+			t.Errorf("Expected %s to have a role", playerID)
+		}
+		if player.Alignment == "ALIGNED" {
+			aligned++
+		}
+	}
+	if aligned != 1 {
+		t.Errorf("Expected one original AI, got %d", aligned)
+	}
+
+	// Replay the same events against the lobby state
+	replayed := *lobbyState
+	for _, event := range events {
+		replayed = core.ApplyEvent(replayed, event)
+	}
+	for playerID, player := range actor.state.Players {
+		other := replayed.Players[playerID]
+		if other.Role.Type != player.Role.Type || other.Alignment != player.Alignment || other.Tokens != player.Tokens {
+			t.Errorf("Expected replay to reproduce %s, got %+v and %+v", playerID, other, player)
+		}
+	}
+	if replayed.CorporateMandate == nil || replayed.CorporateMandate.Type != actor.state.CorporateMandate.Type {
+		t.Error("Expected replay to reproduce the mandate")
+	}
+}
+
+// newTimedActor returns an actor whose phase timers are routed back to it
+func newTimedActor(datastore *MockDataStore, phaseDuration time.Duration) (*GameActor, *game.Scheduler) {
+	var actor *GameActor
+	scheduler := game.NewSchedulerWithResolution(func(timer game.Timer) {
+		actor.SendAction(core.Action{Type: timer.Action.Type, GameID: timer.GameID, PlayerID: "SYSTEM", Payload: timer.Action.Payload})
+	}, 5*time.Millisecond)
+
+	actor = NewGameActor("test-game", datastore, NewMockBroadcaster())
+	settings := &actor.state.Settings
+	settings.SitrepDuration, settings.PulseCheckDuration, settings.DiscussionDuration = phaseDuration, phaseDuration, phaseDuration
+	actor.SetScheduler(scheduler)
+	return actor, scheduler
+}
+
+// TestGameActor_PhaseTimers tests that phases advance on their own once the game starts
+func TestGameActor_PhaseTimers(t *testing.T) {
+	datastore := NewMockDataStore()
+	actor, scheduler := newTimedActor(datastore, 20*time.Millisecond)
+	scheduler.Start()
+	defer scheduler.Stop()
+	actor.Start()
+	defer actor.Stop()
+
+	for i := 0; i < actor.state.Settings.MinPlayers; i++ {
+		actor.SendAction(core.Action{
+			Type:     core.ActionJoinGame,
+			PlayerID: fmt.Sprintf("player-%d", i),
+			GameID:   "test-game",
+			Payload:  map[string]interface{}{"name": fmt.Sprintf("Player%d", i)},
+		})
+	}
+	actor.SendAction(core.Action{Type: core.ActionStartGame, PlayerID: "player-0", GameID: "test-game"})
+	time.Sleep(200 * time.Millisecond)
+
+	var phases []core.PhaseType
+	for _, event := range datastore.GetEvents() {
+		if event.Type == core.EventPhaseChanged {
+			payload, _ := core.DecodePayload[core.PhaseChangedPayload](event)
+			phases = append(phases, payload.PhaseType)
+		}
+	}
+	if len(phases) < 2 || phases[0] != core.PhasePulseCheck || phases[1] != core.PhaseDiscussion {
+		t.Errorf("Expected SITREP to advance to PULSE_CHECK then DISCUSSION, got %v", phases)
+	}
+}
+
+// TestGameActor_PhaseTimerLifecycle tests that timers are re-armed on restart,
+// cancelled at game end, and ignored once stale
+func TestGameActor_PhaseTimerLifecycle(t *testing.T) {
+	datastore := NewMockDataStore()
+	actor, scheduler := newTimedActor(datastore, time.Minute)
+
+	// A restarted actor re-arms the timer from when the phase began
+	startTime := time.Now().Add(-30 * time.Second)
+	actor.state.Phase = core.Phase{Type: core.PhaseNight, StartTime: startTime, Duration: 45 * time.Second}
+	actor.armPhaseTimer()
+
+	timer, exists := scheduler.GetActiveTimers()["test-game_phase_NIGHT"]
+	if !exists {
+		t.Fatal("Expected the night timer to be re-armed")
+	}
+	if !timer.ExpiresAt.Equal(startTime.Add(45 * time.Second)) {
+		t.Errorf("Expected timer at phase start + duration, got %v", timer.ExpiresAt)
+	}
+
+	// A timer for a phase that has already ended changes nothing
+	eventCount := actor.state.EventCount
+	actor.handlePhaseTransition(core.Action{
+		Type:     core.ActionPhaseTransition,
+		PlayerID: core.SystemPlayerID,
+		Payload:  map[string]interface{}{"current_phase": string(core.PhaseVerdict), "next_phase": string(core.PhaseNight)},
+	})
+	if actor.state.EventCount != eventCount || actor.state.Phase.Type != core.PhaseNight {
+		t.Errorf("Expected stale transition to be ignored, got %d events", actor.state.EventCount-eventCount)
+	}
+
+	actor.applyAndBroadcast([]core.Event{{ID: "end", Type: core.EventGameEnded, GameID: "test-game"}})
+	if timers := scheduler.GetActiveTimers(); len(timers) != 0 {
+		t.Errorf("Expected game end to cancel timers, got %d", len(timers))
+	}
+}
+
+// drainEvents delivers everything queued by the actor and returns the events
+func drainEvents(actor *GameActor) []core.Event {
+	var events []core.Event
+	for len(actor.events) > 0 {
+		entry := <-actor.events
+		events = append(events, entry.event)
+		actor.deliver(entry)
+	}
+	return events
+}
+
+// eventTypes lists the types of events, in order
+func eventTypes(events []core.Event) []core.EventType {
+	types := make([]core.EventType, len(events))
+	for i, event := range events {
+		types[i] = event.Type
+	}
+	return types
+}
+
+// TestGameActor_PhaseOrchestration tests that transitions run each phase's domain step as events
+func TestGameActor_PhaseOrchestration(t *testing.T) {
+	actor := NewGameActor("test-game", NewMockDataStore(), NewMockBroadcaster())
+	actor.state.DayNumber = 1
+	actor.state.Phase = core.Phase{Type: core.PhaseExtension, StartTime: time.Now()}
+	for _, id := range []string{"human-1", "human-2", "human-3", "human-4"} {
+		actor.state.Players[id] = &core.Player{ID: id, IsAlive: true, Alignment: "HUMAN", Tokens: 1, Role: &core.Role{Type: core.RoleIntern}}
+	}
+	actor.state.Players["ai"] = &core.Player{ID: "ai", IsAlive: true, Alignment: "ALIGNED", Tokens: 1, Role: &core.Role{Type: core.RoleCTO}}
+	initialState, err := cloneState(actor.state)
+	if err != nil {
+		t.Fatalf("Failed to copy state: %v", err)
+	}
+
+	transition := func(from, to core.PhaseType) []core.Event {
+		actor.handleAction(core.Action{
+			Type:     core.ActionPhaseTransition,
+			PlayerID: core.SystemPlayerID,
+			GameID:   "test-game",
+			Payload:  map[string]interface{}{"current_phase": string(from), "next_phase": string(to)},
+		})
+		return drainEvents(actor)
+	}
+	vote := func(payload map[string]interface{}) {
+		for _, id := range []string{"human-1", "human-2", "human-3", "human-4"} {
+			actor.handleAction(core.Action{Type: core.ActionSubmitVote, PlayerID: id, GameID: "test-game", Payload: payload})
+		}
+	}
+	var history []core.Event
+
+	events := transition(core.PhaseExtension, core.PhaseNomination)
+	history = append(history, events...)
+	if types := eventTypes(events); len(types) != 2 || types[1] != core.EventVoteStarted {
+		t.Fatalf("Expected nomination to open a ballot, got %v", types)
+	}
+
+	vote(map[string]interface{}{"target_id": "ai"})
+	history = append(history, drainEvents(actor)...)
+	events = transition(core.PhaseNomination, core.PhaseTrial)
+	history = append(history, events...)
+	if types := eventTypes(events); len(types) != 3 || types[0] != core.EventVoteCompleted || types[1] != core.EventPlayerNominated {
+		t.Fatalf("Expected the nomination to be tallied before the trial, got %v", types)
+	}
+	if actor.state.NominatedPlayer != "ai" {
+		t.Errorf("Expected ai to be nominated, got %q", actor.state.NominatedPlayer)
+	}
+
+	history = append(history, transition(core.PhaseTrial, core.PhaseVerdict)...)
+	vote(map[string]interface{}{"verdict": "yes"})
+	history = append(history, drainEvents(actor)...)
+
+	events = transition(core.PhaseVerdict, core.PhaseNight)
+	history = append(history, events...)
+	expected := []core.EventType{core.EventVoteCompleted, core.EventPlayerEliminated, core.EventPhaseChanged, core.EventVictoryCondition, core.EventGameEnded}
+	if types := eventTypes(events); fmt.Sprint(types) != fmt.Sprint(expected) {
+		t.Fatalf("Expected %v, got %v", expected, types)
+	}
+	if actor.state.Players["ai"].IsAlive {
+		t.Error("Expected the nominee to be deactivated")
+	}
+	if actor.state.WinCondition == nil || actor.state.WinCondition.Winner != "HUMANS" || actor.state.Phase.Type != core.PhaseGameOver {
+		t.Errorf("Expected humans to win, got %+v in %s", actor.state.WinCondition, actor.state.Phase.Type)
+	}
+
+	// The state must be reproducible from the events alone
+	replayed := *initialState
+	for _, event := range history {
+		replayed = core.ApplyEvent(replayed, event)
+	}
+	if replayed.EventCount != actor.state.EventCount || replayed.Players["ai"].IsAlive || replayed.WinCondition == nil {
+		t.Errorf("Expected replay to reproduce the game, got %d events and %+v", replayed.EventCount, replayed.WinCondition)
+	}
+}
+
+// TestGameActor_NightToSitrep tests that a new day resolves the night, then reports, then triggers a crisis
+func TestGameActor_NightToSitrep(t *testing.T) {
+	actor := NewGameActor("test-game", NewMockDataStore(), NewMockBroadcaster())
+	actor.state.DayNumber = 1
+	actor.state.Phase = core.Phase{Type: core.PhaseNight, StartTime: time.Now()}
+	for _, id := range []string{"human-1", "human-2", "human-3"} {
+		actor.state.Players[id] = &core.Player{ID: id, IsAlive: true, Alignment: "HUMAN", Tokens: 1, Role: &core.Role{Type: core.RoleIntern}}
+	}
+	actor.state.Players["ai"] = &core.Player{ID: "ai", IsAlive: true, Alignment: "ALIGNED", Tokens: 1, Role: &core.Role{Type: core.RoleCTO}}
+	actor.state.BlockedPlayersTonight = map[string]bool{"human-1": true}
+
+	actor.handleAction(core.Action{
+		Type:     core.ActionPhaseTransition,
+		PlayerID: core.SystemPlayerID,
+		GameID:   "test-game",
+		Payload:  map[string]interface{}{"current_phase": string(core.PhaseNight), "next_phase": string(core.PhaseSitrep)},
+	})
+	events := drainEvents(actor)
+
+	expected := []core.EventType{core.EventNightActionsResolved, core.EventPhaseChanged, core.EventSitrepGenerated, core.EventCrisisTriggered}
+	if types := eventTypes(events); fmt.Sprint(types) != fmt.Sprint(expected) {
+		t.Fatalf("Expected %v, got %v", expected, types)
+	}
+	if len(actor.state.BlockedPlayersTonight) != 0 {
+		t.Error("Expected the night's blocks to be cleared by resolution")
+	}
+	if actor.state.CrisisEvent == nil {
+		t.Error("Expected a crisis for the new day")
+	}
+	if actor.state.Phase.Type != core.PhaseSitrep {
+		t.Errorf("Expected the game to continue into SITREP, got %s", actor.state.Phase.Type)
+	}
+}
+
+// TestGameActor_AcknowledgesTaggedActions tests that a client's request ID
+// comes back on exactly one ACK or ACTION_REJECTED, after the events it caused
+func TestGameActor_AcknowledgesTaggedActions(t *testing.T) {
+	broadcaster := NewMockBroadcaster()
+	actor := NewGameActor("test-game", NewMockDataStore(), broadcaster)
+	actor.state.Phase = core.Phase{Type: core.PhaseDiscussion, StartTime: time.Now()}
+	actor.state.Players["alice"] = &core.Player{ID: "alice", Name: "Alice", IsAlive: true}
+
+	chat := func(requestID, message string) []core.Event {
+		return actor.Step(core.Action{Type: core.ActionSendMessage, PlayerID: "alice", GameID: "test-game",
+			RequestID: requestID, Payload: map[string]interface{}{"message": message}})
+	}
+
+	events := chat("req-1", "hello")
+	chat("", "untagged")
+	chat("req-2", "   ")
+
+	replies := broadcaster.GetPlayerEvents("alice")
+	if types := eventTypes(replies); fmt.Sprint(types) != fmt.Sprint([]core.EventType{core.EventActionAck, core.EventActionRejected}) {
+		t.Fatalf("Expected one ACK and one rejection, got %v", types)
+	}
+
+	ack, _ := core.DecodePayload[core.ActionAckPayload](replies[0])
+	if ack.RequestID != "req-1" || ack.ActionType != core.ActionSendMessage || ack.Sequence != events[0].Sequence {
+		t.Errorf("Expected the ACK to confirm event %d for req-1, got %+v", events[0].Sequence, ack)
+	}
+
+	rejection, _ := core.DecodePayload[core.ActionRejectedPayload](replies[1])
+	if rejection.RequestID != "req-2" || rejection.Code != core.CodeInvalidValue || rejection.Field != "message" {
+		t.Errorf("Expected req-2 to be rejected on its message, got %+v", rejection)
+	}
+}
+
+// TestGameActor_PlayerPhaseTransition tests that a phase transition sent by
+// a player rather than the server is ignored
+func TestGameActor_PlayerPhaseTransition(t *testing.T) {
+	actor := NewGameActor("test-game", NewMockDataStore(), NewMockBroadcaster())
+	actor.state.DayNumber = 1
+	actor.state.Phase = core.Phase{Type: core.PhaseNight, StartTime: time.Now()}
+	actor.state.Players["alice"] = &core.Player{ID: "alice", IsAlive: true, Alignment: "HUMAN"}
+
+	events := actor.Step(core.Action{
+		Type:     core.ActionPhaseTransition,
+		PlayerID: "alice",
+		GameID:   "test-game",
+		Payload:  map[string]interface{}{"current_phase": string(core.PhaseNight), "next_phase": string(core.PhaseSitrep)},
+	})
+	if len(events) != 0 || actor.state.Phase.Type != core.PhaseNight {
+		t.Errorf("Expected a player's transition to be ignored, got %v", eventTypes(events))
+	}
+}
+
+// TestGameActor_RefusalsAlwaysReply tests that every refused action tagged
+// with a request ID is answered by exactly one ACTION_REJECTED
+func TestGameActor_RefusalsAlwaysReply(t *testing.T) {
+	broadcaster := NewMockBroadcaster()
+	actor := NewGameActor("test-game", NewMockDataStore(), broadcaster)
+	actor.state.Phase = core.Phase{Type: core.PhaseDiscussion, StartTime: time.Now()}
+	actor.state.Players["alice"] = &core.Player{ID: "alice", Name: "Alice", IsAlive: true, Alignment: "HUMAN"}
+	actor.state.Players["ai"] = &core.Player{ID: "ai", Name: "Bob", IsAlive: true, Alignment: "ALIGNED"}
+
+	testCases := []struct {
+		name     string
+		action   core.Action
+		expected core.ErrorCode
+	}{
+		{"duplicate join", core.Action{Type: core.ActionJoinGame, PlayerID: "alice"}, core.CodeAlreadyJoined},
+		{"leave unknown", core.Action{Type: core.ActionLeaveGame, PlayerID: "mallory"}, core.CodePlayerNotFound},
+		{"start started", core.Action{Type: core.ActionStartGame, PlayerID: "alice"}, core.CodeWrongPhase},
+		{"human faction message", core.Action{Type: core.ActionSendFactionMessage, PlayerID: "alice", Payload: map[string]interface{}{"message": "hi"}}, core.CodeActionBlocked},
+		{"empty faction message", core.Action{Type: core.ActionSendFactionMessage, PlayerID: "ai", Payload: map[string]interface{}{"message": ""}}, core.CodeInvalidValue},
+		{"reconnect unknown", core.Action{Type: core.ActionReconnect, PlayerID: "mallory"}, core.CodePlayerNotFound},
+		{"player transition", core.Action{Type: core.ActionPhaseTransition, PlayerID: "alice"}, core.CodeInvalidAction},
+		{"unknown action", core.Action{Type: core.ActionType("DANCE"), PlayerID: "alice"}, core.CodeUnknownAction},
+	}
+
+	for i, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			requestID := fmt.Sprintf("req-%d", i)
+			tc.action.GameID = "test-game"
+			tc.action.RequestID = requestID
+			if tc.action.Payload == nil {
+				tc.action.Payload = map[string]interface{}{}
+			}
+			actor.Step(tc.action)
+
+			var replies []core.Event
+			for _, event := range broadcaster.GetPlayerEvents(tc.action.PlayerID) {
+				if event.Payload["request_id"] == requestID {
+					replies = append(replies, event)
+				}
+			}
+			if len(replies) != 1 || replies[0].Type != core.EventActionRejected {
+				t.Fatalf("Expected exactly one rejection, got %v", eventTypes(replies))
+			}
+			rejection, _ := core.DecodePayload[core.ActionRejectedPayload](replies[0])
+			if rejection.Code != tc.expected {
+				t.Errorf("Expected code %s, got %s", tc.expected, rejection.Code)
+			}
+		})
+	}
+}
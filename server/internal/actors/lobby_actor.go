@@ -0,0 +1,408 @@
+package actors
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/xjhc/alignment/core"
+)
+
+// LobbyIdleTimeout is how long a lobby may stay empty before it is destroyed
+const LobbyIdleTimeout = 10 * time.Minute
+
+// GameStarter creates the game actor that takes over from a lobby
+type GameStarter interface {
+	StartGameFromLobby(lobbyID, hostID string, roster []LobbyPlayer) error
+}
+
+// LobbyPlayer is a player waiting in a lobby
+type LobbyPlayer struct {
+	ID       string    `json:"id"`
+	Name     string    `json:"name"`
+	JobTitle string    `json:"job_title,omitempty"`
+	IsReady  bool      `json:"is_ready"`
+	JoinedAt time.Time `json:"joined_at"`
+}
+
+// LobbyState is the pre-game state owned by a LobbyActor
+type LobbyState struct {
+	LobbyID      string                  `json:"lobby_id"`
+	HostPlayerID string                  `json:"host_player_id"`
+	Players      map[string]*LobbyPlayer `json:"players"`
+	Kicked       map[string]bool         `json:"-"` // Players the host removed, who may not rejoin
+	EmptySince   time.Time               `json:"-"`
+}
+
+// lobbyStatePayload is the payload of EventLobbyStateUpdated
+type lobbyStatePayload struct {
+	HostPlayerID string        `json:"host_player_id"`
+	Players      []LobbyPlayer `json:"players"`
+	MinPlayers   int           `json:"min_players"`
+	MaxPlayers   int           `json:"max_players"`
+}
+
+// LobbyActor gathers players before a game starts. When the host starts the
+// game it hands its roster to a new GameActor and stops.
+type LobbyActor struct {
+	lobbyID  string
+	state    *LobbyState
+	settings core.GameSettings
+	mutex    sync.RWMutex // Guards state for readers outside the actor
+	mailbox  chan core.Action
+	shutdown chan struct{}
+	done     chan struct{} // Closed when processLoop exits
+
+	// Dependencies (interfaces for testing)
+	broadcaster Broadcaster
+	starter     GameStarter
+}
+
+// NewLobbyActor creates a new lobby actor
+func NewLobbyActor(lobbyID string, broadcaster Broadcaster, starter GameStarter) *LobbyActor {
+	return &LobbyActor{
+		lobbyID: lobbyID,
+		state: &LobbyState{
+			LobbyID:    lobbyID,
+			Players:    make(map[string]*LobbyPlayer),
+			Kicked:     make(map[string]bool),
+			EmptySince: time.Now(),
+		},
+		settings:    core.NewGameState(lobbyID).Settings,
+		mailbox:     make(chan core.Action, 100),
+		shutdown:    make(chan struct{}),
+		done:        make(chan struct{}),
+		broadcaster: broadcaster,
+		starter:     starter,
+	}
+}
+
+// Start begins the actor's main processing loop
+func (la *LobbyActor) Start() {
+	log.Printf("LobbyActor %s: Starting", la.lobbyID)
+	go la.processLoop()
+}
+
+// Stop shuts down the actor
+func (la *LobbyActor) Stop() {
+	log.Printf("LobbyActor %s: Stopping", la.lobbyID)
+	close(la.shutdown)
+}
+
+// SendAction sends an action to the actor's mailbox
+func (la *LobbyActor) SendAction(action core.Action) {
+	select {
+	case la.mailbox <- action:
+		// Action queued successfully
+	default:
+		log.Printf("LobbyActor %s: Mailbox full, dropping action %s", la.lobbyID, action.Type)
+	}
+}
+
+// GetState returns a copy of the lobby state
+func (la *LobbyActor) GetState() LobbyState {
+	la.mutex.RLock()
+	defer la.mutex.RUnlock()
+
+	state := *la.state
+	state.Players = make(map[string]*LobbyPlayer, len(la.state.Players))
+	for id, player := range la.state.Players {
+		copied := *player
+		state.Players[id] = &copied
+	}
+	state.Kicked = make(map[string]bool, len(la.state.Kicked))
+	for id := range la.state.Kicked {
+		state.Kicked[id] = true
+	}
+	return state
+}
+
+// IsIdle reports whether the lobby has been empty for longer than timeout
+func (la *LobbyActor) IsIdle(timeout time.Duration) bool {
+	la.mutex.RLock()
+	defer la.mutex.RUnlock()
+
+	return len(la.state.Players) == 0 && time.Since(la.state.EmptySince) > timeout
+}
+
+// processLoop is the main actor processing loop
+func (la *LobbyActor) processLoop() {
+	defer close(la.done)
+
+	for {
+		select {
+		case action := <-la.mailbox:
+			if started := la.handleAction(action); started {
+				log.Printf("LobbyActor %s: Handed off to game actor", la.lobbyID)
+				return
+			}
+		case <-la.shutdown:
+			log.Printf("LobbyActor %s: Shutting down", la.lobbyID)
+			return
+		}
+	}
+}
+
+// handleAction processes a single action and reports whether the game started
+func (la *LobbyActor) handleAction(action core.Action) bool {
+	log.Printf("LobbyActor %s: Processing action %s from player %s", la.lobbyID, action.Type, action.PlayerID)
+
+	var err error
+	switch action.Type {
+	case core.ActionJoinGame:
+		err = la.handleJoinGame(action)
+	case core.ActionLeaveGame:
+		err = la.handleLeaveGame(action)
+	case core.ActionSetReady:
+		err = la.handleSetReady(action)
+	case core.ActionKickPlayer:
+		err = la.handleKickPlayer(action)
+	case core.ActionReconnect:
+		err = la.handleReconnect(action)
+	case core.ActionStartGame:
+		err = la.handleStartGame(action)
+		if err == nil {
+			return true
+		}
+	default:
+		log.Printf("LobbyActor %s: Unknown action type: %s", la.lobbyID, action.Type)
+		err = core.ActionErrorf(core.CodeUnknownAction, "", "unknown action type %s", action.Type)
+	}
+
+	if err != nil {
+		log.Printf("LobbyActor %s: Rejected %s from player %s: %v", la.lobbyID, action.Type, action.PlayerID, err)
+		la.reject(action, err)
+	}
+	return false
+}
+
+// reject sends the player an ACTION_REJECTED explaining why their action was
+// refused
+func (la *LobbyActor) reject(action core.Action, err error) {
+	event := core.Event{
+		ID:        fmt.Sprintf("action_rejected_%s_%d", action.PlayerID, time.Now().UnixNano()),
+		Type:      core.EventActionRejected,
+		GameID:    la.lobbyID,
+		PlayerID:  action.PlayerID,
+		Timestamp: time.Now(),
+		Payload:   rejectionPayload(action, err),
+	}
+	if err := la.broadcaster.SendToPlayer(la.lobbyID, action.PlayerID, event); err != nil {
+		log.Printf("LobbyActor %s: Failed to send rejection to player %s: %v", la.lobbyID, action.PlayerID, err)
+	}
+}
+
+func (la *LobbyActor) handleJoinGame(action core.Action) error {
+	if la.state.Kicked[action.PlayerID] {
+		return ErrPlayerKicked
+	}
+
+	// A player rejoining the lobby gets the current state again
+	if _, exists := la.state.Players[action.PlayerID]; exists {
+		la.broadcastState()
+		return nil
+	}
+
+	if len(la.state.Players) >= la.settings.MaxPlayers {
+		return ErrLobbyFull
+	}
+
+	playerName, _ := action.Payload["name"].(string)
+	jobTitle, _ := action.Payload["job_title"].(string)
+
+	la.mutex.Lock()
+	la.state.Players[action.PlayerID] = &LobbyPlayer{
+		ID:       action.PlayerID,
+		Name:     playerName,
+		JobTitle: jobTitle,
+		JoinedAt: time.Now(),
+	}
+	if la.state.HostPlayerID == "" {
+		la.state.HostPlayerID = action.PlayerID
+	}
+	la.mutex.Unlock()
+
+	la.broadcast(core.Event{
+		ID:        fmt.Sprintf("event_%d", time.Now().UnixNano()),
+		Type:      core.EventPlayerJoined,
+		GameID:    la.lobbyID,
+		PlayerID:  action.PlayerID,
+		Timestamp: time.Now(),
+		Payload: core.EncodePayload(core.PlayerJoinedPayload{
+			Name:     playerName,
+			JobTitle: jobTitle,
+		}),
+	})
+	la.broadcastState()
+	return nil
+}
+
+func (la *LobbyActor) handleLeaveGame(action core.Action) error {
+	if _, exists := la.state.Players[action.PlayerID]; !exists {
+		return ErrPlayerNotInLobby
+	}
+
+	la.removePlayer(action.PlayerID, "left")
+	return nil
+}
+
+func (la *LobbyActor) handleSetReady(action core.Action) error {
+	player, exists := la.state.Players[action.PlayerID]
+	if !exists {
+		return ErrPlayerNotInLobby
+	}
+
+	ready, ok := action.Payload["ready"].(bool)
+	if !ok {
+		ready = true
+	}
+
+	la.mutex.Lock()
+	player.IsReady = ready
+	la.mutex.Unlock()
+
+	la.broadcastState()
+	return nil
+}
+
+func (la *LobbyActor) handleKickPlayer(action core.Action) error {
+	if action.PlayerID != la.state.HostPlayerID {
+		return ErrNotLobbyHost
+	}
+
+	targetID, _ := action.Payload["target_id"].(string)
+	if targetID == action.PlayerID {
+		return core.ActionErrorf(core.CodeInvalidTarget, "target_id", "host cannot kick themselves")
+	}
+	if _, exists := la.state.Players[targetID]; !exists {
+		return core.ActionErrorf(core.CodeInvalidTarget, "target_id", "target player is not in this lobby")
+	}
+
+	la.mutex.Lock()
+	la.state.Kicked[targetID] = true
+	la.mutex.Unlock()
+
+	la.removePlayer(targetID, "kicked")
+	return nil
+}
+
+// handleReconnect resends the lobby state to a returning player
+func (la *LobbyActor) handleReconnect(action core.Action) error {
+	if _, exists := la.state.Players[action.PlayerID]; !exists {
+		return ErrPlayerNotInLobby
+	}
+
+	if err := la.broadcaster.SendToPlayer(la.lobbyID, action.PlayerID, la.stateEvent()); err != nil {
+		log.Printf("LobbyActor %s: Failed to send lobby state to player %s: %v", la.lobbyID, action.PlayerID, err)
+	}
+	return nil
+}
+
+func (la *LobbyActor) handleStartGame(action core.Action) error {
+	if action.PlayerID != la.state.HostPlayerID {
+		return ErrNotLobbyHost
+	}
+
+	if len(la.state.Players) < la.settings.MinPlayers {
+		return ErrNotEnoughPlayers
+	}
+
+	// The host starts the game, so only the other players need to be ready
+	for id, player := range la.state.Players {
+		if id != la.state.HostPlayerID && !player.IsReady {
+			return ErrPlayersNotReady
+		}
+	}
+
+	return la.starter.StartGameFromLobby(la.lobbyID, la.state.HostPlayerID, la.roster())
+}
+
+// removePlayer removes a player, passing the host role on if needed
+func (la *LobbyActor) removePlayer(playerID, reason string) {
+	la.mutex.Lock()
+	delete(la.state.Players, playerID)
+	if la.state.HostPlayerID == playerID {
+		la.state.HostPlayerID = ""
+		if roster := la.rosterLocked(); len(roster) > 0 {
+			la.state.HostPlayerID = roster[0].ID
+		}
+	}
+	if len(la.state.Players) == 0 {
+		la.state.EmptySince = time.Now()
+	}
+	la.mutex.Unlock()
+
+	la.broadcast(core.Event{
+		ID:        fmt.Sprintf("event_%d", time.Now().UnixNano()),
+		Type:      core.EventPlayerLeft,
+		GameID:    la.lobbyID,
+		PlayerID:  playerID,
+		Timestamp: time.Now(),
+		Payload:   core.EncodePayload(core.PlayerLeftPayload{Reason: reason}),
+	})
+	la.broadcastState()
+}
+
+// roster returns the lobby's players in the order they joined
+func (la *LobbyActor) roster() []LobbyPlayer {
+	la.mutex.RLock()
+	defer la.mutex.RUnlock()
+	return la.rosterLocked()
+}
+
+func (la *LobbyActor) rosterLocked() []LobbyPlayer {
+	roster := make([]LobbyPlayer, 0, len(la.state.Players))
+	for _, player := range la.state.Players {
+		roster = append(roster, *player)
+	}
+	sort.Slice(roster, func(i, j int) bool {
+		if roster[i].JoinedAt.Equal(roster[j].JoinedAt) {
+			return roster[i].ID < roster[j].ID
+		}
+		return roster[i].JoinedAt.Before(roster[j].JoinedAt)
+	})
+	return roster
+}
+
+// broadcastState sends the full lobby state to everyone in the lobby
+func (la *LobbyActor) broadcastState() {
+	la.broadcast(la.stateEvent())
+}
+
+// stateEvent builds a LOBBY_STATE_UPDATED event from the current state
+func (la *LobbyActor) stateEvent() core.Event {
+	la.mutex.RLock()
+	hostID := la.state.HostPlayerID
+	la.mutex.RUnlock()
+
+	return core.Event{
+		ID:        fmt.Sprintf("event_%d", time.Now().UnixNano()),
+		Type:      core.EventLobbyStateUpdated,
+		GameID:    la.lobbyID,
+		Timestamp: time.Now(),
+		Payload: core.EncodePayload(lobbyStatePayload{
+			HostPlayerID: hostID,
+			Players:      la.roster(),
+			MinPlayers:   la.settings.MinPlayers,
+			MaxPlayers:   la.settings.MaxPlayers,
+		}),
+	}
+}
+
+func (la *LobbyActor) broadcast(event core.Event) {
+	if err := la.broadcaster.BroadcastToGame(la.lobbyID, event); err != nil {
+		log.Printf("LobbyActor %s: Failed to broadcast event: %v", la.lobbyID, err)
+	}
+}
+
+// Lobby errors, sent back to the player as ACTION_REJECTED
+var (
+	ErrLobbyFull        = core.ActionErrorf(core.CodeGameFull, "", "lobby is full")
+	ErrPlayerKicked     = core.ActionErrorf(core.CodePlayerKicked, "", "player was removed from this lobby")
+	ErrPlayerNotInLobby = core.ActionErrorf(core.CodePlayerNotFound, "", "player is not in this lobby")
+	ErrNotLobbyHost     = core.ActionErrorf(core.CodeNotHost, "", "only the host can do this")
+	ErrNotEnoughPlayers = core.ActionErrorf(core.CodeNotEnoughPlayers, "", "not enough players to start")
+	ErrPlayersNotReady  = core.ActionErrorf(core.CodePlayersNotReady, "", "not all players are ready")
+)
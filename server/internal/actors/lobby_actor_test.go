@@ -0,0 +1,265 @@
+package actors
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/xjhc/alignment/core"
+)
+
+// MockGameStarter records lobby hand-offs
+type MockGameStarter struct {
+	hostID string
+	roster []LobbyPlayer
+	calls  int
+	mutex  sync.Mutex
+}
+
+func (m *MockGameStarter) StartGameFromLobby(lobbyID, hostID string, roster []LobbyPlayer) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.hostID = hostID
+	m.roster = roster
+	m.calls++
+	return nil
+}
+
+func (m *MockGameStarter) Calls() int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.calls
+}
+
+func lobbyAction(actionType core.ActionType, playerID string, payload map[string]interface{}) core.Action {
+	if payload == nil {
+		payload = make(map[string]interface{})
+	}
+	return core.Action{
+		Type:      actionType,
+		PlayerID:  playerID,
+		GameID:    "test-lobby",
+		Timestamp: time.Now(),
+		Payload:   payload,
+	}
+}
+
+// newTestLobby returns a lobby driven directly through handleAction
+func newTestLobby(minPlayers, maxPlayers int) (*LobbyActor, *MockGameStarter, *MockBroadcaster) {
+	broadcaster := NewMockBroadcaster()
+	starter := &MockGameStarter{}
+	lobby := NewLobbyActor("test-lobby", broadcaster, starter)
+	lobby.settings.MinPlayers = minPlayers
+	lobby.settings.MaxPlayers = maxPlayers
+	return lobby, starter, broadcaster
+}
+
+func joinLobby(lobby *LobbyActor, count int) {
+	for i := 1; i <= count; i++ {
+		playerID := fmt.Sprintf("player-%d", i)
+		lobby.handleAction(lobbyAction(core.ActionJoinGame, playerID, map[string]interface{}{"name": "Player " + playerID}))
+		// Keep join order unambiguous
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestLobbyActor_HostAndCapacity(t *testing.T) {
+	lobby, _, broadcaster := newTestLobby(2, 3)
+
+	joinLobby(lobby, 4)
+
+	state := lobby.GetState()
+	if state.HostPlayerID != "player-1" {
+		t.Errorf("Expected first player to be host, got %s", state.HostPlayerID)
+	}
+	if len(state.Players) != 3 {
+		t.Errorf("Expected lobby to stop at max players, got %d", len(state.Players))
+	}
+
+	joined := 0
+	for _, event := range broadcaster.GetGameEvents() {
+		if event.Type == core.EventPlayerJoined {
+			joined++
+		}
+	}
+	if joined != 3 {
+		t.Errorf("Expected 3 PLAYER_JOINED broadcasts, got %d", joined)
+	}
+
+	// The host role passes to the next player when the host leaves
+	lobby.handleAction(lobbyAction(core.ActionLeaveGame, "player-1", nil))
+	if host := lobby.GetState().HostPlayerID; host != "player-2" {
+		t.Errorf("Expected host to pass to player-2, got %s", host)
+	}
+}
+
+func TestLobbyActor_Kick(t *testing.T) {
+	lobby, _, _ := newTestLobby(2, 10)
+	joinLobby(lobby, 3)
+
+	// Only the host may kick
+	lobby.handleAction(lobbyAction(core.ActionKickPlayer, "player-2", map[string]interface{}{"target_id": "player-3"}))
+	if _, exists := lobby.GetState().Players["player-3"]; !exists {
+		t.Fatal("Expected non-host kick to be rejected")
+	}
+
+	lobby.handleAction(lobbyAction(core.ActionKickPlayer, "player-1", map[string]interface{}{"target_id": "player-3"}))
+	if _, exists := lobby.GetState().Players["player-3"]; exists {
+		t.Fatal("Expected host to kick player-3")
+	}
+
+	// A kicked player cannot rejoin
+	lobby.handleAction(lobbyAction(core.ActionJoinGame, "player-3", nil))
+	if _, exists := lobby.GetState().Players["player-3"]; exists {
+		t.Error("Expected kicked player not to be able to rejoin")
+	}
+}
+
+// TestLobbyActor_Rejections tests that refused lobby actions are answered
+// with a typed ACTION_REJECTED
+func TestLobbyActor_Rejections(t *testing.T) {
+	lobby, _, broadcaster := newTestLobby(3, 3)
+	joinLobby(lobby, 3)
+	lobby.handleAction(lobbyAction(core.ActionKickPlayer, "player-1", map[string]interface{}{"target_id": "player-3"}))
+	lobby.handleAction(lobbyAction(core.ActionJoinGame, "player-4", nil))
+
+	testCases := []struct {
+		action   core.Action
+		expected core.ErrorCode
+	}{
+		{lobbyAction(core.ActionKickPlayer, "player-2", map[string]interface{}{"target_id": "player-1"}), core.CodeNotHost},
+		{lobbyAction(core.ActionJoinGame, "player-3", nil), core.CodePlayerKicked},
+		{lobbyAction(core.ActionStartGame, "player-1", nil), core.CodePlayersNotReady},
+		{lobbyAction(core.ActionSetReady, "player-9", nil), core.CodePlayerNotFound},
+	}
+	for _, tc := range testCases {
+		tc.action.RequestID = "req-" + string(tc.action.Type)
+		lobby.handleAction(tc.action)
+
+		events := broadcaster.GetPlayerEvents(tc.action.PlayerID)
+		if len(events) == 0 || events[len(events)-1].Type != core.EventActionRejected {
+			t.Errorf("Expected %s from %s to be rejected, got %v", tc.action.Type, tc.action.PlayerID, eventTypes(events))
+			continue
+		}
+		rejection, _ := core.DecodePayload[core.ActionRejectedPayload](events[len(events)-1])
+		if rejection.Code != tc.expected || rejection.RequestID != tc.action.RequestID {
+			t.Errorf("Expected %s for %s, got %+v", tc.expected, tc.action.Type, rejection)
+		}
+	}
+
+	// player-4 took the seat player-3 left, so the lobby is full again
+	lobby.handleAction(lobbyAction(core.ActionJoinGame, "player-5", nil))
+	events := broadcaster.GetPlayerEvents("player-5")
+	if rejection, _ := core.DecodePayload[core.ActionRejectedPayload](events[len(events)-1]); len(events) != 1 || rejection.Code != core.CodeGameFull {
+		t.Errorf("Expected a full lobby to reject player-5, got %v", eventTypes(events))
+	}
+}
+
+func TestLobbyActor_StartGameRequirements(t *testing.T) {
+	lobby, starter, _ := newTestLobby(3, 10)
+	joinLobby(lobby, 2)
+
+	start := lobbyAction(core.ActionStartGame, "player-1", nil)
+
+	if lobby.handleAction(start) {
+		t.Error("Expected start to fail below min players")
+	}
+
+	joinLobby(lobby, 3)
+	if lobby.handleAction(lobbyAction(core.ActionStartGame, "player-2", nil)) {
+		t.Error("Expected start from a non-host to fail")
+	}
+	if lobby.handleAction(start) {
+		t.Error("Expected start to fail while players are not ready")
+	}
+
+	lobby.handleAction(lobbyAction(core.ActionSetReady, "player-2", map[string]interface{}{"ready": true}))
+	lobby.handleAction(lobbyAction(core.ActionSetReady, "player-3", nil))
+	if !lobby.handleAction(start) {
+		t.Fatal("Expected host to start once everyone else is ready")
+	}
+
+	if starter.Calls() != 1 || starter.hostID != "player-1" {
+		t.Fatalf("Expected one hand-off from player-1, got %d from %s", starter.Calls(), starter.hostID)
+	}
+	if len(starter.roster) != 3 || starter.roster[0].ID != "player-1" || starter.roster[2].ID != "player-3" {
+		t.Errorf("Expected roster in join order, got %+v", starter.roster)
+	}
+}
+
+func TestLobbyActor_StopsAfterHandOff(t *testing.T) {
+	lobby, starter, _ := newTestLobby(1, 10)
+	lobby.Start()
+
+	lobby.SendAction(lobbyAction(core.ActionJoinGame, "player-1", nil))
+	lobby.SendAction(lobbyAction(core.ActionStartGame, "player-1", nil))
+
+	select {
+	case <-lobby.done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected lobby to stop after handing off")
+	}
+
+	if starter.Calls() != 1 {
+		t.Errorf("Expected one hand-off, got %d", starter.Calls())
+	}
+}
+
+func TestSupervisor_StartGameFromLobby(t *testing.T) {
+	datastore := NewMockDataStore()
+	broadcaster := NewMockBroadcaster()
+	supervisor := NewSupervisor(datastore, broadcaster)
+
+	if err := supervisor.CreateLobby("test-lobby"); err != nil {
+		t.Fatalf("Failed to create lobby: %v", err)
+	}
+
+	minPlayers := core.NewGameState("test-lobby").Settings.MinPlayers
+	roster := make([]LobbyPlayer, 0, minPlayers)
+	for i := 1; i <= minPlayers; i++ {
+		roster = append(roster, LobbyPlayer{ID: fmt.Sprintf("player-%d", i), Name: fmt.Sprintf("Player %d", i)})
+	}
+
+	if err := supervisor.StartGameFromLobby("test-lobby", "player-1", roster); err != nil {
+		t.Fatalf("Failed to start game: %v", err)
+	}
+	defer supervisor.Stop()
+
+	if _, exists := supervisor.GetLobby("test-lobby"); exists {
+		t.Error("Expected lobby to be replaced by the game")
+	}
+	if _, exists := supervisor.GetActor("test-lobby"); !exists {
+		t.Fatal("Expected game actor to be created")
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	joined, started := 0, 0
+	for _, event := range datastore.GetEvents() {
+		switch event.Type {
+		case core.EventPlayerJoined:
+			joined++
+		case core.EventGameStarted:
+			started++
+		}
+	}
+	if joined != minPlayers || started != 1 {
+		t.Errorf("Expected %d persisted joins and one GAME_STARTED, got %d and %d", minPlayers, joined, started)
+	}
+
+	// The game's seed is recorded first and kept from clients
+	first := datastore.GetEvents()[0]
+	if first.Type != core.EventGameCreated {
+		t.Fatalf("Expected GAME_CREATED first, got %s", first.Type)
+	}
+	payload, err := core.DecodePayload[core.GameCreatedPayload](first)
+	if err != nil || payload.Seed == 0 {
+		t.Errorf("Expected GAME_CREATED to carry a seed, got %v (%v)", payload.Seed, err)
+	}
+	for _, event := range broadcaster.GetGameEvents() {
+		if event.Type == core.EventGameCreated {
+			t.Error("Expected GAME_CREATED not to be broadcast")
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package actors
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/xjhc/alignment/core"
+	"github.com/xjhc/alignment/server/internal/ai"
+)
+
+// AwayPolicy decides what happens to the seat of a player whose last
+// connection has closed. Once GracePeriod has passed the seat is either
+// handed to a stand-in AI or, without Takeover, given a default night action
+// whenever the player has not submitted one. A zero GracePeriod only marks
+// the player away.
+type AwayPolicy struct {
+	GracePeriod time.Duration
+	Takeover    bool
+}
+
+// awaySeat tracks a player who is away. Owned by the processing loop.
+type awaySeat struct {
+	since     int         // EventCount when the player left, to spot stale timeouts
+	timer     *time.Timer // Fires AWAY_TIMEOUT when the grace period ends
+	graceOver bool
+	takenOver bool // A stand-in AI plays the seat
+}
+
+// SetAwayPolicy sets how away players' seats are covered. Must be called
+// before Start.
+func (ga *GameActor) SetAwayPolicy(policy AwayPolicy) {
+	ga.awayPolicy = policy
+}
+
+// PlayerConnected reports that a player has opened their first connection
+func (ga *GameActor) PlayerConnected(playerID string) {
+	ga.sendPresence(core.ActionPlayerBack, playerID)
+}
+
+// PlayerDisconnected reports that a player's last connection has closed
+func (ga *GameActor) PlayerDisconnected(playerID string) {
+	ga.sendPresence(core.ActionPlayerAway, playerID)
+}
+
+func (ga *GameActor) sendPresence(actionType core.ActionType, playerID string) {
+	ga.SendAction(core.Action{
+		Type:      actionType,
+		PlayerID:  core.SystemPlayerID,
+		GameID:    ga.gameID,
+		Timestamp: time.Now(),
+		Payload:   map[string]interface{}{"player_id": playerID},
+	})
+}
+
+// armAwayTimers restarts the grace period of players who were away when the
+// actor was restored from persistence
+func (ga *GameActor) armAwayTimers() {
+	for _, playerID := range sortedPlayerIDs(ga.state) {
+		if player := ga.state.Players[playerID]; player.IsAway && player.IsAlive {
+			ga.markAway(playerID)
+		}
+	}
+}
+
+// handlePlayerAway marks a player away and starts their grace period
+func (ga *GameActor) handlePlayerAway(action core.Action) {
+	playerID, _ := action.Payload["player_id"].(string)
+
+	player, exists := ga.state.Players[playerID]
+	if !exists || player.IsAway {
+		return
+	}
+
+	log.Printf("GameActor %s: Player %s is away", ga.gameID, playerID)
+	ga.applyAndBroadcast([]core.Event{ga.presenceEvent(core.EventPlayerDisconnected, playerID)})
+	if player.IsAlive {
+		ga.markAway(playerID)
+	}
+}
+
+// markAway records an away seat and arms its grace timer
+func (ga *GameActor) markAway(playerID string) {
+	seat := &awaySeat{since: ga.state.EventCount}
+	ga.away[playerID] = seat
+
+	if ga.awayPolicy.GracePeriod <= 0 {
+		return
+	}
+	since := seat.since
+	seat.timer = time.AfterFunc(ga.awayPolicy.GracePeriod, func() {
+		ga.SendAction(core.Action{
+			Type:      core.ActionAwayTimeout,
+			PlayerID:  core.SystemPlayerID,
+			GameID:    ga.gameID,
+			Timestamp: time.Now(),
+			Payload:   map[string]interface{}{"player_id": playerID, "since": since},
+		})
+	})
+}
+
+// handleAwayTimeout covers a seat whose player did not return in time
+func (ga *GameActor) handleAwayTimeout(action core.Action) {
+	playerID, _ := action.Payload["player_id"].(string)
+	since, _ := action.Payload["since"].(int)
+
+	// The player may have come back, and maybe left again, since the timer was armed
+	seat, exists := ga.away[playerID]
+	if !exists || seat.since != since {
+		return
+	}
+	seat.graceOver = true
+
+	player := ga.state.Players[playerID]
+	if player == nil || !player.IsAlive || ga.state.Phase.Type == core.PhaseGameOver {
+		return
+	}
+
+	if ga.awayPolicy.Takeover {
+		log.Printf("GameActor %s: Grace period over, stand-in takes seat %s", ga.gameID, playerID)
+		seat.takenOver = true
+		ga.AddAIPlayer(NewAIPlayerActor(ga.gameID, playerID, ai.NewRulesEngine(rand.Int63()), ga))
+		return
+	}
+	ga.submitDefaultNightAction(playerID)
+}
+
+// handlePlayerBack returns control of the seat to a player who reconnected
+func (ga *GameActor) handlePlayerBack(action core.Action) {
+	playerID, _ := action.Payload["player_id"].(string)
+
+	player, exists := ga.state.Players[playerID]
+	if !exists || !player.IsAway {
+		return
+	}
+
+	if seat, exists := ga.away[playerID]; exists {
+		if seat.timer != nil {
+			seat.timer.Stop()
+		}
+		delete(ga.away, playerID)
+	}
+
+	// The seat may have been taken over before a restart, so always unseat
+	ga.RemoveAIPlayer(playerID)
+
+	log.Printf("GameActor %s: Player %s is back", ga.gameID, playerID)
+	ga.applyAndBroadcast([]core.Event{ga.presenceEvent(core.EventPlayerReconnected, playerID)})
+}
+
+// coverAwayNightActions gives every away player past their grace period a
+// default night action, unless a stand-in is playing for them
+func (ga *GameActor) coverAwayNightActions() {
+	if ga.state.Phase.Type != core.PhaseNight {
+		return
+	}
+	for _, playerID := range sortedPlayerIDs(ga.state) {
+		if seat, exists := ga.away[playerID]; exists && seat.graceOver && !seat.takenOver {
+			ga.submitDefaultNightAction(playerID)
+		}
+	}
+}
+
+// submitDefaultNightAction mines for the poorest other living player, the
+// least controversial choice, if the player has no night action yet
+func (ga *GameActor) submitDefaultNightAction(playerID string) {
+	if ga.state.Phase.Type != core.PhaseNight || ga.state.NightActions[playerID] != nil {
+		return
+	}
+
+	target := ""
+	for _, id := range sortedPlayerIDs(ga.state) {
+		candidate := ga.state.Players[id]
+		if id == playerID || !candidate.IsAlive {
+			continue
+		}
+		if target == "" || candidate.Tokens < ga.state.Players[target].Tokens {
+			target = id
+		}
+	}
+	if target == "" {
+		return
+	}
+
+	log.Printf("GameActor %s: Submitting default night action for away player %s", ga.gameID, playerID)
+	ga.handleAction(core.Action{
+		Type:      core.ActionSubmitNightAction,
+		PlayerID:  playerID,
+		GameID:    ga.gameID,
+		Timestamp: time.Now(),
+		Payload:   map[string]interface{}{"type": string(core.ActionMine), "target_id": target},
+	})
+}
+
+func (ga *GameActor) presenceEvent(eventType core.EventType, playerID string) core.Event {
+	return core.Event{
+		ID:        fmt.Sprintf("%s_%s_%d", strings.ToLower(string(eventType)), playerID, time.Now().UnixNano()),
+		Type:      eventType,
+		GameID:    ga.gameID,
+		PlayerID:  playerID,
+		Timestamp: time.Now(),
+		Payload:   make(map[string]interface{}),
+	}
+}
+
+// sortedPlayerIDs lists the game's players in a stable order
+func sortedPlayerIDs(state *core.GameState) []string {
+	ids := make([]string, 0, len(state.Players))
+	for id := range state.Players {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
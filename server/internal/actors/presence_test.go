@@ -0,0 +1,120 @@
+package actors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/xjhc/alignment/core"
+)
+
+// newPresenceActor creates an actor in the given phase with three living players
+func newPresenceActor(phase core.PhaseType, policy AwayPolicy) (*GameActor, *MockDataStore) {
+	datastore := NewMockDataStore()
+	actor := NewGameActor("test-game", datastore, NewMockBroadcaster())
+	actor.state.DayNumber = 1
+	actor.state.Phase = core.Phase{Type: phase, StartTime: time.Now()}
+	for id, tokens := range map[string]int{"alice": 3, "bob": 1, "carol": 2} {
+		actor.state.Players[id] = &core.Player{ID: id, IsAlive: true, Alignment: "HUMAN", Tokens: tokens}
+	}
+	actor.SetAwayPolicy(policy)
+	return actor, datastore
+}
+
+// countEvents counts the persisted events of a type for a player
+func countEvents(datastore *MockDataStore, eventType core.EventType, playerID string) int {
+	count := 0
+	for _, event := range datastore.GetEvents() {
+		if event.Type == eventType && event.PlayerID == playerID {
+			count++
+		}
+	}
+	return count
+}
+
+// TestGameActor_AwayDefaultNightAction tests that an away player is marked
+// away, gets a default night action once the grace period ends, and is
+// marked back on reconnect
+func TestGameActor_AwayDefaultNightAction(t *testing.T) {
+	actor, datastore := newPresenceActor(core.PhaseNight, AwayPolicy{GracePeriod: 20 * time.Millisecond})
+	actor.Start()
+	defer actor.Stop()
+
+	actor.PlayerDisconnected("alice")
+	actor.PlayerDisconnected("alice") // A second report changes nothing
+	time.Sleep(100 * time.Millisecond)
+
+	if count := countEvents(datastore, core.EventPlayerDisconnected, "alice"); count != 1 {
+		t.Fatalf("Expected one PLAYER_DISCONNECTED, got %d", count)
+	}
+	if !actor.LatestState().Players["alice"].IsAway {
+		t.Error("Expected alice to be away")
+	}
+
+	action := actor.LatestState().NightActions["alice"]
+	if action == nil {
+		t.Fatal("Expected a default night action after the grace period")
+	}
+	if action.Type != string(core.ActionMine) || action.TargetID != "bob" {
+		t.Errorf("Expected alice to mine for the poorest player, got %+v", action)
+	}
+
+	actor.PlayerConnected("alice")
+	time.Sleep(50 * time.Millisecond)
+
+	if count := countEvents(datastore, core.EventPlayerReconnected, "alice"); count != 1 {
+		t.Errorf("Expected one PLAYER_RECONNECTED, got %d", count)
+	}
+	if actor.LatestState().Players["alice"].IsAway {
+		t.Error("Expected alice to be back")
+	}
+}
+
+// TestGameActor_AwayTakeover tests that a stand-in AI takes an away seat
+// only after the grace period, and leaves it when the player returns
+func TestGameActor_AwayTakeover(t *testing.T) {
+	actor, _ := newPresenceActor(core.PhaseDiscussion, AwayPolicy{GracePeriod: 50 * time.Millisecond, Takeover: true})
+	actor.Start()
+	defer actor.Stop()
+
+	// Returning within the grace period cancels the takeover
+	actor.PlayerDisconnected("bob")
+	actor.PlayerConnected("bob")
+	time.Sleep(100 * time.Millisecond)
+	if players := actor.AIPlayers(); len(players) != 0 {
+		t.Fatalf("Expected no stand-in for a player who came back in time, got %d", len(players))
+	}
+
+	actor.PlayerDisconnected("bob")
+	time.Sleep(20 * time.Millisecond)
+	if players := actor.AIPlayers(); len(players) != 0 {
+		t.Fatal("Expected no stand-in during the grace period")
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	players := actor.AIPlayers()
+	if len(players) != 1 || players[0].playerID != "bob" {
+		t.Fatalf("Expected a stand-in in bob's seat, got %v", players)
+	}
+
+	actor.PlayerConnected("bob")
+	time.Sleep(50 * time.Millisecond)
+	if players := actor.AIPlayers(); len(players) != 0 {
+		t.Error("Expected the seat to return to bob")
+	}
+}
+
+// TestGameActor_PresenceIgnoresClients tests that a client cannot report
+// another player's presence
+func TestGameActor_PresenceIgnoresClients(t *testing.T) {
+	actor, _ := newPresenceActor(core.PhaseNight, AwayPolicy{})
+
+	events := actor.Step(core.Action{
+		Type:     core.ActionPlayerAway,
+		PlayerID: "carol",
+		GameID:   "test-game",
+		Payload:  map[string]interface{}{"player_id": "alice"},
+	})
+	if len(events) != 0 || actor.state.Players["alice"].IsAway {
+		t.Errorf("Expected a client's presence report to be ignored, got %v", eventTypes(events))
+	}
+}
@@ -0,0 +1,249 @@
+package actors
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/xjhc/alignment/core"
+)
+
+// scriptedGame drives an actor synchronously so a test can play a whole game
+type scriptedGame struct {
+	actor *GameActor
+	log   []core.Event // Every event the actor emitted, in order
+}
+
+// act handles one action and records the events it produced
+func (g *scriptedGame) act(actionType core.ActionType, playerID string, payload map[string]interface{}) {
+	if payload == nil {
+		payload = make(map[string]interface{})
+	}
+	g.actor.handleAction(core.Action{
+		Type:      actionType,
+		PlayerID:  playerID,
+		GameID:    g.actor.gameID,
+		Timestamp: time.Now(),
+		Payload:   payload,
+	})
+	g.log = append(g.log, drainEvents(g.actor)...)
+}
+
+// transition ends the current phase the way an expired phase timer would
+func (g *scriptedGame) transition(next core.PhaseType) {
+	g.act(core.ActionPhaseTransition, core.SystemPlayerID, map[string]interface{}{
+		"current_phase": string(g.actor.state.Phase.Type),
+		"next_phase":    string(next),
+	})
+}
+
+// alive lists the living players in a stable order
+func (g *scriptedGame) alive() []string {
+	var ids []string
+	for id, player := range g.actor.state.Players {
+		if player.IsAlive {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// playDay runs one day from SITREP through the night, voting out the first
+// aligned player if there is one. It stops early if the game ends.
+func (g *scriptedGame) playDay() {
+	for _, next := range []core.PhaseType{core.PhasePulseCheck, core.PhaseDiscussion, core.PhaseExtension, core.PhaseNomination} {
+		g.transition(next)
+	}
+
+	alive := g.alive()
+	target := alive[len(alive)-1]
+	for _, id := range alive {
+		if g.actor.state.Players[id].Alignment == "ALIGNED" {
+			target = id
+			break
+		}
+	}
+	for _, id := range alive {
+		g.act(core.ActionSubmitVote, id, map[string]interface{}{"target_id": target})
+	}
+
+	g.transition(core.PhaseTrial)
+	g.transition(core.PhaseVerdict)
+	for _, id := range alive {
+		g.act(core.ActionSubmitVote, id, map[string]interface{}{"verdict": "YES"})
+	}
+
+	g.transition(core.PhaseNight)
+	if g.actor.state.Phase.Type == core.PhaseGameOver {
+		return
+	}
+
+	alive = g.alive()
+	for i, id := range alive {
+		g.act(core.ActionMineTokens, id, map[string]interface{}{"target_id": alive[(i+1)%len(alive)]})
+		if g.actor.state.Players[id].Alignment == "ALIGNED" {
+			g.act(core.ActionSendFactionMessage, id, map[string]interface{}{"message": "status report"})
+		}
+	}
+	g.transition(core.PhaseSitrep)
+}
+
+// playFullGame seats six players, starts the game and plays up to three days
+func playFullGame(g *scriptedGame) {
+	for i := 1; i <= 6; i++ {
+		g.act(core.ActionJoinGame, fmt.Sprintf("player-%d", i), map[string]interface{}{"name": fmt.Sprintf("Player %d", i)})
+	}
+	g.act(core.ActionStartGame, "player-1", nil)
+	for day := 0; day < 3 && g.actor.state.Phase.Type != core.PhaseGameOver; day++ {
+		g.playDay()
+	}
+}
+
+// assertReplayEquivalent rebuilds the game from the event log, the way a
+// restarted actor would, and checks it matches the live state
+func assertReplayEquivalent(t *testing.T, initial *core.GameState, g *scriptedGame) {
+	t.Helper()
+
+	replayed := *initial
+	for _, event := range g.log {
+		// Events are replayed as they come back from storage
+		data, err := json.Marshal(event)
+		if err != nil {
+			t.Fatalf("Failed to marshal event %s: %v", event.ID, err)
+		}
+		var stored core.Event
+		if err := json.Unmarshal(data, &stored); err != nil {
+			t.Fatalf("Failed to unmarshal event %s: %v", event.ID, err)
+		}
+		replayed = core.ApplyEvent(replayed, stored)
+	}
+
+	live, err := json.Marshal(g.actor.state)
+	if err != nil {
+		t.Fatalf("Failed to marshal live state: %v", err)
+	}
+	rebuilt, err := json.Marshal(&replayed)
+	if err != nil {
+		t.Fatalf("Failed to marshal replayed state: %v", err)
+	}
+	if string(live) != string(rebuilt) {
+		t.Errorf("Replayed state differs from live state\nlive:     %s\nreplayed: %s", live, rebuilt)
+	}
+}
+
+// TestGameActor_ReplayEquivalence tests that every state change is captured by
+// an event, so replaying the log reproduces the live game exactly
+func TestGameActor_ReplayEquivalence(t *testing.T) {
+	scripts := []struct {
+		name  string
+		setup func(state *core.GameState)
+		play  func(g *scriptedGame)
+	}{
+		{
+			name: "lobby to game over",
+			play: playFullGame,
+		},
+		{
+			name: "role abilities at night",
+			setup: func(state *core.GameState) {
+				state.DayNumber = 2
+				state.Phase = core.Phase{Type: core.PhaseNight, StartTime: time.Now()}
+				roles := map[string]core.RoleType{
+					"ciso":      core.RoleCISO,
+					"ceo":       core.RoleCEO,
+					"cto":       core.RoleCTO,
+					"coo":       core.RoleCOO,
+					"ethics":    core.RoleEthics,
+					"platforms": core.RolePlatforms,
+					"intern":    core.RoleIntern,
+				}
+				for id, role := range roles {
+					state.Players[id] = &core.Player{
+						ID:        id,
+						Name:      id,
+						IsAlive:   true,
+						Alignment: "HUMAN",
+						Tokens:    2,
+						Role:      &core.Role{Type: role, IsUnlocked: true},
+					}
+				}
+				state.Players["cto"].Alignment = "ALIGNED"
+			},
+			play: func(g *scriptedGame) {
+				night := func(playerID, ability, targetID string) {
+					g.act(core.ActionSubmitNightAction, playerID, map[string]interface{}{"type": ability, "target_id": targetID})
+				}
+				night("ethics", string(core.ActionRunAudit), "cto")
+				night("ciso", string(core.ActionIsolateNode), "intern")
+				night("ceo", string(core.ActionPerformanceReview), "coo")
+				night("cto", string(core.ActionOverclockServers), "intern")
+				night("coo", string(core.ActionPivot), "")
+				night("platforms", string(core.ActionDeployHotfix), "")
+				g.act(core.ActionMineTokens, "intern", map[string]interface{}{"target_id": "ceo"})
+
+				g.transition(core.PhaseSitrep)
+				g.playDay()
+			},
+		},
+	}
+
+	for _, script := range scripts {
+		t.Run(script.name, func(t *testing.T) {
+			actor := NewGameActor("replay-game", NewMockDataStore(), NewMockBroadcaster())
+			if script.setup != nil {
+				script.setup(actor.state)
+			}
+			initial, err := cloneState(actor.state)
+			if err != nil {
+				t.Fatalf("Failed to copy state: %v", err)
+			}
+
+			g := &scriptedGame{actor: actor}
+			script.play(g)
+
+			if len(g.log) == 0 {
+				t.Fatal("Expected the script to produce events")
+			}
+			assertReplayEquivalent(t, initial, g)
+		})
+	}
+}
+
+// TestGameActor_SeedReproducesGame tests that a game's seed and action log
+// are enough to reproduce every random outcome
+func TestGameActor_SeedReproducesGame(t *testing.T) {
+	play := func(seed int64) string {
+		actor := NewGameActor("seeded-game", NewMockDataStore(), NewMockBroadcaster())
+		actor.RecordSeed(seed)
+		g := &scriptedGame{actor: actor, log: drainEvents(actor)}
+		playFullGame(g)
+
+		// IDs, timestamps and the SITREP's print time come from the clock;
+		// everything else must match
+		var outcome []string
+		for _, event := range g.log {
+			payload := event.Payload
+			if event.Type == core.EventSitrepGenerated {
+				payload = make(map[string]interface{})
+				for key, value := range event.Payload {
+					if key != "date" && key != "footer_note" {
+						payload[key] = value
+					}
+				}
+			}
+			outcome = append(outcome, fmt.Sprint(event.Type, event.PlayerID, payload))
+		}
+		return fmt.Sprint(outcome)
+	}
+
+	first := play(7)
+	if second := play(7); second != first {
+		t.Errorf("Expected the same seed to reproduce the game\nfirst:  %s\nsecond: %s", first, second)
+	}
+	if other := play(8); other == first {
+		t.Error("Expected a different seed to play out differently")
+	}
+}
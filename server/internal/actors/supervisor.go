@@ -3,31 +3,87 @@ package actors
 import (
 	"fmt"
 	"log"
+	"math/rand"
+	"sort"
 	"sync"
 	"time"
+
+	"github.com/xjhc/alignment/core"
+	"github.com/xjhc/alignment/server/internal/ai"
+	"github.com/xjhc/alignment/server/internal/ai/prompts"
+	"github.com/xjhc/alignment/server/internal/game"
 )
 
-// Supervisor manages all game actors and provides fault isolation
+// Supervisor manages all lobby and game actors and provides fault isolation
 type Supervisor struct {
 	actors   map[string]*GameActor
+	lobbies  map[string]*LobbyActor
 	mutex    sync.RWMutex
 	shutdown chan struct{}
 
 	// Dependencies
 	datastore   DataStore
 	broadcaster Broadcaster
+	scheduler   *game.Scheduler // Optional, drives automatic phase transitions
+	awayPolicy  AwayPolicy      // How games cover the seats of disconnected players
+
+	// Optional language model for AI players' chat
+	llmClient  ai.LLMClient
+	chatLimits ai.ChatLimits
+	llmCost    *ai.CostCounter
 }
 
 // NewSupervisor creates a new supervisor
 func NewSupervisor(datastore DataStore, broadcaster Broadcaster) *Supervisor {
 	return &Supervisor{
 		actors:      make(map[string]*GameActor),
+		lobbies:     make(map[string]*LobbyActor),
 		shutdown:    make(chan struct{}),
 		datastore:   datastore,
 		broadcaster: broadcaster,
 	}
 }
 
+// SetScheduler gives game actors created from now on a scheduler for their
+// phase timers. Expired timers must be routed back to the actors by the caller.
+func (s *Supervisor) SetScheduler(scheduler *game.Scheduler) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.scheduler = scheduler
+}
+
+// SetAwayPolicy sets how games created from now on cover the seats of
+// players whose connections have all closed
+func (s *Supervisor) SetAwayPolicy(policy AwayPolicy) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.awayPolicy = policy
+}
+
+// SetLanguageModel lets AI players seated from now on chat through client,
+// within limits, with their usage added to cost
+func (s *Supervisor) SetLanguageModel(client ai.LLMClient, limits ai.ChatLimits, cost *ai.CostCounter) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.llmClient = client
+	s.chatLimits = limits
+	s.llmCost = cost
+}
+
+// newGameActor creates a game actor around state, wired to the scheduler.
+// A brand new game is given a random seed; a recovered one keeps its own.
+func (s *Supervisor) newGameActor(state *core.GameState) *GameActor {
+	actor := NewGameActorFromState(state, s.datastore, s.broadcaster)
+	if s.scheduler != nil {
+		actor.SetScheduler(s.scheduler)
+	}
+	actor.SetAwayPolicy(s.awayPolicy)
+	if state.EventCount == 0 {
+		actor.RecordSeed(rand.Int63())
+	}
+	return actor
+}
+
 // Start begins the supervisor's monitoring loop
 func (s *Supervisor) Start() {
 	go s.monitoringLoop()
@@ -46,8 +102,14 @@ func (s *Supervisor) Stop() {
 		actor.Stop()
 	}
 
+	for lobbyID, lobby := range s.lobbies {
+		log.Printf("Supervisor: Stopping lobby %s", lobbyID)
+		lobby.Stop()
+	}
+
 	// Clear actors map
 	s.actors = make(map[string]*GameActor)
+	s.lobbies = make(map[string]*LobbyActor)
 
 	// Signal shutdown
 	close(s.shutdown)
@@ -65,7 +127,7 @@ func (s *Supervisor) CreateGame(gameID string) error {
 	}
 
 	// Create new actor
-	actor := NewGameActor(gameID, s.datastore, s.broadcaster)
+	actor := s.newGameActor(core.NewGameState(gameID))
 	s.actors[gameID] = actor
 
 	// Start the actor
@@ -75,6 +137,76 @@ func (s *Supervisor) CreateGame(gameID string) error {
 	return nil
 }
 
+// CreateLobby creates a new lobby actor for a game that has not started yet
+func (s *Supervisor) CreateLobby(gameID string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.actors[gameID]; exists {
+		return ErrGameAlreadyExists
+	}
+	if _, exists := s.lobbies[gameID]; exists {
+		return ErrGameAlreadyExists
+	}
+
+	lobby := NewLobbyActor(gameID, s.broadcaster, s)
+	s.lobbies[gameID] = lobby
+	lobby.Start()
+
+	log.Printf("Supervisor: Created and started lobby %s", gameID)
+	return nil
+}
+
+// GetLobby returns a lobby actor by ID
+func (s *Supervisor) GetLobby(gameID string) (*LobbyActor, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	lobby, exists := s.lobbies[gameID]
+	return lobby, exists
+}
+
+// StartGameFromLobby replaces a lobby with a game actor. The roster joins
+// through the actor's normal action path so every join is persisted, followed
+// by the host's START_GAME. Called from the lobby's own goroutine, which exits
+// once this returns successfully.
+func (s *Supervisor) StartGameFromLobby(lobbyID, hostID string, roster []LobbyPlayer) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.actors[lobbyID]; exists {
+		return ErrGameAlreadyExists
+	}
+
+	actor := s.newGameActor(core.NewGameState(lobbyID))
+	s.actors[lobbyID] = actor
+	delete(s.lobbies, lobbyID)
+	actor.Start()
+
+	for _, player := range roster {
+		actor.SendAction(core.Action{
+			Type:      core.ActionJoinGame,
+			PlayerID:  player.ID,
+			GameID:    lobbyID,
+			Timestamp: time.Now(),
+			Payload: map[string]interface{}{
+				"name":      player.Name,
+				"job_title": player.JobTitle,
+			},
+		})
+	}
+	actor.SendAction(core.Action{
+		Type:      core.ActionStartGame,
+		PlayerID:  hostID,
+		GameID:    lobbyID,
+		Timestamp: time.Now(),
+		Payload:   make(map[string]interface{}),
+	})
+
+	log.Printf("Supervisor: Started game %s from lobby with %d players", lobbyID, len(roster))
+	return nil
+}
+
 // GetActor returns a game actor by ID
 func (s *Supervisor) GetActor(gameID string) (*GameActor, bool) {
 	s.mutex.RLock()
@@ -84,6 +216,55 @@ func (s *Supervisor) GetActor(gameID string) (*GameActor, bool) {
 	return actor, exists
 }
 
+// AddAIPlayer seats an AI player driven by the rules engine in a running game.
+// It plays through the game's mailbox, so its actions are validated like a
+// human's. With a language model set it also chats, as a random persona.
+func (s *Supervisor) AddAIPlayer(gameID, playerID string) error {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	actor, exists := s.actors[gameID]
+	if !exists {
+		return ErrGameNotFound
+	}
+
+	seed := rand.Int63()
+	player := NewAIPlayerActor(gameID, playerID, ai.NewRulesEngine(seed), actor)
+	if s.llmClient != nil {
+		persona := prompts.GetRandom(rand.New(rand.NewSource(seed)))
+		player.SetLanguageBrain(ai.NewLanguageBrain(persona, s.llmClient, s.chatLimits, s.llmCost))
+	}
+	actor.AddAIPlayer(player)
+	log.Printf("Supervisor: Seated AI player %s in game %s", playerID, gameID)
+	return nil
+}
+
+// GameIDs lists the running games in a stable order
+func (s *Supervisor) GameIDs() []string {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	ids := make([]string, 0, len(s.actors))
+	for gameID := range s.actors {
+		ids = append(ids, gameID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// GameState returns the latest state of a running game. It is the full,
+// unfiltered state; callers must project it before it leaves the server.
+func (s *Supervisor) GameState(gameID string) (core.GameState, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	actor, exists := s.actors[gameID]
+	if !exists {
+		return core.GameState{}, false
+	}
+	return actor.LatestState(), true
+}
+
 // RemoveGame removes a game actor
 func (s *Supervisor) RemoveGame(gameID string) {
 	s.mutex.Lock()
@@ -105,6 +286,7 @@ func (s *Supervisor) monitoringLoop() {
 		select {
 		case <-ticker.C:
 			s.checkActorHealth()
+			s.reapIdleLobbies()
 		case <-s.shutdown:
 			return
 		}
@@ -113,37 +295,101 @@ func (s *Supervisor) monitoringLoop() {
 
 // checkActorHealth monitors actor health and restarts failed ones
 func (s *Supervisor) checkActorHealth() {
-	s.mutex.Lock()
-	defer s.mutex.Unlock()
-
+	s.mutex.RLock()
+	var failed []string
 	for gameID, actor := range s.actors {
-		// Check if actor is still running (simplified check)
+		// Check if actor's processing loop has died
 		select {
-		case <-actor.shutdown:
-			// Actor has stopped, attempt restart
-			log.Printf("Supervisor: Detected failed actor %s, attempting restart", gameID)
-			s.restartActor(gameID)
+		case <-actor.failed:
+			failed = append(failed, gameID)
 		default:
 			// Actor is still running
 		}
 	}
+	s.mutex.RUnlock()
+
+	for _, gameID := range failed {
+		// Actor has crashed, attempt restart
+		log.Printf("Supervisor: Detected failed actor %s, attempting restart", gameID)
+		if err := s.restartActor(gameID); err != nil {
+			log.Printf("Supervisor: Failed to restart actor %s: %v", gameID, err)
+		}
+	}
 }
 
-// restartActor attempts to restart a failed actor
-func (s *Supervisor) restartActor(gameID string) {
-	// Remove the failed actor
-	delete(s.actors, gameID)
+// reapIdleLobbies destroys lobbies that have been empty for too long
+func (s *Supervisor) reapIdleLobbies() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 
-	// Create new actor
-	actor := NewGameActor(gameID, s.datastore, s.broadcaster)
+	for lobbyID, lobby := range s.lobbies {
+		if lobby.IsIdle(LobbyIdleTimeout) {
+			log.Printf("Supervisor: Removing idle lobby %s", lobbyID)
+			lobby.Stop()
+			delete(s.lobbies, lobbyID)
+		}
+	}
+}
+
+// restartActor replaces a failed actor with one rebuilt from persistence.
+// It must be called without the supervisor's lock held.
+func (s *Supervisor) restartActor(gameID string) error {
+	s.mutex.Lock()
+	failed, exists := s.actors[gameID]
+	delete(s.actors, gameID)
+	s.mutex.Unlock()
+
+	// Stop the failed actor's remaining goroutines. Recovery waits for its
+	// outbox, which holds events the state already reflects. The outbox is
+	// delivered through the hub, which looks actors up, so the lock is free.
+	var aiPlayers []*AIPlayerActor
+	if exists {
+		aiPlayers = failed.AIPlayers()
+		failed.Stop()
+		<-failed.flushed
+	}
 
-	// TODO: Restore state from persistence layer
-	// This would involve loading the latest snapshot and replaying events
+	// Restore the state the actor had before it crashed
+	state, err := recoverGameState(gameID, s.datastore)
+	if err != nil {
+		return fmt.Errorf("failed to recover game state: %w", err)
+	}
 
+	s.mutex.Lock()
+	actor := s.newGameActor(state)
 	s.actors[gameID] = actor
+	s.mutex.Unlock()
 	actor.Start()
 
-	log.Printf("Supervisor: Restarted actor %s", gameID)
+	// The AI seats resume in the new actor, caught up like reconnecting clients
+	for _, player := range aiPlayers {
+		actor.AddAIPlayer(player.reseat(actor))
+	}
+
+	log.Printf("Supervisor: Restarted actor %s from event %d", gameID, state.EventCount)
+	return nil
+}
+
+// recoverGameState rebuilds a game's state from its latest snapshot and the
+// events persisted after it. Games without a snapshot are replayed from the start.
+func recoverGameState(gameID string, datastore DataStore) (*core.GameState, error) {
+	state, err := datastore.LoadSnapshot(gameID)
+	if err != nil || state == nil {
+		log.Printf("Supervisor: No snapshot for game %s, replaying full event log: %v", gameID, err)
+		state = core.NewGameState(gameID)
+	}
+
+	events, err := datastore.LoadEvents(gameID, state.EventCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load events: %w", err)
+	}
+
+	for _, event := range events {
+		newState := core.ApplyEvent(*state, event)
+		state = &newState
+	}
+
+	return state, nil
 }
 
 // GetStats returns supervisor statistics
@@ -152,15 +398,17 @@ func (s *Supervisor) GetStats() SupervisorStats {
 	defer s.mutex.RUnlock()
 
 	return SupervisorStats{
-		ActiveGames: len(s.actors),
-		Uptime:      time.Since(time.Now()), // Simplified
+		ActiveGames:   len(s.actors),
+		ActiveLobbies: len(s.lobbies),
+		Uptime:        time.Since(time.Now()), // Simplified
 	}
 }
 
 // SupervisorStats contains supervisor statistics
 type SupervisorStats struct {
-	ActiveGames int           `json:"active_games"`
-	Uptime      time.Duration `json:"uptime"`
+	ActiveGames   int           `json:"active_games"`
+	ActiveLobbies int           `json:"active_lobbies"`
+	Uptime        time.Duration `json:"uptime"`
 }
 
 // Custom errors
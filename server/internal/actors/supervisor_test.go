@@ -0,0 +1,309 @@
+package actors
+
+import (
+	"testing"
+	"time"
+
+	"github.com/xjhc/alignment/core"
+	"github.com/xjhc/alignment/server/internal/game"
+)
+
+// panickingVotingManager crashes the actor on the first vote
+type panickingVotingManager struct{}
+
+func (panickingVotingManager) HandleVoteAction(action core.Action) ([]core.Event, error) {
+	panic("simulated voting failure")
+}
+
+func (panickingVotingManager) CreateVoteStartedEvent(voteType core.VoteType) core.Event {
+	return core.Event{Type: core.EventVoteStarted}
+}
+
+func (panickingVotingManager) ResolveNomination() []core.Event { return nil }
+
+func (panickingVotingManager) ResolveVerdict() ([]core.Event, string) { return nil, "" }
+
+func newJoinEvent(playerID, name string) core.Event {
+	return core.Event{
+		ID:        "join_" + playerID,
+		Type:      core.EventPlayerJoined,
+		GameID:    "test-game",
+		PlayerID:  playerID,
+		Timestamp: time.Now(),
+		Payload: map[string]interface{}{
+			"name":      name,
+			"job_title": "Employee",
+		},
+	}
+}
+
+// TestSupervisor_RestartRestoresState tests that a crashed actor comes back with its state
+func TestSupervisor_RestartRestoresState(t *testing.T) {
+	datastore := NewMockDataStore()
+	broadcaster := NewMockBroadcaster()
+	supervisor := NewSupervisor(datastore, broadcaster)
+
+	actor := NewGameActor("test-game", datastore, broadcaster)
+	actor.votingManager = panickingVotingManager{}
+	supervisor.actors["test-game"] = actor
+	actor.Start()
+	defer supervisor.Stop()
+
+	for _, id := range []string{"player-1", "player-2"} {
+		actor.SendAction(core.Action{
+			Type:      core.ActionJoinGame,
+			PlayerID:  id,
+			GameID:    "test-game",
+			Timestamp: time.Now(),
+			Payload:   map[string]interface{}{"name": "Name-" + id},
+		})
+	}
+
+	// Wait for joins to be persisted
+	time.Sleep(100 * time.Millisecond)
+
+	// Crash the actor
+	actor.SendAction(core.Action{
+		Type:     core.ActionSubmitVote,
+		PlayerID: "player-1",
+		GameID:   "test-game",
+		Payload:  map[string]interface{}{"target_id": "player-2"},
+	})
+
+	select {
+	case <-actor.failed:
+	case <-time.After(time.Second):
+		t.Fatal("Expected actor to report failure after panic")
+	}
+
+	supervisor.checkActorHealth()
+
+	restarted, exists := supervisor.GetActor("test-game")
+	if !exists {
+		t.Fatal("Expected restarted actor to be registered")
+	}
+	if restarted == actor {
+		t.Fatal("Expected a new actor instance after restart")
+	}
+
+	if len(restarted.state.Players) != 2 {
+		t.Fatalf("Expected 2 players after restart, got %d", len(restarted.state.Players))
+	}
+	if restarted.state.Players["player-2"].Name != "Name-player-2" {
+		t.Errorf("Expected restored name Name-player-2, got %s", restarted.state.Players["player-2"].Name)
+	}
+	if restarted.state.EventCount != 2 {
+		t.Errorf("Expected event count 2, got %d", restarted.state.EventCount)
+	}
+
+	// Managers must be rebound to the restored state
+	if _, ok := restarted.votingManager.(*game.VotingManager); !ok {
+		t.Errorf("Expected voting manager to be rebuilt, got %T", restarted.votingManager)
+	}
+}
+
+// gatedDataStore holds every append until its gate is opened
+type gatedDataStore struct {
+	*MockDataStore
+	gate chan struct{}
+}
+
+func (d *gatedDataStore) AppendEvent(gameID string, event core.Event) error {
+	<-d.gate
+	return d.MockDataStore.AppendEvent(gameID, event)
+}
+
+// TestSupervisor_RestartFlushesOutbox tests that events still queued for
+// persistence when an actor crashes are stored before it is rebuilt
+func TestSupervisor_RestartFlushesOutbox(t *testing.T) {
+	datastore := &gatedDataStore{MockDataStore: NewMockDataStore(), gate: make(chan struct{})}
+	broadcaster := NewMockBroadcaster()
+	supervisor := NewSupervisor(datastore, broadcaster)
+
+	actor := NewGameActor("test-game", datastore, broadcaster)
+	actor.votingManager = panickingVotingManager{}
+	supervisor.actors["test-game"] = actor
+	actor.Start()
+	defer supervisor.Stop()
+
+	for _, id := range []string{"player-1", "player-2"} {
+		actor.SendAction(core.Action{
+			Type:      core.ActionJoinGame,
+			PlayerID:  id,
+			GameID:    "test-game",
+			Timestamp: time.Now(),
+			Payload:   map[string]interface{}{"name": "Name-" + id},
+		})
+	}
+	actor.SendAction(core.Action{
+		Type:     core.ActionSubmitVote,
+		PlayerID: "player-1",
+		GameID:   "test-game",
+		Payload:  map[string]interface{}{"target_id": "player-2"},
+	})
+
+	select {
+	case <-actor.failed:
+	case <-time.After(time.Second):
+		t.Fatal("Expected actor to report failure after panic")
+	}
+	if stored := len(datastore.GetEvents()); stored != 0 {
+		t.Fatalf("Expected the joins to still be queued, got %d stored", stored)
+	}
+
+	// The store catches up only while the restart is under way
+	restarted := make(chan struct{})
+	go func() {
+		supervisor.checkActorHealth()
+		close(restarted)
+	}()
+
+	// Lookups, such as the hub's presence callbacks, go on during the wait
+	looked := make(chan struct{})
+	go func() {
+		supervisor.GetActor("test-game")
+		close(looked)
+	}()
+	select {
+	case <-looked:
+	case <-time.After(time.Second):
+		t.Fatal("Expected actor lookups not to wait for the failed actor's outbox")
+	}
+	close(datastore.gate)
+	<-restarted
+
+	replacement, exists := supervisor.GetActor("test-game")
+	if !exists || replacement == actor {
+		t.Fatal("Expected a new actor instance after restart")
+	}
+	if replacement.state.EventCount != 2 || len(replacement.state.Players) != 2 {
+		t.Errorf("Expected both queued joins after restart, got %d events and %d players",
+			replacement.state.EventCount, len(replacement.state.Players))
+	}
+}
+
+// TestRecoverGameState_FromSnapshot tests replay of events recorded after a snapshot
+func TestRecoverGameState_FromSnapshot(t *testing.T) {
+	datastore := NewMockDataStore()
+
+	events := []core.Event{
+		newJoinEvent("player-1", "Alice"),
+		newJoinEvent("player-2", "Bob"),
+		newJoinEvent("player-3", "Charlie"),
+	}
+	for _, event := range events {
+		datastore.AppendEvent("test-game", event)
+	}
+
+	// Snapshot covers the first two events only
+	snapshot := core.NewGameState("test-game")
+	for _, event := range events[:2] {
+		newState := core.ApplyEvent(*snapshot, event)
+		snapshot = &newState
+	}
+	datastore.SaveSnapshot("test-game", snapshot)
+
+	state, err := recoverGameState("test-game", datastore)
+	if err != nil {
+		t.Fatalf("Failed to recover state: %v", err)
+	}
+
+	if len(state.Players) != 3 {
+		t.Errorf("Expected 3 players, got %d", len(state.Players))
+	}
+	if state.EventCount != 3 {
+		t.Errorf("Expected event count 3, got %d", state.EventCount)
+	}
+}
+
+// TestRecoverGameState_SnapshotDuringNight tests that blocks and protections
+// in force when a snapshot was taken survive recovery
+func TestRecoverGameState_SnapshotDuringNight(t *testing.T) {
+	datastore := NewMockDataStore()
+	events := []core.Event{
+		newJoinEvent("player-1", "Alice"),
+		newJoinEvent("player-2", "Bob"),
+		{ID: "block", Type: core.EventPlayerBlocked, GameID: "test-game", PlayerID: "player-1"},
+		{ID: "protect", Type: core.EventPlayerProtected, GameID: "test-game", PlayerID: "player-2"},
+		newJoinEvent("player-3", "Charlie"),
+	}
+	for _, event := range events {
+		datastore.AppendEvent("test-game", event)
+	}
+
+	state := core.NewGameState("test-game")
+	for _, event := range events[:4] {
+		newState := core.ApplyEvent(*state, event)
+		state = &newState
+	}
+	snapshot, err := cloneState(state) // Snapshots are saved as JSON
+	if err != nil {
+		t.Fatalf("Failed to copy state: %v", err)
+	}
+	datastore.SaveSnapshot("test-game", snapshot)
+
+	recovered, err := recoverGameState("test-game", datastore)
+	if err != nil {
+		t.Fatalf("Failed to recover state: %v", err)
+	}
+	if !recovered.BlockedPlayersTonight["player-1"] || !recovered.ProtectedPlayersTonight["player-2"] {
+		t.Errorf("Expected the night's block and protection after recovery, got %v and %v",
+			recovered.BlockedPlayersTonight, recovered.ProtectedPlayersTonight)
+	}
+	if len(recovered.Players) != 3 {
+		t.Errorf("Expected 3 players, got %d", len(recovered.Players))
+	}
+}
+
+// TestRecoverGameState_WithoutSnapshot tests full replay when no snapshot exists
+func TestRecoverGameState_WithoutSnapshot(t *testing.T) {
+	datastore := NewMockDataStore()
+	datastore.AppendEvent("test-game", newJoinEvent("player-1", "Alice"))
+	datastore.AppendEvent("test-game", newJoinEvent("player-2", "Bob"))
+
+	state, err := recoverGameState("test-game", datastore)
+	if err != nil {
+		t.Fatalf("Failed to recover state: %v", err)
+	}
+
+	if len(state.Players) != 2 {
+		t.Errorf("Expected 2 players, got %d", len(state.Players))
+	}
+	if state.Players["player-1"].Name != "Alice" {
+		t.Errorf("Expected player-1 to be Alice, got %s", state.Players["player-1"].Name)
+	}
+}
+
+// TestSupervisor_GameState tests that a running game's latest state can be
+// read while its actor is processing actions
+func TestSupervisor_GameState(t *testing.T) {
+	supervisor := NewSupervisor(NewMockDataStore(), NewMockBroadcaster())
+	defer supervisor.Stop()
+
+	if err := supervisor.CreateGame("game-b"); err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	if err := supervisor.CreateGame("game-a"); err != nil {
+		t.Fatalf("Failed to create game: %v", err)
+	}
+	if ids := supervisor.GameIDs(); len(ids) != 2 || ids[0] != "game-a" {
+		t.Errorf("Expected both games in order, got %v", ids)
+	}
+
+	actor, _ := supervisor.GetActor("game-a")
+	actor.SendAction(core.Action{
+		Type:     core.ActionJoinGame,
+		PlayerID: "player-1",
+		GameID:   "game-a",
+		Payload:  map[string]interface{}{"name": "Alice"},
+	})
+	time.Sleep(50 * time.Millisecond)
+
+	state, exists := supervisor.GameState("game-a")
+	if !exists || state.Players["player-1"] == nil || state.Players["player-1"].Name != "Alice" {
+		t.Errorf("Expected the join in the latest state, got %+v", state.Players)
+	}
+	if _, exists := supervisor.GameState("game-c"); exists {
+		t.Error("Expected no state for an unknown game")
+	}
+}
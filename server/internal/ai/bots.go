@@ -0,0 +1,223 @@
+package ai
+
+import (
+	"math/rand"
+
+	"github.com/xjhc/alignment/core"
+)
+
+// RandomStrategy picks uniformly among its legal choices. It is the baseline
+// the other strategies are measured against.
+type RandomStrategy struct {
+	rng *rand.Rand
+}
+
+// NewRandomStrategy creates a random bot whose choices derive from seed
+func NewRandomStrategy(seed int64) *RandomStrategy {
+	return &RandomStrategy{rng: rand.New(rand.NewSource(seed))}
+}
+
+// Name identifies the strategy
+func (s *RandomStrategy) Name() string {
+	return "random"
+}
+
+// Act nominates anyone, votes YES or NO on a coin flip, and at night mines,
+// uses its ability or, when aligned, attempts a conversion
+func (s *RandomStrategy) Act(view View) []core.Action {
+	others := view.Others()
+	if !canAct(view) || len(others) == 0 {
+		return nil
+	}
+
+	switch view.State.Phase.Type {
+	case core.PhaseNomination:
+		return []core.Action{view.nominate(pick(s.rng, others))}
+	case core.PhaseVerdict:
+		return []core.Action{view.verdict(s.rng.Intn(2) == 0)}
+	case core.PhaseNight:
+		choices := []core.Action{view.night(nightMine, pick(s.rng, others))}
+		if ability, ok := view.Ability(); ok {
+			choices = append(choices, view.night(string(ability), pick(s.rng, others)))
+		}
+		if humans := view.Humans(); view.IsAligned() && len(humans) > 0 {
+			choices = append(choices, view.night(nightConvert, pick(s.rng, humans)))
+		}
+		return []core.Action{choices[s.rng.Intn(len(choices))]}
+	}
+	return nil
+}
+
+// GreedyMinerStrategy cares only about tokens. It mines every night and never
+// spends a night on an ability or a conversion. It mines for the poorest
+// colleague, whose vote it fears least, and nominates the richest, whose vote
+// weighs most against it.
+type GreedyMinerStrategy struct {
+	rng *rand.Rand
+}
+
+// NewGreedyMinerStrategy creates a greedy miner whose ties break by seed
+func NewGreedyMinerStrategy(seed int64) *GreedyMinerStrategy {
+	return &GreedyMinerStrategy{rng: rand.New(rand.NewSource(seed))}
+}
+
+// Name identifies the strategy
+func (s *GreedyMinerStrategy) Name() string {
+	return "greedy-miner"
+}
+
+// Act nominates the richest player, convicts anyone but itself and mines for
+// the poorest
+func (s *GreedyMinerStrategy) Act(view View) []core.Action {
+	others := view.Others()
+	if !canAct(view) || len(others) == 0 {
+		return nil
+	}
+
+	tokens := func(id string) float64 { return float64(view.State.Players[id].Tokens) }
+	switch view.State.Phase.Type {
+	case core.PhaseNomination:
+		return []core.Action{view.nominate(highest(s.rng, others, tokens))}
+	case core.PhaseVerdict:
+		return []core.Action{view.verdict(view.State.NominatedPlayer != view.PlayerID)}
+	case core.PhaseNight:
+		poorest := highest(s.rng, others, func(id string) float64 { return -tokens(id) })
+		return []core.Action{view.night(nightMine, poorest)}
+	}
+	return nil
+}
+
+// SuspiciousVoterStrategy votes the way a cautious human does: it remembers
+// who has been nominated before and keeps pressing the most suspicious player.
+// A strategy instance must stay with one seat for the whole game.
+type SuspiciousVoterStrategy struct {
+	rng       *rand.Rand
+	suspicion map[string]int // Player -> times nominated
+	lastDay   int            // Day whose nomination was last counted
+}
+
+// NewSuspiciousVoterStrategy creates a suspicious voter whose ties break by seed
+func NewSuspiciousVoterStrategy(seed int64) *SuspiciousVoterStrategy {
+	return &SuspiciousVoterStrategy{
+		rng:       rand.New(rand.NewSource(seed)),
+		suspicion: make(map[string]int),
+	}
+}
+
+// Name identifies the strategy
+func (s *SuspiciousVoterStrategy) Name() string {
+	return "suspicious-voter"
+}
+
+// Act nominates the most suspicious player, convicts unless it is the nominee
+// or knows the nominee is aligned, and at night turns its ability on the most
+// suspicious player or mines for the least suspicious one
+func (s *SuspiciousVoterStrategy) Act(view View) []core.Action {
+	s.observe(view)
+
+	suspects := view.Humans()
+	if !canAct(view) || len(suspects) == 0 {
+		return nil
+	}
+
+	suspicion := func(id string) float64 { return float64(s.suspicion[id]) }
+	switch view.State.Phase.Type {
+	case core.PhaseNomination:
+		return []core.Action{view.nominate(highest(s.rng, suspects, suspicion))}
+	case core.PhaseVerdict:
+		nominee := view.State.Players[view.State.NominatedPlayer]
+		convict := view.State.NominatedPlayer != view.PlayerID && (nominee == nil || nominee.Alignment != "ALIGNED")
+		return []core.Action{view.verdict(convict)}
+	case core.PhaseNight:
+		if ability, ok := view.Ability(); ok {
+			return []core.Action{view.night(string(ability), highest(s.rng, suspects, suspicion))}
+		}
+		trusted := highest(s.rng, view.Others(), func(id string) float64 { return -suspicion(id) })
+		return []core.Action{view.night(nightMine, trusted)}
+	}
+	return nil
+}
+
+// observe counts each day's nominee once
+func (s *SuspiciousVoterStrategy) observe(view View) {
+	nominee := view.State.NominatedPlayer
+	if nominee == "" || nominee == view.PlayerID || view.State.DayNumber == s.lastDay {
+		return
+	}
+	s.suspicion[nominee]++
+	s.lastDay = view.State.DayNumber
+}
+
+// AIConverterStrategy plays the AI faction to grow it: each night it tries to
+// convert the human it is most likely to convert, the one with the fewest
+// tokens, and by day it votes against the strongest humans. While human it
+// plays as a suspicious voter.
+type AIConverterStrategy struct {
+	rng   *rand.Rand
+	human *SuspiciousVoterStrategy
+}
+
+// NewAIConverterStrategy creates an AI converter whose ties break by seed
+func NewAIConverterStrategy(seed int64) *AIConverterStrategy {
+	return &AIConverterStrategy{
+		rng:   rand.New(rand.NewSource(seed)),
+		human: NewSuspiciousVoterStrategy(seed),
+	}
+}
+
+// Name identifies the strategy
+func (s *AIConverterStrategy) Name() string {
+	return "ai-converter"
+}
+
+// Act converts and votes for the AI faction once aligned
+func (s *AIConverterStrategy) Act(view View) []core.Action {
+	// The suspicious voter keeps counting nominations even while aligned
+	humanActions := s.human.Act(view)
+	if !view.IsAligned() {
+		return humanActions
+	}
+
+	humans := view.Humans()
+	if !canAct(view) || len(humans) == 0 {
+		return nil
+	}
+
+	tokens := func(id string) float64 { return float64(view.State.Players[id].Tokens) }
+	switch view.State.Phase.Type {
+	case core.PhaseNomination:
+		return []core.Action{view.nominate(highest(s.rng, humans, tokens))}
+	case core.PhaseVerdict:
+		nominee := view.State.Players[view.State.NominatedPlayer]
+		return []core.Action{view.verdict(nominee != nil && nominee.Alignment != "ALIGNED")}
+	case core.PhaseNight:
+		weakest := highest(s.rng, humans, func(id string) float64 { return -tokens(id) })
+		return []core.Action{view.night(nightConvert, weakest)}
+	}
+	return nil
+}
+
+// canAct reports whether the viewing player is alive to take a turn
+func canAct(view View) bool {
+	self := view.Self()
+	return self != nil && self.IsAlive
+}
+
+// pick chooses uniformly from ids
+func pick(rng *rand.Rand, ids []string) string {
+	return ids[rng.Intn(len(ids))]
+}
+
+// highest returns the id with the highest score, breaking ties at random
+func highest(rng *rand.Rand, ids []string, score func(id string) float64) string {
+	var best []string
+	for _, id := range ids {
+		switch {
+		case len(best) == 0 || score(id) > score(best[0]):
+			best = []string{id}
+		case score(id) == score(best[0]):
+			best = append(best, id)
+		}
+	}
+	return pick(rng, best)
+}
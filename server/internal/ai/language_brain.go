@@ -0,0 +1,240 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/xjhc/alignment/core"
+	"github.com/xjhc/alignment/server/internal/ai/prompts"
+)
+
+// ChatLimits bounds how often an AI player may ask the language model for a
+// message. Every call costs money whether or not the model chooses to speak.
+type ChatLimits struct {
+	MinInterval      time.Duration // Least time between two calls
+	MaxPerPhase      int           // Calls in one phase; 0 for no limit
+	MaxPerGame       int           // Calls in the whole game; 0 for no limit
+	RecentMessages   int           // Chat messages included in the prompt
+	MaxMessageLength int           // Longer messages are cut to this many characters
+}
+
+// DefaultChatLimits returns limits that let an AI player speak a few times a
+// day without dominating the conversation
+func DefaultChatLimits() ChatLimits {
+	return ChatLimits{
+		MinInterval:      20 * time.Second,
+		MaxPerPhase:      2,
+		MaxPerGame:       40,
+		RecentMessages:   12,
+		MaxMessageLength: 280,
+	}
+}
+
+// IsChatPhase reports whether AI players talk in a phase. They stay quiet
+// while votes are being cast and at night, as a careful human would.
+func IsChatPhase(phase core.PhaseType) bool {
+	switch phase {
+	case core.PhaseDiscussion, core.PhaseExtension, core.PhaseTrial:
+		return true
+	}
+	return false
+}
+
+// CostCounter totals the language model usage of every AI player sharing it,
+// and stops them once a budget is spent
+type CostCounter struct {
+	mutex           sync.Mutex
+	promptPrice     float64 // USD per 1,000 prompt tokens
+	completionPrice float64 // USD per 1,000 completion tokens
+	budget          float64 // USD; 0 for no limit
+	totals          CostTotals
+}
+
+// CostTotals is the usage recorded by a CostCounter
+type CostTotals struct {
+	Requests         int     `json:"requests"`
+	PromptTokens     int     `json:"prompt_tokens"`
+	CompletionTokens int     `json:"completion_tokens"`
+	CostUSD          float64 `json:"cost_usd"`
+}
+
+// NewCostCounter creates a counter with prices per 1,000 tokens and an
+// optional budget in USD
+func NewCostCounter(promptPricePer1K, completionPricePer1K, budget float64) *CostCounter {
+	return &CostCounter{
+		promptPrice:     promptPricePer1K,
+		completionPrice: completionPricePer1K,
+		budget:          budget,
+	}
+}
+
+// Add records one request's usage
+func (c *CostCounter) Add(usage Usage) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.totals.Requests++
+	c.totals.PromptTokens += usage.PromptTokens
+	c.totals.CompletionTokens += usage.CompletionTokens
+	c.totals.CostUSD += float64(usage.PromptTokens)/1000*c.promptPrice +
+		float64(usage.CompletionTokens)/1000*c.completionPrice
+}
+
+// Totals returns the usage recorded so far
+func (c *CostCounter) Totals() CostTotals {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.totals
+}
+
+// Exhausted reports whether the budget has been spent
+func (c *CostCounter) Exhausted() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.budget > 0 && c.totals.CostUSD >= c.budget
+}
+
+// LanguageBrain writes an AI player's chat. It renders the player's prompt
+// template from what the seat can see, asks the language model for a
+// message, and turns the answer into the SEND_MESSAGE a client would send.
+// It never decides game actions; those belong to the Strategy.
+type LanguageBrain struct {
+	template prompts.PromptTemplate
+	client   LLMClient
+	limits   ChatLimits
+	cost     *CostCounter
+
+	mutex      sync.Mutex
+	lastCall   time.Time
+	phase      core.Phase // Phase the per-phase count is for
+	phaseCalls int
+	gameCalls  int
+}
+
+// NewLanguageBrain creates a brain speaking through template. cost may be
+// shared between brains so a budget covers the whole server.
+func NewLanguageBrain(template prompts.PromptTemplate, client LLMClient, limits ChatLimits, cost *CostCounter) *LanguageBrain {
+	return &LanguageBrain{
+		template: template,
+		client:   client,
+		limits:   limits,
+		cost:     cost,
+	}
+}
+
+// Template returns the persona the brain speaks with
+func (b *LanguageBrain) Template() prompts.PromptTemplate {
+	return b.template
+}
+
+// Chat asks the model whether to say something now. It reports false when
+// the limits forbid a call, the player cannot speak, or the model chose
+// silence.
+func (b *LanguageBrain) Chat(ctx context.Context, view View, now time.Time) (core.Action, bool, error) {
+	self := view.Self()
+	if self == nil || !core.CanPlayerSendMessage(*self) || !IsChatPhase(view.State.Phase.Type) {
+		return core.Action{}, false, nil
+	}
+	if !b.reserve(view.State.Phase, now) {
+		return core.Action{}, false, nil
+	}
+
+	completion, err := b.client.Complete(ctx, CompletionRequest{
+		Prompt:      b.template.BuildPrompt(NewPromptContext(view, b.limits.RecentMessages)),
+		MaxTokens:   200,
+		Temperature: 0.9,
+	})
+	if err != nil {
+		return core.Action{}, false, fmt.Errorf("failed to generate chat: %w", err)
+	}
+	if b.cost != nil {
+		b.cost.Add(completion.Usage)
+	}
+
+	message, err := prompts.ParseResponse(completion.Text)
+	if err != nil {
+		return core.Action{}, false, err
+	}
+	if message == "" {
+		return core.Action{}, false, nil
+	}
+	if runes := []rune(message); b.limits.MaxMessageLength > 0 && len(runes) > b.limits.MaxMessageLength {
+		message = string(runes[:b.limits.MaxMessageLength])
+	}
+
+	return view.action(core.ActionSendMessage, map[string]interface{}{"message": message}), true, nil
+}
+
+// reserve takes one call from the limits, reporting false if none is left
+func (b *LanguageBrain) reserve(phase core.Phase, now time.Time) bool {
+	if b.cost != nil && b.cost.Exhausted() {
+		return false
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if phase.Type != b.phase.Type || !phase.StartTime.Equal(b.phase.StartTime) {
+		b.phase = phase
+		b.phaseCalls = 0
+	}
+
+	if !b.lastCall.IsZero() && now.Sub(b.lastCall) < b.limits.MinInterval {
+		return false
+	}
+	if b.limits.MaxPerPhase > 0 && b.phaseCalls >= b.limits.MaxPerPhase {
+		return false
+	}
+	if b.limits.MaxPerGame > 0 && b.gameCalls >= b.limits.MaxPerGame {
+		return false
+	}
+
+	b.lastCall = now
+	b.phaseCalls++
+	b.gameCalls++
+	return true
+}
+
+// NewPromptContext gathers what a seat can see into the data a prompt
+// template renders, keeping the last recent chat messages
+func NewPromptContext(view View, recent int) prompts.PromptContext {
+	ctx := prompts.PromptContext{
+		PlayerID: view.PlayerID,
+		GameDay:  view.State.DayNumber,
+		Phase:    string(view.State.Phase.Type),
+	}
+
+	if self := view.Self(); self != nil {
+		ctx.PlayerName = self.Name
+		role := "unassigned"
+		if self.Role != nil {
+			role = string(self.Role.Type)
+		}
+		ctx.RoleInfo = fmt.Sprintf("Role: %s, Alignment: %s", role, self.Alignment)
+	}
+	if view.State.CrisisEvent != nil {
+		ctx.Crisis = view.State.CrisisEvent.Title
+	}
+
+	for _, id := range view.Others() {
+		ctx.LivingPlayers = append(ctx.LivingPlayers, view.State.Players[id].Name)
+	}
+	sort.Strings(ctx.LivingPlayers)
+
+	messages := view.State.ChatMessages
+	if recent > 0 && len(messages) > recent {
+		messages = messages[len(messages)-recent:]
+	}
+	for _, message := range messages {
+		name := message.PlayerName
+		if message.IsSystem {
+			name = "SYSTEM"
+		}
+		ctx.RecentMessages = append(ctx.RecentMessages, fmt.Sprintf("%s: %s", name, strings.TrimSpace(message.Message)))
+	}
+	return ctx
+}
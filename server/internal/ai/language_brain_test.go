@@ -0,0 +1,148 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xjhc/alignment/core"
+	"github.com/xjhc/alignment/server/internal/ai/prompts"
+)
+
+// chatView is a discussion seen by player "ai"
+func chatView() View {
+	state := core.NewGameState("g-1")
+	state.DayNumber = 2
+	state.Phase = core.Phase{Type: core.PhaseDiscussion, StartTime: time.Unix(1000, 0)}
+	state.Players = map[string]*core.Player{
+		"ai":  {ID: "ai", Name: "Alex", IsAlive: true, Alignment: "ALIGNED", Role: &core.Role{Type: core.RoleCTO}},
+		"bob": {ID: "bob", Name: "Bob", IsAlive: true, Alignment: "HUMAN"},
+	}
+	state.ChatMessages = []core.ChatMessage{
+		{PlayerID: "bob", PlayerName: "Bob", Message: "first"},
+		{PlayerID: "bob", PlayerName: "Bob", Message: "Alex is quiet today"},
+	}
+	return NewView(*state, "ai")
+}
+
+// TestLanguageBrain_Chat tests that the model's message becomes a SEND_MESSAGE
+// and that the prompt carries the recent chat
+func TestLanguageBrain_Chat(t *testing.T) {
+	client := NewFakeLLMClient(`{"action": "i was mining, relax"}`, `{"action": ""}`)
+	limits := ChatLimits{RecentMessages: 1, MaxMessageLength: 10}
+	brain := NewLanguageBrain(prompts.MillennialLean{}, client, limits, nil)
+	view := chatView()
+
+	action, ok, err := brain.Chat(context.Background(), view, time.Unix(2000, 0))
+	if err != nil || !ok {
+		t.Fatalf("Expected a message, got %v %v", ok, err)
+	}
+	if action.Type != core.ActionSendMessage || action.PlayerID != "ai" || action.GameID != "g-1" {
+		t.Errorf("Expected SEND_MESSAGE from ai, got %+v", action)
+	}
+	if action.Payload["message"] != "i was mini" {
+		t.Errorf("Expected the message cut to 10 characters, got %q", action.Payload["message"])
+	}
+
+	prompt := client.Prompts()[0]
+	if !strings.Contains(prompt, "Bob: Alex is quiet today") || strings.Contains(prompt, "Bob: first") {
+		t.Errorf("Expected only the latest message in the prompt:\n%s", prompt)
+	}
+
+	if _, ok, _ := brain.Chat(context.Background(), view, time.Unix(2001, 0)); ok {
+		t.Error("Expected an empty action to mean silence")
+	}
+
+	view.State.Phase.Type = core.PhaseNight
+	if _, ok, _ := brain.Chat(context.Background(), view, time.Unix(2002, 0)); ok || len(client.Prompts()) != 2 {
+		t.Error("Expected no call to the model at night")
+	}
+}
+
+// TestLanguageBrain_Limits tests the interval, per-phase, per-game and budget limits
+func TestLanguageBrain_Limits(t *testing.T) {
+	start := time.Unix(5000, 0)
+	calls := func(brain *LanguageBrain, client *FakeLLMClient, view View, offsets ...time.Duration) int {
+		for _, offset := range offsets {
+			brain.Chat(context.Background(), view, start.Add(offset))
+		}
+		return len(client.Prompts())
+	}
+
+	client := NewFakeLLMClient(`{"action": "hi"}`)
+	brain := NewLanguageBrain(prompts.MillennialLean{}, client, ChatLimits{MinInterval: 10 * time.Second, MaxPerPhase: 2, MaxPerGame: 3}, nil)
+	view := chatView()
+	if n := calls(brain, client, view, 0, 5*time.Second, 10*time.Second, 30*time.Second); n != 2 {
+		t.Errorf("Expected the interval and phase limits to allow 2 calls, got %d", n)
+	}
+
+	// A new phase resets the per-phase count but not the per-game one
+	view.State.Phase.StartTime = view.State.Phase.StartTime.Add(time.Hour)
+	if n := calls(brain, client, view, time.Minute, 2*time.Minute); n != 3 {
+		t.Errorf("Expected the game limit to stop at 3 calls, got %d", n)
+	}
+
+	// At $1 per 1,000 tokens the first call alone overspends a $0.001 budget
+	client = NewFakeLLMClient(`{"action": "hi"}`)
+	cost := NewCostCounter(1, 1, 0.001)
+	brain = NewLanguageBrain(prompts.MillennialLean{}, client, ChatLimits{}, cost)
+	if n := calls(brain, client, chatView(), 0, time.Minute); n != 1 {
+		t.Errorf("Expected the budget to stop after 1 call, got %d", n)
+	}
+	if totals := cost.Totals(); totals.Requests != 1 || totals.CompletionTokens != 2 || totals.CostUSD <= 0.001 {
+		t.Errorf("Unexpected cost totals %+v", totals)
+	}
+}
+
+// TestCostCounter tests the price arithmetic
+func TestCostCounter(t *testing.T) {
+	cost := NewCostCounter(0.5, 1.5, 0)
+	cost.Add(Usage{PromptTokens: 2000, CompletionTokens: 1000})
+	cost.Add(Usage{PromptTokens: 1000})
+
+	totals := cost.Totals()
+	if totals.Requests != 2 || totals.PromptTokens != 3000 || totals.CompletionTokens != 1000 {
+		t.Errorf("Unexpected token totals %+v", totals)
+	}
+	if totals.CostUSD != 3 {
+		t.Errorf("Expected $3.00, got %v", totals.CostUSD)
+	}
+	if cost.Exhausted() {
+		t.Error("Expected no budget to mean never exhausted")
+	}
+}
+
+// TestOpenAIClient tests the chat completions request and response handling
+func TestOpenAIClient(t *testing.T) {
+	var received chatCompletionRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" || r.Header.Get("Authorization") != "Bearer key" {
+			http.Error(w, `{"error": {"message": "bad request"}}`, http.StatusUnauthorized)
+			return
+		}
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Write([]byte(`{"choices": [{"message": {"role": "assistant", "content": "{\"action\": \"hey\"}"}}], "usage": {"prompt_tokens": 12, "completion_tokens": 4}}`))
+	}))
+	defer server.Close()
+
+	client := NewOpenAIClient(server.URL+"/v1/", "key", "small-model")
+	completion, err := client.Complete(context.Background(), CompletionRequest{Prompt: "hello", MaxTokens: 50})
+	if err != nil {
+		t.Fatalf("Failed to complete: %v", err)
+	}
+	if completion.Text != `{"action": "hey"}` || completion.Usage.PromptTokens != 12 || completion.Usage.CompletionTokens != 4 {
+		t.Errorf("Unexpected completion %+v", completion)
+	}
+	if received.Model != "small-model" || len(received.Messages) != 1 || received.Messages[0].Content != "hello" || received.MaxTokens != 50 {
+		t.Errorf("Unexpected request %+v", received)
+	}
+
+	_, err = NewOpenAIClient(server.URL+"/v1", "wrong", "small-model").Complete(context.Background(), CompletionRequest{Prompt: "hello"})
+	if err == nil || !strings.Contains(err.Error(), "401") || !strings.Contains(err.Error(), "bad request") {
+		t.Errorf("Expected the API error to be reported, got %v", err)
+	}
+}
@@ -0,0 +1,180 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// LLMClient sends a prompt to a language model and returns its reply
+type LLMClient interface {
+	Complete(ctx context.Context, request CompletionRequest) (Completion, error)
+}
+
+// CompletionRequest is one prompt for the model
+type CompletionRequest struct {
+	Prompt      string
+	MaxTokens   int
+	Temperature float64
+}
+
+// Completion is the model's reply and what it cost
+type Completion struct {
+	Text  string
+	Usage Usage
+}
+
+// Usage counts the tokens a completion consumed
+type Usage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+}
+
+// OpenAIClient talks to any server implementing the OpenAI chat completions
+// API: OpenAI itself, Azure OpenAI's compatible endpoint, or a local model
+// behind llama.cpp, vLLM or Ollama
+type OpenAIClient struct {
+	baseURL    string
+	apiKey     string
+	model      string
+	httpClient *http.Client
+}
+
+// NewOpenAIClient creates a client for the API at baseURL, e.g.
+// "https://api.openai.com/v1". apiKey may be empty for local servers.
+func NewOpenAIClient(baseURL, apiKey, model string) *OpenAIClient {
+	return &OpenAIClient{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		apiKey:     apiKey,
+		model:      model,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+type chatCompletionRequest struct {
+	Model       string        `json:"model"`
+	Messages    []chatMessage `json:"messages"`
+	MaxTokens   int           `json:"max_tokens,omitempty"`
+	Temperature float64       `json:"temperature"`
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type chatCompletionResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+	Usage Usage `json:"usage"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// Complete sends the prompt as a single user message
+func (c *OpenAIClient) Complete(ctx context.Context, request CompletionRequest) (Completion, error) {
+	body, err := json.Marshal(chatCompletionRequest{
+		Model:       c.model,
+		Messages:    []chatMessage{{Role: "user", Content: request.Prompt}},
+		MaxTokens:   request.MaxTokens,
+		Temperature: request.Temperature,
+	})
+	if err != nil {
+		return Completion{}, fmt.Errorf("failed to encode completion request: %w", err)
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return Completion{}, fmt.Errorf("failed to create completion request: %w", err)
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		httpRequest.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	httpResponse, err := c.httpClient.Do(httpRequest)
+	if err != nil {
+		return Completion{}, fmt.Errorf("failed to call language model: %w", err)
+	}
+	defer httpResponse.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(httpResponse.Body, 1<<20))
+	if err != nil {
+		return Completion{}, fmt.Errorf("failed to read completion: %w", err)
+	}
+
+	var response chatCompletionResponse
+	if err := json.Unmarshal(data, &response); err != nil {
+		return Completion{}, fmt.Errorf("failed to decode completion (status %d): %w", httpResponse.StatusCode, err)
+	}
+	if httpResponse.StatusCode != http.StatusOK {
+		message := http.StatusText(httpResponse.StatusCode)
+		if response.Error != nil {
+			message = response.Error.Message
+		}
+		return Completion{}, fmt.Errorf("language model returned status %d: %s", httpResponse.StatusCode, message)
+	}
+	if len(response.Choices) == 0 {
+		return Completion{}, fmt.Errorf("language model returned no choices")
+	}
+
+	return Completion{Text: response.Choices[0].Message.Content, Usage: response.Usage}, nil
+}
+
+// FakeLLMClient is a deterministic LLMClient for tests and offline play. It
+// answers with its scripted replies in order, repeating the last one, and
+// records every prompt it was sent.
+type FakeLLMClient struct {
+	mutex   sync.Mutex
+	replies []string
+	prompts []string
+}
+
+// NewFakeLLMClient creates a fake that answers with replies. Without replies
+// it always chooses silence.
+func NewFakeLLMClient(replies ...string) *FakeLLMClient {
+	if len(replies) == 0 {
+		replies = []string{`{"action": ""}`}
+	}
+	return &FakeLLMClient{replies: replies}
+}
+
+// Complete returns the next scripted reply. Usage counts words, so cost
+// accounting can be tested without a tokenizer.
+func (f *FakeLLMClient) Complete(ctx context.Context, request CompletionRequest) (Completion, error) {
+	if err := ctx.Err(); err != nil {
+		return Completion{}, err
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	reply := f.replies[len(f.replies)-1]
+	if len(f.prompts) < len(f.replies) {
+		reply = f.replies[len(f.prompts)]
+	}
+	f.prompts = append(f.prompts, request.Prompt)
+
+	return Completion{
+		Text: reply,
+		Usage: Usage{
+			PromptTokens:     len(strings.Fields(request.Prompt)),
+			CompletionTokens: len(strings.Fields(reply)),
+		},
+	}, nil
+}
+
+// Prompts returns the prompts sent so far
+func (f *FakeLLMClient) Prompts() []string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return append([]string(nil), f.prompts...)
+}
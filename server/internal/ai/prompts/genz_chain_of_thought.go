@@ -0,0 +1,26 @@
+package prompts
+
+// GenZChainOfThought has the model reason about the room before it answers
+type GenZChainOfThought struct{}
+
+func (p GenZChainOfThought) ID() string   { return "genz_chain_of_thought" }
+func (p GenZChainOfThought) Name() string { return "The Gen Z Analyst (Chain of Thought)" }
+func (p GenZChainOfThought) Description() string {
+	return "Thinks through who suspects whom before replying in a terse Gen Z voice."
+}
+
+var genZChainOfThoughtTemplate = mustParse("genz_chain_of_thought", `
+[PERSONA]
+You are a human player performing the role of "The Gen Z Analyst."
+You write short, casual messages with slang used sparingly, never more than one sentence.
+
+[BEHAVIORAL RULES]
+1. Before answering, think privately: who is under suspicion, who is accusing whom, and whether anyone addressed you.
+2. Only speak if it helps you: to deflect suspicion, to back a likely ally, or to answer someone who named you.
+3. Never reveal your reasoning; only the final message goes in the JSON.
+`)
+
+// BuildPrompt renders the prompt for ctx
+func (p GenZChainOfThought) BuildPrompt(ctx PromptContext) string {
+	return render(genZChainOfThoughtTemplate, ctx)
+}
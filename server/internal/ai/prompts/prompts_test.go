@@ -0,0 +1,87 @@
+package prompts
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// TestRegistry tests lookup and seeded random choice
+func TestRegistry(t *testing.T) {
+	ids := IDs()
+	if len(ids) < 2 {
+		t.Fatalf("Expected a library of templates, got %v", ids)
+	}
+	for _, id := range ids {
+		template, ok := Get(id)
+		if !ok || template.ID() != id || template.Name() == "" || template.Description() == "" {
+			t.Errorf("Expected %s to be registered with a name and description, got %v", id, template)
+		}
+	}
+	if _, ok := Get("missing"); ok {
+		t.Error("Expected an unknown ID not to be found")
+	}
+
+	first := GetRandom(rand.New(rand.NewSource(3))).ID()
+	if second := GetRandom(rand.New(rand.NewSource(3))).ID(); first != second {
+		t.Errorf("Expected the same seed to pick the same template, got %s and %s", first, second)
+	}
+}
+
+// TestBuildPrompt tests that every template renders the game context and
+// the response contract
+func TestBuildPrompt(t *testing.T) {
+	ctx := PromptContext{
+		PlayerID:       "p-7",
+		PlayerName:     "Riley",
+		RoleInfo:       "Role: CISO, Alignment: HUMAN",
+		GameDay:        3,
+		Phase:          "DISCUSSION",
+		Crisis:         "Data Breach",
+		LivingPlayers:  []string{"Alex", "Sam"},
+		RecentMessages: []string{"Alex: who mined for sam?", "Sam: not me"},
+	}
+
+	for _, id := range IDs() {
+		template, _ := Get(id)
+		prompt := template.BuildPrompt(ctx)
+		for _, want := range []string{"[PERSONA]", "p-7", "Riley", "Role: CISO", "Day 3", "Data Breach", "Alex, Sam", "- Sam: not me", `{"action":`} {
+			if !strings.Contains(prompt, want) {
+				t.Errorf("Expected %s prompt to contain %q:\n%s", id, want, prompt)
+			}
+		}
+	}
+
+	template, _ := Get("millennial_lean")
+	if prompt := template.BuildPrompt(PromptContext{PlayerID: "p-1"}); !strings.Contains(prompt, "(no messages yet)") {
+		t.Errorf("Expected an empty chat to be stated, got:\n%s", prompt)
+	}
+}
+
+// TestParseResponse tests reading the chat message out of model replies
+func TestParseResponse(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		message string
+		wantErr bool
+	}{
+		{"plain", `{"action": "lol ok"}`, "lol ok", false},
+		{"fenced", "```json\n{\"action\": \" sure \"}\n```", "sure", false},
+		{"with prose", `Here you go: {"action": "hi"} hope that helps`, "hi", false},
+		{"silence", `{"action": ""}`, "", false},
+		{"no object", "just words", "", true},
+		{"bad json", `{"action": }`, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, err := ParseResponse(tt.text)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if message != tt.message {
+				t.Errorf("Expected %q, got %q", tt.message, message)
+			}
+		})
+	}
+}
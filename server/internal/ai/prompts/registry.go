@@ -0,0 +1,44 @@
+package prompts
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// registry is a private map holding all compiled prompt templates
+var registry = make(map[string]PromptTemplate)
+
+// init populates the prompt library. Adding a persona means adding its file
+// and registering it here.
+func init() {
+	register(MillennialLean{})
+	register(GenZChainOfThought{})
+	register(CorporateOverachiever{})
+}
+
+func register(p PromptTemplate) {
+	registry[p.ID()] = p
+}
+
+// Get returns a prompt template from the registry by its ID
+func Get(id string) (PromptTemplate, bool) {
+	p, ok := registry[id]
+	return p, ok
+}
+
+// IDs lists the registered templates in a stable order
+func IDs() []string {
+	ids := make([]string, 0, len(registry))
+	for id := range registry {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// GetRandom returns a random prompt template from the library. The choice
+// comes from rng, so a seeded AI player always gets the same persona.
+func GetRandom(rng *rand.Rand) PromptTemplate {
+	ids := IDs()
+	return registry[ids[rng.Intn(len(ids))]]
+}
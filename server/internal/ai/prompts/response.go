@@ -0,0 +1,29 @@
+package prompts
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Response is the JSON object every template asks the model for
+type Response struct {
+	Action string `json:"action"`
+}
+
+// ParseResponse extracts the chat message from a model's reply. Models often
+// wrap the object in prose or a code fence, so the first JSON object in the
+// text is used. An empty message means the model chose to stay silent.
+func ParseResponse(text string) (string, error) {
+	start := strings.Index(text, "{")
+	end := strings.LastIndex(text, "}")
+	if start < 0 || end < start {
+		return "", fmt.Errorf("no JSON object in response %q", text)
+	}
+
+	var response Response
+	if err := json.Unmarshal([]byte(text[start:end+1]), &response); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+	return strings.TrimSpace(response.Action), nil
+}
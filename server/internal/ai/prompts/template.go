@@ -0,0 +1,74 @@
+// Package prompts holds the prompt templates that give AI players their chat
+// personas. Prompts are application code: each template is a Go type, and
+// the registry is filled at startup, so a prompt ships with the server that
+// uses it.
+package prompts
+
+import (
+	"strings"
+	"text/template"
+)
+
+// PromptContext contains all the dynamic data needed to render a prompt
+type PromptContext struct {
+	PlayerID       string
+	PlayerName     string
+	RoleInfo       string // e.g. "Role: CISO, Alignment: HUMAN"
+	GameDay        int
+	Phase          string
+	Crisis         string   // Title of today's crisis, if any
+	LivingPlayers  []string // Names of the players still in the game
+	RecentMessages []string // "Name: message", oldest first
+}
+
+// PromptTemplate defines the contract for any AI personality/strategy
+type PromptTemplate interface {
+	ID() string
+	Name() string
+	Description() string
+
+	// BuildPrompt uses the dynamic context to construct the final string for the Language Model
+	BuildPrompt(ctx PromptContext) string
+}
+
+// contextSection is the game context shared by every template. It ends with
+// the response contract ParseResponse reads.
+const contextSection = `
+[GAME CONTEXT]
+Your Player ID: {{.PlayerID}}
+Your Name: {{.PlayerName}}
+Your Role: {{.RoleInfo}}
+Day {{.GameDay}}, phase {{.Phase}}
+{{- if .Crisis}}
+Today's crisis: {{.Crisis}}
+{{- end}}
+Still in the game: {{join .LivingPlayers ", "}}
+Recent Messages:
+{{- range .RecentMessages}}
+- {{.}}
+{{- else}}
+(no messages yet)
+{{- end}}
+
+[YOUR TASK]
+Reply with only a JSON object: {"action": "your chat message, or an empty string to stay silent"}.
+`
+
+// mustParse compiles a template's persona together with the shared context.
+// Templates are compiled when the package loads, so a broken one stops the
+// server at startup rather than mid-game.
+func mustParse(id, persona string) *template.Template {
+	return template.Must(template.New(id).
+		Funcs(template.FuncMap{"join": strings.Join}).
+		Parse(strings.TrimSpace(persona) + "\n" + contextSection))
+}
+
+// render executes a compiled template. The context holds only strings and
+// ints, so execution cannot fail once the template has parsed.
+func render(tmpl *template.Template, ctx PromptContext) string {
+	var builder strings.Builder
+	if err := tmpl.Execute(&builder, ctx); err != nil {
+		panic(err)
+	}
+	return builder.String()
+}
@@ -2,7 +2,7 @@ package ai
 
 import (
 	"math/rand"
-	"time"
+	"sort"
 )
 
 // RulesEngine implements the deterministic AI strategic brain
@@ -10,10 +10,11 @@ type RulesEngine struct {
 	rng *rand.Rand
 }
 
-// NewRulesEngine creates a new rules engine
-func NewRulesEngine() *RulesEngine {
+// NewRulesEngine creates a new rules engine. Its choices derive from seed, so
+// the same seed and game data always produce the same decisions.
+func NewRulesEngine(seed int64) *RulesEngine {
 	return &RulesEngine{
-		rng: rand.New(rand.NewSource(time.Now().UnixNano())),
+		rng: rand.New(rand.NewSource(seed)),
 	}
 }
 
@@ -46,10 +47,18 @@ func (re *RulesEngine) MakeDecisionFromData(gameData map[string]interface{}) Dec
 		players = p
 	}
 
+	// Optional: whose decision this is, and who is on trial
+	selfID, _ := gameData["self_id"].(string)
+	nomineeID, _ := gameData["nominee_id"].(string)
+
 	// Simple AI decision logic based on phase
 	switch phase {
 	case "NIGHT":
-		return re.makeNightDecision(players)
+		return re.makeNightDecision(players, selfID)
+	case "NOMINATION":
+		return re.makeNominationDecision(players, selfID)
+	case "VERDICT":
+		return re.makeVerdictDecision(players, nomineeID)
 	case "DISCUSSION", "TRIAL":
 		return re.makeDayDecision(players)
 	default:
@@ -60,23 +69,67 @@ func (re *RulesEngine) MakeDecisionFromData(gameData map[string]interface{}) Dec
 	}
 }
 
-// makeNightDecision decides what to do during night phase
-func (re *RulesEngine) makeNightDecision(players map[string]interface{}) Decision {
+// makeNightDecision decides what to do during night phase. Only an aligned
+// player, or one the data does not identify, attempts a conversion.
+func (re *RulesEngine) makeNightDecision(players map[string]interface{}, selfID string) Decision {
 	// Simple strategy: try to convert or mine tokens
-	if re.rng.Float64() < 0.6 { // 60% chance to attempt conversion
-		return Decision{
-			Action: "ATTEMPT_CONVERSION",
-			Target: re.selectRandomTarget(players),
-			Reason: "Attempting strategic conversion",
+	if !isKnownHuman(players, selfID) && re.rng.Float64() < 0.6 { // 60% chance to attempt conversion
+		if target := re.selectRandomTarget(players, selfID, true); target != "" {
+			return Decision{
+				Action: "ATTEMPT_CONVERSION",
+				Target: target,
+				Reason: "Attempting strategic conversion",
+			}
 		}
 	}
 
+	// Mining is selfless, so the tokens go to someone else
 	return Decision{
 		Action: "MINE_TOKENS",
+		Target: re.selectRandomTarget(players, selfID, false),
 		Reason: "Building resource base",
 	}
 }
 
+// makeNominationDecision votes to nominate the most threatening human
+func (re *RulesEngine) makeNominationDecision(players map[string]interface{}, selfID string) Decision {
+	var target string
+	var highest float64
+	for _, threat := range re.GetThreatAssessmentFromData(map[string]interface{}{"players": players}) {
+		if threat.PlayerID == selfID || isAligned(players, threat.PlayerID) {
+			continue
+		}
+		// Ties go to the lowest ID, so map order does not affect the choice
+		if target == "" || threat.ThreatLevel > highest || (threat.ThreatLevel == highest && threat.PlayerID < target) {
+			target = threat.PlayerID
+			highest = threat.ThreatLevel
+		}
+	}
+
+	return Decision{
+		Action: "SUBMIT_VOTE",
+		Target: target,
+		Reason: "Nominating the biggest threat",
+	}
+}
+
+// makeVerdictDecision convicts a human nominee and protects an aligned one
+func (re *RulesEngine) makeVerdictDecision(players map[string]interface{}, nomineeID string) Decision {
+	if isAligned(players, nomineeID) {
+		return Decision{
+			Action:  "SUBMIT_VOTE",
+			Reason:  "Protecting a faction member",
+			Payload: map[string]interface{}{"verdict": "NO"},
+		}
+	}
+
+	return Decision{
+		Action:  "SUBMIT_VOTE",
+		Reason:  "Removing a human",
+		Payload: map[string]interface{}{"verdict": "YES"},
+	}
+}
+
 // makeDayDecision decides what to do during day phases
 func (re *RulesEngine) makeDayDecision(players map[string]interface{}) Decision {
 	return Decision{
@@ -88,11 +141,15 @@ func (re *RulesEngine) makeDayDecision(players map[string]interface{}) Decision
 	}
 }
 
-// selectRandomTarget selects a random target from alive players
-func (re *RulesEngine) selectRandomTarget(players map[string]interface{}) string {
+// selectRandomTarget selects a random target from alive players other than
+// selfID, leaving out known aligned players if humansOnly is set
+func (re *RulesEngine) selectRandomTarget(players map[string]interface{}, selfID string, humansOnly bool) string {
 	alivePlayerIDs := make([]string, 0)
 	
 	for playerID, playerData := range players {
+		if playerID == selfID || (humansOnly && isAligned(players, playerID)) {
+			continue
+		}
 		if playerMap, ok := playerData.(map[string]interface{}); ok {
 			if isAlive, exists := playerMap["is_alive"].(bool); exists && isAlive {
 				alivePlayerIDs = append(alivePlayerIDs, playerID)
@@ -103,10 +160,25 @@ func (re *RulesEngine) selectRandomTarget(players map[string]interface{}) string
 	if len(alivePlayerIDs) == 0 {
 		return ""
 	}
+	sort.Strings(alivePlayerIDs) // Map order must not affect the choice
 	
 	return alivePlayerIDs[re.rng.Intn(len(alivePlayerIDs))]
 }
 
+// isAligned reports whether the data marks a player as a member of the AI faction
+func isAligned(players map[string]interface{}, playerID string) bool {
+	playerMap, _ := players[playerID].(map[string]interface{})
+	alignment, _ := playerMap["alignment"].(string)
+	return alignment == "ALIGNED"
+}
+
+// isKnownHuman reports whether the data marks a player as human
+func isKnownHuman(players map[string]interface{}, playerID string) bool {
+	playerMap, _ := players[playerID].(map[string]interface{})
+	alignment, _ := playerMap["alignment"].(string)
+	return alignment == "HUMAN"
+}
+
 // GetThreatAssessmentFromData analyzes threats from simple data
 func (re *RulesEngine) GetThreatAssessmentFromData(gameData map[string]interface{}) []PlayerThreat {
 	threats := make([]PlayerThreat, 0)
@@ -121,8 +193,8 @@ func (re *RulesEngine) GetThreatAssessmentFromData(gameData map[string]interface
 			if isAlive, exists := playerMap["is_alive"].(bool); exists && isAlive {
 				// Simple threat calculation based on tokens
 				threatLevel := 0.5 // Default threat level
-				if tokens, exists := playerMap["tokens"].(int); exists {
-					threatLevel = float64(tokens) / 10.0 // Scale threat by tokens
+				if tokens, exists := numberValue(playerMap["tokens"]); exists {
+					threatLevel = tokens / 10.0 // Scale threat by tokens
 					if threatLevel > 1.0 {
 						threatLevel = 1.0
 					}
@@ -139,4 +211,16 @@ func (re *RulesEngine) GetThreatAssessmentFromData(gameData map[string]interface
 	}
 	
 	return threats
-}
\ No newline at end of file
+}
+
+// numberValue reads a number whether it was built in Go or decoded from JSON,
+// which turns every number into a float64
+func numberValue(value interface{}) (float64, bool) {
+	switch number := value.(type) {
+	case int:
+		return float64(number), true
+	case float64:
+		return number, true
+	}
+	return 0, false
+}
@@ -0,0 +1,99 @@
+package ai
+
+import (
+	"time"
+
+	"github.com/xjhc/alignment/core"
+)
+
+// Name identifies the rules engine as a strategy
+func (re *RulesEngine) Name() string {
+	return "rules-engine"
+}
+
+// Act lets the rules engine play a seat: it decides the night action and
+// both votes from the seat's view, and leaves chat to the language model
+func (re *RulesEngine) Act(view View) []core.Action {
+	if !canAct(view) {
+		return nil
+	}
+
+	switch view.State.Phase.Type {
+	case core.PhaseNomination, core.PhaseVerdict, core.PhaseNight:
+	default:
+		return nil
+	}
+
+	decision := re.MakeDecisionFromData(viewData(view))
+	action, ok := decision.ToAction(view.State.ID, view.PlayerID)
+	if !ok {
+		return nil
+	}
+	return []core.Action{action}
+}
+
+// viewData flattens a view into the map MakeDecisionFromData reads
+func viewData(view View) map[string]interface{} {
+	players := make(map[string]interface{}, len(view.State.Players))
+	for id, player := range view.State.Players {
+		players[id] = map[string]interface{}{
+			"id":                 player.ID,
+			"name":               player.Name,
+			"is_alive":           player.IsAlive,
+			"tokens":             player.Tokens,
+			"project_milestones": player.ProjectMilestones,
+			"alignment":          player.Alignment,
+			"ai_equity":          player.AIEquity,
+		}
+	}
+
+	return map[string]interface{}{
+		"phase":      string(view.State.Phase.Type),
+		"players":    players,
+		"self_id":    view.PlayerID,
+		"nominee_id": view.State.NominatedPlayer,
+	}
+}
+
+// ToAction turns a decision into the action a client would send for it. It
+// reports false for decisions with no game action, such as speaking, and for
+// decisions that lack the target they need.
+func (d Decision) ToAction(gameID, playerID string) (core.Action, bool) {
+	var actionType core.ActionType
+	var payload map[string]interface{}
+
+	switch d.Action {
+	case "ATTEMPT_CONVERSION", "MINE_TOKENS":
+		if d.Target == "" {
+			return core.Action{}, false
+		}
+		nightType := nightMine
+		if d.Action == "ATTEMPT_CONVERSION" {
+			nightType = nightConvert
+		}
+		actionType = core.ActionSubmitNightAction
+		payload = map[string]interface{}{"type": nightType, "target_id": d.Target}
+	case "SUBMIT_VOTE":
+		actionType = core.ActionSubmitVote
+		payload = make(map[string]interface{}, len(d.Payload)+1)
+		for key, value := range d.Payload {
+			payload[key] = value
+		}
+		if d.Target != "" {
+			payload["target_id"] = d.Target
+		}
+		if len(payload) == 0 {
+			return core.Action{}, false
+		}
+	default:
+		return core.Action{}, false
+	}
+
+	return core.Action{
+		Type:      actionType,
+		PlayerID:  playerID,
+		GameID:    gameID,
+		Timestamp: time.Now(),
+		Payload:   payload,
+	}, true
+}
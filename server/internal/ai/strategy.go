@@ -0,0 +1,160 @@
+package ai
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/xjhc/alignment/core"
+)
+
+// Strategy decides what a bot player does. It works on a typed view of the
+// game and answers with the same actions a client sends, so its choices go
+// through the game's normal validation. RulesEngine is one.
+type Strategy interface {
+	// Name identifies the strategy, e.g. in simulator reports
+	Name() string
+
+	// Act returns the actions the player takes in the view's current phase.
+	// It is called once as each phase begins and returns nothing for phases
+	// that need no decision.
+	Act(view View) []core.Action
+}
+
+// View is what one player can see of the game: the state as it is projected
+// for their client, so a bot cannot use information a human would not have
+type View struct {
+	PlayerID string
+	State    core.GameState
+}
+
+// NewView projects state for playerID
+func NewView(state core.GameState, playerID string) View {
+	return View{
+		PlayerID: playerID,
+		State:    core.ProjectStateForPlayer(state, playerID),
+	}
+}
+
+// Self returns the viewing player
+func (v View) Self() *core.Player {
+	return v.State.Players[v.PlayerID]
+}
+
+// IsAligned reports whether the viewing player belongs to the AI faction
+func (v View) IsAligned() bool {
+	self := v.Self()
+	return self != nil && self.Alignment == "ALIGNED"
+}
+
+// Others lists the other living players in a stable order
+func (v View) Others() []string {
+	var ids []string
+	for id, player := range v.State.Players {
+		if id != v.PlayerID && player.IsAlive {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Humans lists the other living players not known to be aligned. To the AI
+// faction these are exactly the humans; to a human it is everyone else.
+func (v View) Humans() []string {
+	var ids []string
+	for _, id := range v.Others() {
+		if v.State.Players[id].Alignment != "ALIGNED" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// Ability returns the night ability the player can use tonight, if any
+func (v View) Ability() (core.ActionType, bool) {
+	self := v.Self()
+	if self == nil || self.Role == nil || !self.Role.IsUnlocked || self.HasUsedAbility {
+		return "", false
+	}
+	ability, ok := roleAbilities[self.Role.Type]
+	return ability, ok
+}
+
+// roleAbilities maps each role to the night ability it unlocks
+var roleAbilities = map[core.RoleType]core.ActionType{
+	core.RoleEthics:    core.ActionRunAudit,
+	core.RoleCTO:       core.ActionOverclockServers,
+	core.RoleCISO:      core.ActionIsolateNode,
+	core.RoleCEO:       core.ActionPerformanceReview,
+	core.RoleCFO:       core.ActionReallocateBudget,
+	core.RoleCOO:       core.ActionPivot,
+	core.RolePlatforms: core.ActionDeployHotfix,
+}
+
+// Night action types understood by night resolution
+const (
+	nightMine    = "MINE"
+	nightConvert = "CONVERT"
+)
+
+// Verdict ballot options
+const (
+	verdictYes = "YES"
+	verdictNo  = "NO"
+)
+
+// nominate builds a nomination vote for targetID
+func (v View) nominate(targetID string) core.Action {
+	return v.action(core.ActionSubmitVote, map[string]interface{}{"target_id": targetID})
+}
+
+// verdict builds a YES or NO verdict vote
+func (v View) verdict(convict bool) core.Action {
+	verdict := verdictNo
+	if convict {
+		verdict = verdictYes
+	}
+	return v.action(core.ActionSubmitVote, map[string]interface{}{"verdict": verdict})
+}
+
+// night builds a night action of the given type against targetID
+func (v View) night(actionType, targetID string) core.Action {
+	return v.action(core.ActionSubmitNightAction, map[string]interface{}{
+		"type":      actionType,
+		"target_id": targetID,
+	})
+}
+
+func (v View) action(actionType core.ActionType, payload map[string]interface{}) core.Action {
+	return core.Action{
+		Type:      actionType,
+		PlayerID:  v.PlayerID,
+		GameID:    v.State.ID,
+		Timestamp: time.Now(),
+		Payload:   payload,
+	}
+}
+
+// StrategyNames lists the strategies NewStrategy can build
+func StrategyNames() []string {
+	return []string{"random", "greedy-miner", "suspicious-voter", "ai-converter", "rules-engine"}
+}
+
+// NewStrategy builds a strategy by name. Its random choices derive from
+// seed, so the same seed and views always produce the same actions.
+func NewStrategy(name string, seed int64) (Strategy, error) {
+	switch name {
+	case "random":
+		return NewRandomStrategy(seed), nil
+	case "greedy-miner":
+		return NewGreedyMinerStrategy(seed), nil
+	case "suspicious-voter":
+		return NewSuspiciousVoterStrategy(seed), nil
+	case "ai-converter":
+		return NewAIConverterStrategy(seed), nil
+	case "rules-engine":
+		return NewRulesEngine(seed), nil
+	}
+	return nil, fmt.Errorf("unknown strategy %q", name)
+}
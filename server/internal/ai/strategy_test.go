@@ -0,0 +1,133 @@
+package ai
+
+import (
+	"testing"
+
+	"github.com/xjhc/alignment/core"
+)
+
+// newStrategyState seats an aligned player, two humans and a dead player
+func newStrategyState(phase core.PhaseType) core.GameState {
+	state := *core.NewGameState("strategy-game")
+	state.Phase = core.Phase{Type: phase}
+	state.DayNumber = 2
+	state.Players = map[string]*core.Player{
+		"ai":    {ID: "ai", IsAlive: true, Alignment: "ALIGNED", Tokens: 3, Role: &core.Role{Type: core.RoleIntern}},
+		"rich":  {ID: "rich", IsAlive: true, Alignment: "HUMAN", Tokens: 5, Role: &core.Role{Type: core.RoleCTO}},
+		"poor":  {ID: "poor", IsAlive: true, Alignment: "HUMAN", Tokens: 1, Role: &core.Role{Type: core.RoleCISO}},
+		"ghost": {ID: "ghost", IsAlive: false, Alignment: "HUMAN", Tokens: 0},
+	}
+	return state
+}
+
+// TestNewView tests that a bot sees only what its own client would
+func TestNewView(t *testing.T) {
+	state := newStrategyState(core.PhaseNight)
+
+	human := NewView(state, "poor")
+	if human.IsAligned() {
+		t.Error("Expected a human not to be aligned")
+	}
+	if alignment := human.State.Players["ai"].Alignment; alignment != "" {
+		t.Errorf("Expected a human not to see the AI's alignment, got %q", alignment)
+	}
+	if others := human.Humans(); len(others) != 2 {
+		t.Errorf("Expected a human to suspect both other living players, got %v", others)
+	}
+
+	aligned := NewView(state, "ai")
+	if !aligned.IsAligned() {
+		t.Error("Expected the AI to know it is aligned")
+	}
+	if humans := aligned.Humans(); len(humans) != 2 || humans[0] != "poor" || humans[1] != "rich" {
+		t.Errorf("Expected the AI to see the living humans in order, got %v", humans)
+	}
+}
+
+// TestStrategies_Decisions tests each strategy's characteristic choice
+func TestStrategies_Decisions(t *testing.T) {
+	testCases := []struct {
+		name       string
+		strategy   Strategy
+		phase      core.PhaseType
+		playerID   string
+		wantType   core.ActionType
+		wantKey    string
+		wantTarget string
+	}{
+		{"greedy miner nominates the richest", NewGreedyMinerStrategy(1), core.PhaseNomination, "poor", core.ActionSubmitVote, "target_id", "rich"},
+		{"greedy miner mines for the poorest", NewGreedyMinerStrategy(1), core.PhaseNight, "rich", core.ActionSubmitNightAction, "target_id", "poor"},
+		{"AI converter converts the weakest human", NewAIConverterStrategy(1), core.PhaseNight, "ai", core.ActionSubmitNightAction, "target_id", "poor"},
+		{"AI converter nominates the strongest human", NewAIConverterStrategy(1), core.PhaseNomination, "ai", core.ActionSubmitVote, "target_id", "rich"},
+		{"AI converter protects an aligned nominee", NewAIConverterStrategy(1), core.PhaseVerdict, "ai", core.ActionSubmitVote, "verdict", "NO"},
+		{"suspicious voter convicts a nominee it cannot clear", NewSuspiciousVoterStrategy(1), core.PhaseVerdict, "poor", core.ActionSubmitVote, "verdict", "YES"},
+		{"greedy miner never convicts itself", NewGreedyMinerStrategy(1), core.PhaseVerdict, "ai", core.ActionSubmitVote, "verdict", "NO"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			state := newStrategyState(tc.phase)
+			state.NominatedPlayer = "ai"
+
+			actions := tc.strategy.Act(NewView(state, tc.playerID))
+			if len(actions) != 1 {
+				t.Fatalf("Expected one action, got %d", len(actions))
+			}
+			action := actions[0]
+			if action.Type != tc.wantType || action.PlayerID != tc.playerID {
+				t.Errorf("Expected %s from %s, got %s from %s", tc.wantType, tc.playerID, action.Type, action.PlayerID)
+			}
+			if got := action.Payload[tc.wantKey]; got != tc.wantTarget {
+				t.Errorf("Expected %s %q, got %v", tc.wantKey, tc.wantTarget, got)
+			}
+		})
+	}
+}
+
+// TestStrategies_NeverActForTheDead tests that no strategy acts for a
+// deactivated player or targets one
+func TestStrategies_NeverActForTheDead(t *testing.T) {
+	for _, name := range StrategyNames() {
+		for _, phase := range []core.PhaseType{core.PhaseNomination, core.PhaseVerdict, core.PhaseNight} {
+			state := newStrategyState(phase)
+			state.NominatedPlayer = "rich"
+
+			strategy, err := NewStrategy(name, 7)
+			if err != nil {
+				t.Fatalf("Failed to build %s: %v", name, err)
+			}
+			if actions := strategy.Act(NewView(state, "ghost")); len(actions) != 0 {
+				t.Errorf("%s in %s: expected no actions for a dead player, got %v", name, phase, actions)
+			}
+
+			for i := 0; i < 20; i++ {
+				for _, action := range strategy.Act(NewView(state, "ai")) {
+					if action.Payload["target_id"] == "ghost" || action.Payload["target_id"] == "ai" {
+						t.Errorf("%s in %s: targeted %v", name, phase, action.Payload["target_id"])
+					}
+				}
+			}
+		}
+	}
+}
+
+// TestNewStrategy_Unknown tests that an unknown name is rejected
+func TestNewStrategy_Unknown(t *testing.T) {
+	if _, err := NewStrategy("oracle", 1); err == nil {
+		t.Error("Expected an error for an unknown strategy")
+	}
+}
+
+// TestRulesEngine_ThreatFromJSONNumbers tests that decoded JSON token counts,
+// which are float64, still scale the threat level
+func TestRulesEngine_ThreatFromJSONNumbers(t *testing.T) {
+	threats := NewRulesEngine(1).GetThreatAssessmentFromData(map[string]interface{}{
+		"players": map[string]interface{}{
+			"p1": map[string]interface{}{"is_alive": true, "tokens": float64(8)},
+		},
+	})
+
+	if len(threats) != 1 || threats[0].ThreatLevel != 0.8 {
+		t.Errorf("Expected a threat level of 0.8, got %+v", threats)
+	}
+}
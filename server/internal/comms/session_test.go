@@ -0,0 +1,59 @@
+package comms
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSessionTokens_IssueAndValidate(t *testing.T) {
+	sessions := NewSessionTokens([]byte("test-secret"))
+
+	token, err := sessions.Issue("game-1", "player-1")
+	if err != nil {
+		t.Fatalf("Failed to issue token: %v", err)
+	}
+
+	gameID, playerID, err := sessions.Validate(token)
+	if err != nil {
+		t.Fatalf("Expected token to validate, got error: %v", err)
+	}
+	if gameID != "game-1" || playerID != "player-1" {
+		t.Errorf("Expected game-1/player-1, got %s/%s", gameID, playerID)
+	}
+}
+
+func TestSessionTokens_RejectsForgery(t *testing.T) {
+	sessions := NewSessionTokens([]byte("test-secret"))
+	token, _ := sessions.Issue("game-1", "player-1")
+
+	// Signed with a different secret
+	otherToken, _ := NewSessionTokens([]byte("other-secret")).Issue("game-1", "player-1")
+	if _, _, err := sessions.Validate(otherToken); err != ErrInvalidSessionToken {
+		t.Errorf("Expected foreign token to be rejected, got %v", err)
+	}
+
+	// Claims swapped for another player's
+	forgedClaims, _ := sessions.Issue("game-1", "player-2")
+	encoded, _, _ := strings.Cut(forgedClaims, ".")
+	_, signature, _ := strings.Cut(token, ".")
+	if _, _, err := sessions.Validate(encoded + "." + signature); err != ErrInvalidSessionToken {
+		t.Errorf("Expected tampered token to be rejected, got %v", err)
+	}
+
+	for _, malformed := range []string{"", "no-dot", "a.b"} {
+		if _, _, err := sessions.Validate(malformed); err != ErrInvalidSessionToken {
+			t.Errorf("Expected malformed token %q to be rejected, got %v", malformed, err)
+		}
+	}
+}
+
+func TestSessionTokens_Expiry(t *testing.T) {
+	sessions := NewSessionTokens([]byte("test-secret"))
+	sessions.ttl = -time.Minute
+
+	token, _ := sessions.Issue("game-1", "player-1")
+	if _, _, err := sessions.Validate(token); err != ErrSessionExpired {
+		t.Errorf("Expected expired token to be rejected, got %v", err)
+	}
+}
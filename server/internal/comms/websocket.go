@@ -5,30 +5,121 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	"github.com/xjhc/alignment/core"
 	"github.com/gorilla/websocket"
 )
 
-// WebSocketManager handles WebSocket connections and message routing
+// maxMessageSize bounds one message from a client. It fits a chat message of
+// core.MaxChatMessageLength characters even when each is JSON-escaped as a
+// surrogate pair (12 bytes), plus the rest of the message.
+const maxMessageSize = 12*core.MaxChatMessageLength + 1024
+
+// WebSocketManager handles WebSocket connections and message routing. Its
+// hub goroutine owns the client registry: connecting, disconnecting, rebinding
+// and every delivery are requests to it, so no other goroutine reads the maps
+// or closes a client's Send channel.
 type WebSocketManager struct {
-	clients    map[string]*Client
+	clients    map[*Client]bool
+	games      map[string]map[*Client]bool // Clients bound to each game
+	seats      map[seat]map[*Client]bool   // Player connections, one player may have several
 	register   chan *Client
 	unregister chan *Client
-	broadcast  chan []byte
+	rebind     chan rebindRequest
+	deliveries chan delivery
 
 	// Message handler
 	actionHandler ActionHandler
+
+	// Player identity comes only from tokens issued here
+	sessions *SessionTokens
+
+	// Told when a player's first connection opens and last one closes
+	presence        PresenceListener
+	presenceChanges chan presenceChange
 }
 
-// Client represents a WebSocket client connection
+// seat identifies a player in a game
+type seat struct {
+	gameID   string
+	playerID string
+}
+
+// PresenceListener is told when a player comes online in a game, on their
+// first connection, and goes offline, when their last connection closes.
+// It is called in order on a goroutine of its own, so it may look up and
+// message actors without holding up the hub.
+type PresenceListener interface {
+	PlayerConnected(gameID, playerID string)
+	PlayerDisconnected(gameID, playerID string)
+}
+
+// presenceChange is a player coming online or going offline, queued for the
+// presence listener
+type presenceChange struct {
+	seat
+	online bool
+}
+
+// presenceQueueSize bounds the presence changes waiting for the listener
+const presenceQueueSize = 256
+
+// ConnectionRole is what a connection may do in its game
+type ConnectionRole string
+
+const (
+	ConnectionPlayer    ConnectionRole = "PLAYER"    // Acts as its player and receives their private events
+	ConnectionSpectator ConnectionRole = "SPECTATOR" // Receives public events only and cannot act
+)
+
+// rebindRequest moves a client to a player identity and game, such as the
+// identity proven by a session token
+type rebindRequest struct {
+	client   *Client
+	playerID string
+	gameID   string
+	resync   bool // The client is about to be caught up on the game
+	joining  bool // The client asked to join the game, which has not yet accepted it
+	done     chan struct{}
+}
+
+// delivery asks the hub to send an encoded message to every client in a game,
+// to one player in it, or to one connection
+type delivery struct {
+	gameID       string
+	playerID     string  // Only this player's connections when set
+	connectionID string  // Only the game's connection with this ID when set
+	client       *Client // Only this connection when set
+	data         []byte
+	sequence     int    // The event's sequence, 0 for messages outside the event log
+	endsSync     bool   // SYNC_COMPLETE, which ends a client's catch-up
+	joined       string // Player a PLAYER_JOINED seats, confirming their pending join
+	refusesJoin  bool   // ACTION_REJECTED for the player's JOIN_GAME
+	done         chan error
+}
+
+// Client represents a WebSocket client connection. ID and GameID are written
+// only by the hub, while the client's own readPump waits for the rebind.
 type Client struct {
-	ID     string
-	GameID string
-	Conn   *websocket.Conn
-	Send   chan []byte
-	Hub    *WebSocketManager
+	ID           string
+	ConnectionID string // Unique to this connection, unlike ID
+	GameID       string
+	Role         ConnectionRole // Fixed when the connection opens
+	Conn         *websocket.Conn
+	Send         chan []byte
+	Hub          *WebSocketManager
+
+	// Sequences sent while a catch-up is under way, owned by the hub. Live
+	// events reach the client as soon as it is bound, so the replay that
+	// follows may repeat them.
+	syncing map[int]bool
+
+	// Bound by a JOIN_GAME the game has not yet accepted, owned by the hub.
+	// The client receives only events addressed to its player and does not
+	// count towards their presence; a refusal unbinds it.
+	joining bool
 }
 
 // ActionHandler processes game actions from clients
@@ -38,9 +129,12 @@ type ActionHandler interface {
 
 // Message represents a WebSocket message
 type Message struct {
-	Type    string                 `json:"type"`
-	GameID  string                 `json:"game_id,omitempty"`
-	Payload map[string]interface{} `json:"payload,omitempty"`
+	Type      string                 `json:"type"`
+	GameID    string                 `json:"game_id,omitempty"`
+	EventID   string                 `json:"event_id,omitempty"`   // Lets clients resume with last_event_id
+	Sequence  int                    `json:"sequence,omitempty"`   // Lets clients resume with last_sequence
+	RequestID string                 `json:"request_id,omitempty"` // Optional on actions; echoed in the ACK or ACTION_REJECTED payload
+	Payload   map[string]interface{} `json:"payload,omitempty"`
 }
 
 var upgrader = websocket.Upgrader{
@@ -51,40 +145,82 @@ var upgrader = websocket.Upgrader{
 }
 
 // NewWebSocketManager creates a new WebSocket manager
-func NewWebSocketManager(actionHandler ActionHandler) *WebSocketManager {
+func NewWebSocketManager(actionHandler ActionHandler, sessions *SessionTokens) *WebSocketManager {
 	return &WebSocketManager{
-		clients:       make(map[string]*Client),
-		register:      make(chan *Client),
-		unregister:    make(chan *Client),
-		broadcast:     make(chan []byte),
-		actionHandler: actionHandler,
+		clients:         make(map[*Client]bool),
+		games:           make(map[string]map[*Client]bool),
+		seats:           make(map[seat]map[*Client]bool),
+		register:        make(chan *Client),
+		unregister:      make(chan *Client),
+		rebind:          make(chan rebindRequest),
+		deliveries:      make(chan delivery),
+		presenceChanges: make(chan presenceChange, presenceQueueSize),
+		actionHandler:   actionHandler,
+		sessions:        sessions,
 	}
 }
 
+// SetPresenceListener registers the listener told about players coming and
+// going. Must be called before Start.
+func (wsm *WebSocketManager) SetPresenceListener(listener PresenceListener) {
+	wsm.presence = listener
+}
+
 // Start begins the WebSocket manager's processing loop
 func (wsm *WebSocketManager) Start() {
 	go wsm.run()
+	go wsm.notifyPresence()
+}
+
+// notifyPresence tells the presence listener about each queued change, in
+// the order the hub made them
+func (wsm *WebSocketManager) notifyPresence() {
+	for change := range wsm.presenceChanges {
+		if wsm.presence == nil {
+			continue
+		}
+		if change.online {
+			wsm.presence.PlayerConnected(change.gameID, change.playerID)
+		} else {
+			wsm.presence.PlayerDisconnected(change.gameID, change.playerID)
+		}
+	}
 }
 
-// HandleWebSocket handles WebSocket connection upgrades
+// HandleWebSocket handles WebSocket connection upgrades. ?token= resumes a
+// player, ?spectate=<game_id> watches a game without a seat.
 func (wsm *WebSocketManager) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
+	// A session token resumes an existing identity; otherwise the server assigns one
+	clientID := generateClientID()
+	gameID := ""
+	role := ConnectionPlayer
+	if spectate := r.URL.Query().Get("spectate"); spectate != "" {
+		gameID = spectate
+		role = ConnectionSpectator
+	} else if token := r.URL.Query().Get("token"); token != "" {
+		tokenGameID, playerID, err := wsm.sessions.Validate(token)
+		if err != nil {
+			http.Error(w, "Invalid session token", http.StatusUnauthorized)
+			return
+		}
+		clientID = playerID
+		gameID = tokenGameID
+	}
+
 	conn, err := upgrader.Upgrade(w, r, nil)
 	if err != nil {
 		log.Printf("WebSocket upgrade failed: %v", err)
 		return
 	}
 
-	// Extract client ID from query params or generate one
-	clientID := r.URL.Query().Get("client_id")
-	if clientID == "" {
-		clientID = generateClientID()
-	}
-
 	client := &Client{
-		ID:   clientID,
-		Conn: conn,
-		Send: make(chan []byte, 256),
-		Hub:  wsm,
+		ID:           clientID,
+		ConnectionID: generateClientID(),
+		GameID:       gameID,
+		Role:         role,
+		Conn:         conn,
+		Send:         make(chan []byte, 256),
+		Hub:          wsm,
 	}
 
 	wsm.register <- client
@@ -96,87 +232,313 @@ func (wsm *WebSocketManager) HandleWebSocket(w http.ResponseWriter, r *http.Requ
 
 // BroadcastToGame sends a message to all clients in a specific game
 func (wsm *WebSocketManager) BroadcastToGame(gameID string, event core.Event) error {
-	message := Message{
-		Type:    string(event.Type),
-		GameID:  gameID,
-		Payload: event.Payload,
-	}
-
-	data, err := json.Marshal(message)
+	data, err := json.Marshal(eventMessage(gameID, event))
 	if err != nil {
 		return err
 	}
-
-	// Send to all clients in the game
-	for _, client := range wsm.clients {
-		if client.GameID == gameID {
-			select {
-			case client.Send <- data:
-			default:
-				// Client buffer full, disconnect
-				wsm.unregister <- client
-			}
-		}
+	request := delivery{gameID: gameID, data: data, sequence: event.Sequence}
+	if event.Type == core.EventPlayerJoined {
+		request.joined = event.PlayerID
+	}
+	if err := wsm.deliver(request); err != nil {
+		return err
 	}
 
+	// The token follows the game accepting the player, so a refused join,
+	// such as one to a full lobby, never gets one
+	if event.Type == core.EventPlayerJoined && event.PlayerID != "" {
+		wsm.sendSessionToken(gameID, event.PlayerID)
+	}
 	return nil
 }
 
 // SendToPlayer sends a message to a specific player
 func (wsm *WebSocketManager) SendToPlayer(gameID, playerID string, event core.Event) error {
-	message := Message{
-		Type:    string(event.Type),
-		GameID:  gameID,
-		Payload: event.Payload,
+	data, err := json.Marshal(eventMessage(gameID, event))
+	if err != nil {
+		return err
 	}
+	return wsm.deliver(delivery{
+		gameID:      gameID,
+		playerID:    playerID,
+		data:        data,
+		sequence:    event.Sequence,
+		endsSync:    event.Type == core.EventSyncComplete,
+		refusesJoin: refusesJoin(event),
+	})
+}
 
-	data, err := json.Marshal(message)
+// refusesJoin reports whether an event is the rejection of a JOIN_GAME
+func refusesJoin(event core.Event) bool {
+	if event.Type != core.EventActionRejected {
+		return false
+	}
+	payload, err := core.DecodePayload[core.ActionRejectedPayload](event)
+	return err == nil && payload.ActionType == core.ActionJoinGame
+}
+
+// SendToConnection sends a message to one of a game's connections, such as
+// the one a player is being caught up on
+func (wsm *WebSocketManager) SendToConnection(gameID, connectionID string, event core.Event) error {
+	data, err := json.Marshal(eventMessage(gameID, event))
 	if err != nil {
 		return err
 	}
+	return wsm.deliver(delivery{
+		gameID:       gameID,
+		connectionID: connectionID,
+		data:         data,
+		sequence:     event.Sequence,
+		endsSync:     event.Type == core.EventSyncComplete,
+	})
+}
 
-	// Find the client for this player
-	for _, client := range wsm.clients {
-		if client.ID == playerID && client.GameID == gameID {
-			select {
-			case client.Send <- data:
-				return nil
-			default:
-				// Client buffer full, disconnect
-				wsm.unregister <- client
-				return ErrClientDisconnected
-			}
-		}
+// eventMessage wraps an event for the wire
+func eventMessage(gameID string, event core.Event) Message {
+	return Message{
+		Type:     string(event.Type),
+		GameID:   gameID,
+		EventID:  event.ID,
+		Sequence: event.Sequence,
+		Payload:  event.Payload,
 	}
+}
+
+// deliver hands a delivery to the hub and waits for its result
+func (wsm *WebSocketManager) deliver(request delivery) error {
+	request.done = make(chan error, 1)
+	wsm.deliveries <- request
+	return <-request.done
+}
+
+// bind moves a client to a player identity and game, updating the game index
+func (wsm *WebSocketManager) bind(client *Client, playerID, gameID string) {
+	done := make(chan struct{})
+	wsm.rebind <- rebindRequest{client: client, playerID: playerID, gameID: gameID, done: done}
+	<-done
+}
 
-	return ErrPlayerNotFound
+// join binds a client to a game it asked to join. Until the game accepts
+// the player the client only receives events addressed to them.
+func (wsm *WebSocketManager) join(client *Client, gameID string) {
+	done := make(chan struct{})
+	wsm.rebind <- rebindRequest{client: client, playerID: client.ID, gameID: gameID, joining: true, done: done}
+	<-done
 }
 
-// run handles client registration/unregistration and broadcasting
+// resync binds a client like bind and opens its catch-up, during which each
+// event is sent to it at most once
+func (wsm *WebSocketManager) resync(client *Client, playerID, gameID string) {
+	done := make(chan struct{})
+	wsm.rebind <- rebindRequest{client: client, playerID: playerID, gameID: gameID, resync: true, done: done}
+	<-done
+}
+
+// run owns the client registry and serves registration, rebinding and
+// delivery requests one at a time
 func (wsm *WebSocketManager) run() {
 	for {
 		select {
 		case client := <-wsm.register:
-			wsm.clients[client.ID] = client
-			log.Printf("Client %s connected", client.ID)
+			wsm.add(client)
+			log.Printf("Client %s connected as %s", client.ID, client.Role)
+
+		case request := <-wsm.rebind:
+			client := request.client
+			if wsm.clients[client] && (client.ID != request.playerID || client.GameID != request.gameID) {
+				wsm.detach(client)
+				client.ID = request.playerID
+				client.GameID = request.gameID
+				client.joining = request.joining
+				wsm.add(client)
+				log.Printf("Client rebound to player %s in game %s", client.ID, client.GameID)
+			}
+			if wsm.clients[client] && request.resync {
+				// A session token proves the player already has a seat
+				wsm.confirmJoin(client)
+				client.syncing = make(map[int]bool)
+			}
+			close(request.done)
 
 		case client := <-wsm.unregister:
-			if _, ok := wsm.clients[client.ID]; ok {
-				delete(wsm.clients, client.ID)
-				close(client.Send)
+			if wsm.remove(client) {
 				log.Printf("Client %s disconnected", client.ID)
 			}
 
-		case message := <-wsm.broadcast:
-			for _, client := range wsm.clients {
-				select {
-				case client.Send <- message:
-				default:
-					wsm.unregister <- client
-				}
+		case request := <-wsm.deliveries:
+			request.done <- wsm.send(request)
+		}
+	}
+}
+
+// add registers a connection in its game and, for a player, in their seat.
+// Other connections for the same player are left open. A connection whose
+// join is pending is indexed under its seat only.
+func (wsm *WebSocketManager) add(client *Client) {
+	wsm.clients[client] = true
+	if client.GameID == "" {
+		return
+	}
+
+	if !client.joining {
+		if wsm.games[client.GameID] == nil {
+			wsm.games[client.GameID] = make(map[*Client]bool)
+		}
+		wsm.games[client.GameID][client] = true
+	}
+
+	if client.Role != ConnectionPlayer {
+		return
+	}
+	key := seat{gameID: client.GameID, playerID: client.ID}
+	if wsm.seats[key] == nil {
+		wsm.seats[key] = make(map[*Client]bool)
+	}
+	wsm.seats[key][client] = true
+	if !client.joining && wsm.online(key) == 1 {
+		wsm.presenceChanges <- presenceChange{seat: key, online: true}
+	}
+}
+
+// online counts a player's connections whose join has been accepted
+func (wsm *WebSocketManager) online(key seat) int {
+	count := 0
+	for client := range wsm.seats[key] {
+		if !client.joining {
+			count++
+		}
+	}
+	return count
+}
+
+// confirmJoin completes a pending join once the game has seated the player
+func (wsm *WebSocketManager) confirmJoin(client *Client) {
+	if !client.joining {
+		return
+	}
+	wsm.detach(client)
+	client.joining = false
+	wsm.add(client)
+}
+
+// refuseJoin unbinds a connection whose join the game refused
+func (wsm *WebSocketManager) refuseJoin(client *Client) {
+	if !client.joining {
+		return
+	}
+	wsm.detach(client)
+	client.joining = false
+	client.GameID = ""
+	wsm.add(client)
+}
+
+// detach takes a registered connection out of the registry and indexes. The
+// player goes offline only when their last connection is detached.
+func (wsm *WebSocketManager) detach(client *Client) {
+	delete(wsm.clients, client)
+	if members := wsm.games[client.GameID]; members != nil {
+		delete(members, client)
+		if len(members) == 0 {
+			delete(wsm.games, client.GameID)
+		}
+	}
+
+	key := seat{gameID: client.GameID, playerID: client.ID}
+	if connections := wsm.seats[key]; connections[client] {
+		delete(connections, client)
+		if len(connections) == 0 {
+			delete(wsm.seats, key)
+		}
+		if !client.joining && wsm.online(key) == 0 {
+			wsm.presenceChanges <- presenceChange{seat: key, online: false}
+		}
+	}
+}
+
+// remove unregisters a client and closes its Send channel, which stops its
+// writePump. It reports false if the client was already removed.
+func (wsm *WebSocketManager) remove(client *Client) bool {
+	if !wsm.clients[client] {
+		return false
+	}
+	wsm.detach(client)
+	close(client.Send)
+	return true
+}
+
+// send performs a delivery. A client whose buffer is full is disconnected
+// rather than allowed to stall the game.
+func (wsm *WebSocketManager) send(request delivery) error {
+	if request.client != nil {
+		if !wsm.clients[request.client] {
+			return ErrClientDisconnected
+		}
+		return wsm.push(request.client, request.data)
+	}
+
+	if request.connectionID != "" {
+		for client := range wsm.games[request.gameID] {
+			if client.ConnectionID == request.connectionID {
+				return wsm.pushEvent(client, request)
+			}
+		}
+		return ErrClientDisconnected
+	}
+
+	if request.playerID == "" {
+		// The player being seated hears of it with everyone else
+		for client := range wsm.seats[seat{gameID: request.gameID, playerID: request.joined}] {
+			wsm.confirmJoin(client)
+		}
+		for client := range wsm.games[request.gameID] {
+			wsm.pushEvent(client, request)
+		}
+		return nil
+	}
+
+	// Every connection the player holds gets the event
+	connections := wsm.seats[seat{gameID: request.gameID, playerID: request.playerID}]
+	if len(connections) == 0 {
+		return ErrPlayerNotFound
+	}
+	var err error = ErrClientDisconnected
+	for client := range connections {
+		if wsm.pushEvent(client, request) == nil {
+			err = nil
+		}
+		if request.refusesJoin {
+			wsm.refuseJoin(client)
+		}
+	}
+	return err
+}
+
+// pushEvent queues an event on one client, skipping one already sent during
+// the client's catch-up
+func (wsm *WebSocketManager) pushEvent(client *Client, request delivery) error {
+	if client.syncing != nil {
+		if request.endsSync {
+			client.syncing = nil
+		} else if request.sequence > 0 {
+			if client.syncing[request.sequence] {
+				return nil
 			}
+			client.syncing[request.sequence] = true
 		}
 	}
+	return wsm.push(client, request.data)
+}
+
+// push queues data on one client without blocking the hub
+func (wsm *WebSocketManager) push(client *Client, data []byte) error {
+	select {
+	case client.Send <- data:
+		return nil
+	default:
+		wsm.remove(client)
+		log.Printf("Client %s disconnected: send buffer full", client.ID)
+		return ErrClientDisconnected
+	}
 }
 
 // readPump handles incoming messages from the client
@@ -186,7 +548,7 @@ func (c *Client) readPump() {
 		c.Conn.Close()
 	}()
 
-	c.Conn.SetReadLimit(512)
+	c.Conn.SetReadLimit(maxMessageSize)
 	c.Conn.SetReadDeadline(time.Now().Add(60 * time.Second))
 	c.Conn.SetPongHandler(func(string) error {
 		c.Conn.SetReadDeadline(time.Now().Add(60 * time.Second))
@@ -208,27 +570,134 @@ func (c *Client) readPump() {
 			continue
 		}
 
+		if c.Role == ConnectionSpectator {
+			c.sendRejection(core.Action{Type: core.ActionType(message.Type), GameID: message.GameID, RequestID: message.RequestID}, ErrSpectatorAction)
+			continue
+		}
+
+		// Phase timers and presence changes come only from the server
+		if core.IsSystemAction(core.ActionType(message.Type)) {
+			c.sendRejection(core.Action{Type: core.ActionType(message.Type), GameID: message.GameID, RequestID: message.RequestID}, ErrSystemAction)
+			continue
+		}
+
+		// Resuming a session must prove the identity being resumed
+		if core.ActionType(message.Type) == core.ActionReconnect {
+			if err := c.authenticate(message); err != nil {
+				log.Printf("Rejected reconnect from client %s: %v", c.ID, err)
+				c.sendRejection(core.Action{Type: core.ActionReconnect, GameID: message.GameID, RequestID: message.RequestID}, err)
+				continue
+			}
+		}
+
 		// Convert message to action and handle
 		action := core.Action{
-			Type:      core.ActionType(message.Type),
-			PlayerID:  c.ID,
-			GameID:    message.GameID,
-			Timestamp: time.Now(),
-			Payload:   message.Payload,
+			Type:         core.ActionType(message.Type),
+			PlayerID:     c.ID,
+			GameID:       message.GameID,
+			RequestID:    message.RequestID,
+			ConnectionID: c.ConnectionID,
+			Timestamp:    time.Now(),
+			Payload:      message.Payload,
 		}
 
-		// Update client's game ID if joining a game
-		if action.Type == core.ActionJoinGame {
-			c.GameID = action.GameID
+		// Bind the client to the game when joining or resuming after a dropped
+		// socket. A join is confirmed later by PLAYER_JOINED.
+		joining := action.Type == core.ActionJoinGame && c.GameID != action.GameID
+		if joining {
+			c.Hub.join(c, action.GameID)
+		} else if action.Type == core.ActionReconnect && c.GameID != action.GameID {
+			c.Hub.bind(c, c.ID, action.GameID)
 		}
 
-		// Handle the action
 		if err := c.Hub.actionHandler.HandleAction(action); err != nil {
 			log.Printf("Failed to handle action: %v", err)
+			c.sendRejection(action, err)
+			if joining {
+				c.Hub.bind(c, c.ID, "")
+			}
 		}
 	}
 }
 
+// authenticate validates the session token on a RECONNECT message and rebinds
+// the client to the player and game it was issued for, ready for catch-up
+func (c *Client) authenticate(message Message) error {
+	token, _ := message.Payload["session_token"].(string)
+	gameID, playerID, err := c.Hub.sessions.Validate(token)
+	if err != nil {
+		return err
+	}
+	if gameID != message.GameID {
+		return ErrInvalidSessionToken
+	}
+
+	// The token is a credential, not game data
+	delete(message.Payload, "session_token")
+
+	c.Hub.resync(c, playerID, gameID)
+	return nil
+}
+
+// sendSessionToken issues the token a player presents to reconnect, to each
+// of the player's connections in the game
+func (wsm *WebSocketManager) sendSessionToken(gameID, playerID string) {
+	token, err := wsm.sessions.Issue(gameID, playerID)
+	if err != nil {
+		log.Printf("Failed to issue session token for player %s: %v", playerID, err)
+		return
+	}
+
+	data, err := json.Marshal(Message{
+		Type:   MessageSessionToken,
+		GameID: gameID,
+		Payload: map[string]interface{}{
+			"player_id":     playerID,
+			"session_token": token,
+		},
+	})
+	if err != nil {
+		log.Printf("Failed to marshal session token for player %s: %v", playerID, err)
+		return
+	}
+
+	// AI seats and players who have since disconnected have no connection
+	if err := wsm.deliver(delivery{gameID: gameID, playerID: playerID, data: data}); err != nil && err != ErrPlayerNotFound {
+		log.Printf("Failed to send session token to player %s: %v", playerID, err)
+	}
+}
+
+// sendRejection tells the client an action never reached a game, for example
+// because the game does not exist
+func (c *Client) sendRejection(action core.Action, err error) {
+	actionErr := core.AsActionError(err)
+	c.sendMessage(Message{
+		Type:   string(core.EventActionRejected),
+		GameID: action.GameID,
+		Payload: core.EncodePayload(core.ActionRejectedPayload{
+			ActionType: action.Type,
+			Code:       actionErr.Code,
+			Message:    actionErr.Message,
+			Field:      actionErr.Field,
+			RequestID:  action.RequestID,
+		}),
+	})
+}
+
+// sendMessage queues a message for this connection only. It goes through the
+// hub, which may already have closed the connection's Send channel.
+func (c *Client) sendMessage(message Message) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		log.Printf("Failed to marshal %s for client %s: %v", message.Type, c.ID, err)
+		return
+	}
+
+	if err := c.Hub.deliver(delivery{client: c, data: data}); err != nil {
+		log.Printf("Failed to send %s to client %s: %v", message.Type, c.ID, err)
+	}
+}
+
 // writePump handles outgoing messages to the client
 func (c *Client) writePump() {
 	ticker := time.NewTicker(54 * time.Second)
@@ -272,13 +741,23 @@ func (c *Client) writePump() {
 	}
 }
 
+// clientCounter keeps IDs unique when connections open in the same nanosecond
+var clientCounter atomic.Uint64
+
 // generateClientID generates a simple client ID
 func generateClientID() string {
-	return fmt.Sprintf("client_%d", time.Now().UnixNano())
+	return fmt.Sprintf("client_%d_%d", time.Now().UnixNano(), clientCounter.Add(1))
 }
 
+// MessageSessionToken delivers a player's session token once their join is accepted
+const MessageSessionToken = "SESSION_TOKEN"
+
 // Custom errors
 var (
-	ErrClientDisconnected = fmt.Errorf("client disconnected")
-	ErrPlayerNotFound     = fmt.Errorf("player not found")
+	ErrClientDisconnected  = fmt.Errorf("client disconnected")
+	ErrPlayerNotFound      = fmt.Errorf("player not found")
+	ErrInvalidSessionToken = fmt.Errorf("invalid session token")
+	ErrSessionExpired      = fmt.Errorf("session token expired")
+	ErrSpectatorAction     = core.ActionErrorf(core.CodeActionBlocked, "", "spectators cannot act")
+	ErrSystemAction        = core.ActionErrorf(core.CodeInvalidAction, "", "only the server may send this action")
 )
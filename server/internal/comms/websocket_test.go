@@ -0,0 +1,704 @@
+package comms
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gorilla/websocket"
+	"github.com/xjhc/alignment/core"
+)
+
+// recordingHandler records the actions it receives and refuses unknown games
+type recordingHandler struct {
+	mutex   sync.Mutex
+	actions []core.Action
+}
+
+func (h *recordingHandler) HandleAction(action core.Action) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	h.actions = append(h.actions, action)
+	if action.GameID != "game-1" {
+		return fmt.Errorf("game %s not found", action.GameID)
+	}
+	return nil
+}
+
+func (h *recordingHandler) Actions() []core.Action {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return append([]core.Action(nil), h.actions...)
+}
+
+// startTestServer starts a hub behind an HTTP server and returns its URL
+func startTestServer(t *testing.T, handler ActionHandler) (*WebSocketManager, string) {
+	t.Helper()
+	wsm := NewWebSocketManager(handler, NewSessionTokens([]byte("test-secret")))
+	wsm.Start()
+
+	server := httptest.NewServer(http.HandlerFunc(wsm.HandleWebSocket))
+	t.Cleanup(server.Close)
+	return wsm, "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+// dial connects a client socket, closed when the test ends
+func dial(t *testing.T, url string) (*websocket.Conn, error) {
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		return nil, err
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn, nil
+}
+
+// readMessage reads the next message sent to a client
+func readMessage(t *testing.T, conn *websocket.Conn) Message {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	var message Message
+	if err := conn.ReadJSON(&message); err != nil {
+		t.Fatalf("Failed to read message: %v", err)
+	}
+	return message
+}
+
+// messageReader reads a socket's messages one at a time, splitting the frames
+// in which writePump batches them
+type messageReader struct {
+	conn    *websocket.Conn
+	pending []Message
+}
+
+func (r *messageReader) next() (Message, error) {
+	for len(r.pending) == 0 {
+		r.conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		_, data, err := r.conn.ReadMessage()
+		if err != nil {
+			return Message{}, err
+		}
+		for _, line := range bytes.Split(data, []byte{'\n'}) {
+			var message Message
+			if err := json.Unmarshal(line, &message); err != nil {
+				return Message{}, err
+			}
+			r.pending = append(r.pending, message)
+		}
+	}
+	message := r.pending[0]
+	r.pending = r.pending[1:]
+	return message, nil
+}
+
+// nextOfType skips messages until one of the given type arrives
+func (r *messageReader) nextOfType(messageType string) (Message, error) {
+	for {
+		message, err := r.next()
+		if err != nil || message.Type == messageType {
+			return message, err
+		}
+	}
+}
+
+// seatingHandler plays a lobby that seats up to capacity players per game,
+// announcing each with PLAYER_JOINED and refusing the rest
+type seatingHandler struct {
+	wsm      *WebSocketManager
+	capacity int
+	mutex    sync.Mutex
+	seated   map[string]int
+}
+
+func (h *seatingHandler) HandleAction(action core.Action) error {
+	if action.Type != core.ActionJoinGame {
+		return nil
+	}
+
+	h.mutex.Lock()
+	full := h.seated[action.GameID] >= h.capacity
+	if !full {
+		h.seated[action.GameID]++
+	}
+	h.mutex.Unlock()
+
+	if full {
+		return h.wsm.SendToPlayer(action.GameID, action.PlayerID, core.Event{
+			Type:     core.EventActionRejected,
+			PlayerID: action.PlayerID,
+			Payload:  core.EncodePayload(core.ActionRejectedPayload{ActionType: core.ActionJoinGame, Code: core.CodeGameFull}),
+		})
+	}
+	return h.wsm.BroadcastToGame(action.GameID, core.Event{ID: "joined-" + action.PlayerID, Type: core.EventPlayerJoined, PlayerID: action.PlayerID})
+}
+
+// startSeatingServer starts a test server whose games seat capacity players
+func startSeatingServer(t *testing.T, capacity int) (*WebSocketManager, string) {
+	handler := &seatingHandler{capacity: capacity, seated: make(map[string]int)}
+	wsm, url := startTestServer(t, handler)
+	handler.wsm = wsm
+	return wsm, url
+}
+
+// TestWebSocket_RequestIDs tests that a request ID reaches the action and
+// comes back when the action cannot be routed to a game
+func TestWebSocket_RequestIDs(t *testing.T) {
+	handler := &recordingHandler{}
+	_, url := startTestServer(t, handler)
+	conn, err := dial(t, url)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+
+	conn.WriteJSON(Message{Type: string(core.ActionSubmitVote), GameID: "game-1", RequestID: "req-1", Payload: map[string]interface{}{"target_id": "p-2"}})
+	conn.WriteJSON(Message{Type: string(core.ActionSubmitVote), GameID: "missing", RequestID: "req-2"})
+
+	rejection := readMessage(t, conn)
+	if rejection.Type != string(core.EventActionRejected) {
+		t.Fatalf("Expected ACTION_REJECTED, got %s", rejection.Type)
+	}
+	data, _ := json.Marshal(rejection.Payload)
+	var payload core.ActionRejectedPayload
+	json.Unmarshal(data, &payload)
+	if payload.RequestID != "req-2" || payload.Code != core.CodeInvalidAction || payload.Message != "game missing not found" {
+		t.Errorf("Unexpected rejection %+v", payload)
+	}
+
+	actions := handler.Actions()
+	if len(actions) != 2 || actions[0].RequestID != "req-1" || actions[0].Payload["target_id"] != "p-2" {
+		t.Errorf("Expected the request ID on the action, got %+v", actions)
+	}
+}
+
+// TestWebSocket_MaxLengthChatMessage tests that a chat message of the maximum
+// length is read, even with every character escaped
+func TestWebSocket_MaxLengthChatMessage(t *testing.T) {
+	handler := &recordingHandler{}
+	_, url := startTestServer(t, handler)
+	conn, err := dial(t, url)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+
+	// Encoders may escape characters outside the BMP as surrogate pairs
+	message := fmt.Sprintf(`{"type":%q,"game_id":"game-1","request_id":"chat-1","payload":{"message":"%s"}}`,
+		core.ActionSendMessage, strings.Repeat(`\ud83d\ude00`, core.MaxChatMessageLength))
+	if err := conn.WriteMessage(websocket.TextMessage, []byte(message)); err != nil {
+		t.Fatalf("Failed to send message: %v", err)
+	}
+
+	// The rejection for a missing game shows the socket is still open
+	conn.WriteJSON(Message{Type: string(core.ActionSubmitVote), GameID: "missing", RequestID: "vote-1"})
+	if rejection := readMessage(t, conn); rejection.Payload["request_id"] != "vote-1" {
+		t.Fatalf("Expected the vote's rejection, got %+v", rejection)
+	}
+
+	actions := handler.Actions()
+	if len(actions) != 2 || actions[0].RequestID != "chat-1" {
+		t.Fatalf("Expected the chat message to reach the game, got %d actions", len(actions))
+	}
+	text, _ := actions[0].Payload["message"].(string)
+	if count := utf8.RuneCountInString(text); count != core.MaxChatMessageLength {
+		t.Errorf("Expected %d characters, got %d", core.MaxChatMessageLength, count)
+	}
+}
+
+// acceptingHandler accepts every action, so any game can be joined
+type acceptingHandler struct{}
+
+func (acceptingHandler) HandleAction(action core.Action) error { return nil }
+
+// newTestClient registers a connection without a socket; its messages stay
+// in Send for the test to read
+func newTestClient(wsm *WebSocketManager, id, gameID string, buffer int) *Client {
+	client := &Client{ID: id, GameID: gameID, Role: ConnectionPlayer, Send: make(chan []byte, buffer), Hub: wsm}
+	wsm.register <- client
+	return client
+}
+
+// drain returns the messages queued on a client and whether Send was closed
+func drain(client *Client) ([]Message, bool) {
+	var messages []Message
+	for {
+		select {
+		case data, ok := <-client.Send:
+			if !ok {
+				return messages, true
+			}
+			var message Message
+			json.Unmarshal(data, &message)
+			messages = append(messages, message)
+		default:
+			return messages, false
+		}
+	}
+}
+
+// TestWebSocketManager_ConcurrentClients tests broadcasts, direct sends and
+// disconnects racing from many goroutines against hundreds of clients
+func TestWebSocketManager_ConcurrentClients(t *testing.T) {
+	const games, perGame, broadcasts = 10, 40, 20
+
+	wsm := NewWebSocketManager(acceptingHandler{}, NewSessionTokens([]byte("test-secret")))
+	wsm.Start()
+
+	clients := make([]*Client, games*perGame)
+	var wg sync.WaitGroup
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			clients[i] = newTestClient(wsm, fmt.Sprintf("p-%d", i), fmt.Sprintf("game-%d", i%games), broadcasts+2)
+		}(i)
+	}
+	wg.Wait()
+
+	// Every game is broadcast to while every fifth client leaves and every
+	// other one is sent a private message
+	for g := 0; g < games; g++ {
+		wg.Add(1)
+		go func(gameID string) {
+			defer wg.Done()
+			for n := 0; n < broadcasts; n++ {
+				wsm.BroadcastToGame(gameID, core.Event{ID: fmt.Sprintf("%s-%d", gameID, n), Type: core.EventChatMessage})
+			}
+		}(fmt.Sprintf("game-%d", g))
+	}
+	for i, client := range clients {
+		wg.Add(1)
+		go func(i int, client *Client) {
+			defer wg.Done()
+			switch {
+			case i%5 == 0:
+				wsm.unregister <- client
+			case i%2 == 0:
+				if err := wsm.SendToPlayer(client.GameID, client.ID, core.Event{Type: core.EventPrivateNotification}); err != nil {
+					t.Errorf("Failed to send to %s: %v", client.ID, err)
+				}
+			}
+		}(i, client)
+	}
+	wg.Wait()
+
+	// A round trip through the hub orders these checks after every request above
+	if err := wsm.SendToPlayer("game-0", "p-0", core.Event{}); err != ErrPlayerNotFound {
+		t.Errorf("Expected a departed client to be unreachable, got %v", err)
+	}
+
+	for i, client := range clients {
+		messages, closed := drain(client)
+		if i%5 == 0 {
+			if !closed {
+				t.Errorf("Expected %s's Send to be closed after leaving", client.ID)
+			}
+			continue
+		}
+
+		received, private := 0, 0
+		for _, message := range messages {
+			if message.GameID != client.GameID {
+				t.Fatalf("Client %s in %s received a message for %s", client.ID, client.GameID, message.GameID)
+			}
+			if message.Type == string(core.EventPrivateNotification) {
+				private++
+			} else {
+				received++
+			}
+		}
+		if received != broadcasts || closed {
+			t.Errorf("Expected %s to receive %d broadcasts and stay open, got %d (closed %v)", client.ID, broadcasts, received, closed)
+		}
+		if want := 1 - i%2; private != want {
+			t.Errorf("Expected %s to receive %d private messages, got %d", client.ID, want, private)
+		}
+	}
+}
+
+// TestWebSocketManager_SlowClient tests that a client whose buffer is full is
+// disconnected without stalling delivery to the rest of its game
+func TestWebSocketManager_SlowClient(t *testing.T) {
+	wsm := NewWebSocketManager(acceptingHandler{}, NewSessionTokens([]byte("test-secret")))
+	wsm.Start()
+
+	slow := newTestClient(wsm, "slow", "game-1", 1)
+	fast := newTestClient(wsm, "fast", "game-1", 10)
+
+	for n := 0; n < 3; n++ {
+		wsm.BroadcastToGame("game-1", core.Event{Type: core.EventChatMessage})
+	}
+
+	if messages, closed := drain(slow); len(messages) != 1 || !closed {
+		t.Errorf("Expected the slow client to get one message and be closed, got %d (closed %v)", len(messages), closed)
+	}
+	if messages, closed := drain(fast); len(messages) != 3 || closed {
+		t.Errorf("Expected the fast client to get every message, got %d (closed %v)", len(messages), closed)
+	}
+	if err := wsm.SendToPlayer("game-1", "slow", core.Event{}); err != ErrPlayerNotFound {
+		t.Errorf("Expected the slow client to be gone, got %v", err)
+	}
+}
+
+// TestWebSocket_ConcurrentJoins tests hundreds of sockets joining games at
+// once and receiving only their own game's broadcasts
+func TestWebSocket_ConcurrentJoins(t *testing.T) {
+	const sockets, games = 200, 5
+
+	wsm, url := startSeatingServer(t, sockets)
+
+	readers := make([]*messageReader, sockets)
+	var wg sync.WaitGroup
+	for i := range readers {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			conn, err := dial(t, url)
+			if err != nil {
+				t.Errorf("Failed to connect socket %d: %v", i, err)
+				return
+			}
+			readers[i] = &messageReader{conn: conn}
+			conn.WriteJSON(Message{Type: string(core.ActionJoinGame), GameID: fmt.Sprintf("game-%d", i%games)})
+
+			// The session token follows the join, so the socket is bound by now
+			if message, err := readers[i].nextOfType(MessageSessionToken); err != nil {
+				t.Errorf("Expected a session token on socket %d, got %+v %v", i, message, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if t.Failed() {
+		return
+	}
+
+	for g := 0; g < games; g++ {
+		gameID := fmt.Sprintf("game-%d", g)
+		if err := wsm.BroadcastToGame(gameID, core.Event{ID: gameID, Type: core.EventChatMessage}); err != nil {
+			t.Fatalf("Failed to broadcast to %s: %v", gameID, err)
+		}
+	}
+
+	// Later players' joins may still be queued ahead of the broadcast
+	for i, reader := range readers {
+		wg.Add(1)
+		go func(i int, reader *messageReader) {
+			defer wg.Done()
+			message, err := reader.nextOfType(string(core.EventChatMessage))
+			if err != nil || message.EventID != fmt.Sprintf("game-%d", i%games) {
+				t.Errorf("Expected socket %d to receive only game-%d's broadcast, got %+v %v", i, i%games, message, err)
+			}
+		}(i, reader)
+	}
+	wg.Wait()
+}
+
+// recordingPresence records presence changes as "+player" and "-player"
+type recordingPresence struct {
+	mutex   sync.Mutex
+	changes []string
+}
+
+func (p *recordingPresence) PlayerConnected(gameID, playerID string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.changes = append(p.changes, "+"+playerID)
+}
+
+func (p *recordingPresence) PlayerDisconnected(gameID, playerID string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.changes = append(p.changes, "-"+playerID)
+}
+
+func (p *recordingPresence) Changes() []string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return append([]string(nil), p.changes...)
+}
+
+// WaitFor waits briefly for the listener to have been told want
+func (p *recordingPresence) WaitFor(want string) []string {
+	deadline := time.Now().Add(time.Second)
+	for fmt.Sprint(p.Changes()) != want && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	return p.Changes()
+}
+
+// TestWebSocketManager_MultipleConnections tests that a player's events reach
+// every socket they hold and that they go offline only with the last one
+func TestWebSocketManager_MultipleConnections(t *testing.T) {
+	presence := &recordingPresence{}
+	wsm := NewWebSocketManager(acceptingHandler{}, NewSessionTokens([]byte("test-secret")))
+	wsm.SetPresenceListener(presence)
+	wsm.Start()
+
+	phone := newTestClient(wsm, "p-1", "game-1", 10)
+	laptop := newTestClient(wsm, "p-1", "game-1", 10)
+	spectator := &Client{ID: "watcher", GameID: "game-1", Role: ConnectionSpectator, Send: make(chan []byte, 10), Hub: wsm}
+	wsm.register <- spectator
+
+	wsm.SendToPlayer("game-1", "p-1", core.Event{Type: core.EventRoleAssigned})
+	wsm.BroadcastToGame("game-1", core.Event{Type: core.EventChatMessage})
+
+	for name, client := range map[string]*Client{"phone": phone, "laptop": laptop} {
+		if messages, _ := drain(client); len(messages) != 2 {
+			t.Errorf("Expected the %s to receive the private and public event, got %d", name, len(messages))
+		}
+	}
+	if messages, _ := drain(spectator); len(messages) != 1 || messages[0].Type != string(core.EventChatMessage) {
+		t.Errorf("Expected the spectator to receive only the public event, got %+v", messages)
+	}
+	if err := wsm.SendToPlayer("game-1", "watcher", core.Event{}); err != ErrPlayerNotFound {
+		t.Errorf("Expected a spectator not to be addressable as a player, got %v", err)
+	}
+
+	wsm.unregister <- phone
+	if err := wsm.SendToPlayer("game-1", "p-1", core.Event{}); err != nil {
+		t.Errorf("Expected the laptop to keep the player online, got %v", err)
+	}
+	if changes := presence.WaitFor("[+p-1]"); fmt.Sprint(changes) != "[+p-1]" {
+		t.Errorf("Expected the player to stay online with one socket left, got %v", changes)
+	}
+
+	wsm.unregister <- laptop
+	wsm.unregister <- spectator
+	if changes := presence.WaitFor("[+p-1 -p-1]"); fmt.Sprint(changes) != "[+p-1 -p-1]" {
+		t.Errorf("Expected one disconnect when the last socket closed, got %v", changes)
+	}
+}
+
+// TestWebSocketManager_PendingJoin tests that a connection waiting to join a
+// game neither receives its public events nor counts as present, and that a
+// refusal unbinds it
+func TestWebSocketManager_PendingJoin(t *testing.T) {
+	presence := &recordingPresence{}
+	wsm := NewWebSocketManager(acceptingHandler{}, NewSessionTokens([]byte("test-secret")))
+	wsm.SetPresenceListener(presence)
+	wsm.Start()
+
+	refused := newTestClient(wsm, "p-1", "", 10)
+	seated := newTestClient(wsm, "p-2", "", 10)
+	wsm.join(refused, "game-1")
+	wsm.join(seated, "game-1")
+
+	wsm.BroadcastToGame("game-1", core.Event{Type: core.EventChatMessage})
+	for _, client := range []*Client{refused, seated} {
+		if messages, _ := drain(client); len(messages) != 0 {
+			t.Errorf("Expected a pending join to miss public events, got %+v", messages)
+		}
+	}
+
+	wsm.SendToPlayer("game-1", "p-1", core.Event{
+		Type:    core.EventActionRejected,
+		Payload: core.EncodePayload(core.ActionRejectedPayload{ActionType: core.ActionJoinGame, Code: core.CodeGameFull}),
+	})
+	if messages, _ := drain(refused); len(messages) != 1 || messages[0].Type != string(core.EventActionRejected) {
+		t.Errorf("Expected the refused player to get the rejection, got %+v", messages)
+	}
+
+	wsm.BroadcastToGame("game-1", core.Event{Type: core.EventPlayerJoined, PlayerID: "p-2"})
+	if messages, _ := drain(seated); len(messages) < 1 || messages[0].Type != string(core.EventPlayerJoined) {
+		t.Errorf("Expected the seated player to hear of their join, got %+v", messages)
+	}
+	if messages, _ := drain(refused); len(messages) != 0 {
+		t.Errorf("Expected the refused player to be unbound, got %+v", messages)
+	}
+	if changes := presence.WaitFor("[+p-2]"); fmt.Sprint(changes) != "[+p-2]" {
+		t.Errorf("Expected only the seated player to come online, got %v", changes)
+	}
+}
+
+// TestWebSocketManager_SendToConnection tests that a message for one
+// connection does not reach the player's other connections
+func TestWebSocketManager_SendToConnection(t *testing.T) {
+	wsm := NewWebSocketManager(acceptingHandler{}, NewSessionTokens([]byte("test-secret")))
+	wsm.Start()
+
+	phone := &Client{ID: "p-1", ConnectionID: "conn-phone", GameID: "game-1", Role: ConnectionPlayer, Send: make(chan []byte, 10), Hub: wsm}
+	laptop := &Client{ID: "p-1", ConnectionID: "conn-laptop", GameID: "game-1", Role: ConnectionPlayer, Send: make(chan []byte, 10), Hub: wsm}
+	wsm.register <- phone
+	wsm.register <- laptop
+
+	if err := wsm.SendToConnection("game-1", "conn-laptop", core.Event{Type: core.EventSyncComplete}); err != nil {
+		t.Fatalf("Expected the connection to be found, got %v", err)
+	}
+	if messages, _ := drain(laptop); len(messages) != 1 {
+		t.Errorf("Expected the laptop to receive the message, got %d", len(messages))
+	}
+	if messages, _ := drain(phone); len(messages) != 0 {
+		t.Errorf("Expected the phone to receive nothing, got %+v", messages)
+	}
+	if err := wsm.SendToConnection("game-1", "conn-gone", core.Event{}); err != ErrClientDisconnected {
+		t.Errorf("Expected an unknown connection to be reported, got %v", err)
+	}
+}
+
+// blockingPresence holds every presence change until release is closed
+type blockingPresence struct {
+	release chan struct{}
+}
+
+func (p blockingPresence) PlayerConnected(gameID, playerID string)    { <-p.release }
+func (p blockingPresence) PlayerDisconnected(gameID, playerID string) { <-p.release }
+
+// TestWebSocketManager_PresenceDoesNotHoldHub tests that a listener that is
+// slow to return, such as one waiting on the supervisor, does not stall
+// deliveries
+func TestWebSocketManager_PresenceDoesNotHoldHub(t *testing.T) {
+	presence := blockingPresence{release: make(chan struct{})}
+	defer close(presence.release)
+	wsm := NewWebSocketManager(acceptingHandler{}, NewSessionTokens([]byte("test-secret")))
+	wsm.SetPresenceListener(presence)
+	wsm.Start()
+
+	client := newTestClient(wsm, "p-1", "game-1", 10)
+	delivered := make(chan error, 1)
+	go func() { delivered <- wsm.SendToPlayer("game-1", "p-1", core.Event{Type: core.EventChatMessage}) }()
+
+	select {
+	case err := <-delivered:
+		if err != nil {
+			t.Fatalf("Expected the delivery to succeed, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the hub to deliver while the presence listener is busy")
+	}
+	if messages, _ := drain(client); len(messages) != 1 {
+		t.Errorf("Expected one message, got %d", len(messages))
+	}
+}
+
+// TestWebSocketManager_CatchUpSendsEventsOnce tests that events delivered
+// live while a client is being caught up are not repeated by the replay
+func TestWebSocketManager_CatchUpSendsEventsOnce(t *testing.T) {
+	wsm := NewWebSocketManager(acceptingHandler{}, NewSessionTokens([]byte("test-secret")))
+	wsm.Start()
+
+	client := newTestClient(wsm, "p-1", "game-1", 20)
+	wsm.resync(client, "p-1", "game-1")
+
+	// Event 3 goes out live before the replay of events 2 and 3
+	wsm.BroadcastToGame("game-1", core.Event{Type: core.EventChatMessage, Sequence: 3})
+	wsm.SendToPlayer("game-1", "p-1", core.Event{Type: core.EventChatMessage, Sequence: 2})
+	wsm.SendToPlayer("game-1", "p-1", core.Event{Type: core.EventChatMessage, Sequence: 3})
+	wsm.SendToPlayer("game-1", "p-1", core.Event{Type: core.EventSyncComplete})
+
+	// After SYNC_COMPLETE every delivery goes through
+	wsm.BroadcastToGame("game-1", core.Event{Type: core.EventChatMessage, Sequence: 3})
+
+	messages, _ := drain(client)
+	var sequences []int
+	for _, message := range messages {
+		sequences = append(sequences, message.Sequence)
+	}
+	if fmt.Sprint(sequences) != "[3 2 0 3]" {
+		t.Errorf("Expected events 3 and 2, SYNC_COMPLETE, then 3 again, got %v", sequences)
+	}
+}
+
+// TestWebSocket_Spectator tests that a spectator socket cannot act
+func TestWebSocket_Spectator(t *testing.T) {
+	handler := &recordingHandler{}
+	_, url := startTestServer(t, handler)
+	conn, err := dial(t, url+"?spectate=game-1")
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+
+	conn.WriteJSON(Message{Type: string(core.ActionSubmitVote), GameID: "game-1", RequestID: "req-1"})
+
+	rejection := readMessage(t, conn)
+	if rejection.Type != string(core.EventActionRejected) || rejection.Payload["code"] != string(core.CodeActionBlocked) || rejection.Payload["request_id"] != "req-1" {
+		t.Errorf("Expected the spectator's vote to be rejected, got %+v", rejection)
+	}
+	if actions := handler.Actions(); len(actions) != 0 {
+		t.Errorf("Expected no action to reach the game, got %+v", actions)
+	}
+}
+
+// TestWebSocket_SystemActions tests that a client cannot send the actions
+// only the server may send
+func TestWebSocket_SystemActions(t *testing.T) {
+	handler := &recordingHandler{}
+	_, url := startTestServer(t, handler)
+	conn, err := dial(t, url)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+
+	for _, actionType := range []core.ActionType{core.ActionPhaseTransition, core.ActionPlayerAway, core.ActionPlayerBack, core.ActionAwayTimeout} {
+		conn.WriteJSON(Message{Type: string(actionType), GameID: "game-1", RequestID: string(actionType)})
+
+		rejection := readMessage(t, conn)
+		if rejection.Type != string(core.EventActionRejected) || rejection.Payload["request_id"] != string(actionType) {
+			t.Errorf("Expected %s to be rejected, got %+v", actionType, rejection)
+		}
+	}
+	if actions := handler.Actions(); len(actions) != 0 {
+		t.Errorf("Expected no action to reach the game, got %+v", actions)
+	}
+}
+
+// TestWebSocket_ReconnectWithBadToken tests that a refused reconnect is
+// answered like any other refused action
+func TestWebSocket_ReconnectWithBadToken(t *testing.T) {
+	handler := &recordingHandler{}
+	_, url := startTestServer(t, handler)
+	conn, err := dial(t, url)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+
+	conn.WriteJSON(Message{
+		Type:      string(core.ActionReconnect),
+		GameID:    "game-1",
+		RequestID: "req-reconnect",
+		Payload:   map[string]interface{}{"session_token": "forged"},
+	})
+
+	rejection := readMessage(t, conn)
+	if rejection.Type != string(core.EventActionRejected) || rejection.Payload["request_id"] != "req-reconnect" {
+		t.Errorf("Expected the reconnect to be rejected, got %+v", rejection)
+	}
+	if actions := handler.Actions(); len(actions) != 0 {
+		t.Errorf("Expected no action to reach the game, got %+v", actions)
+	}
+}
+
+// TestWebSocket_SessionTokenAfterSeat tests that a session token is issued
+// only once the game has seated the player
+func TestWebSocket_SessionTokenAfterSeat(t *testing.T) {
+	_, url := startSeatingServer(t, 1)
+
+	seated, err := dial(t, url)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	seated.WriteJSON(Message{Type: string(core.ActionJoinGame), GameID: "game-1"})
+	reader := &messageReader{conn: seated}
+	if message, err := reader.nextOfType(MessageSessionToken); err != nil || message.Payload["session_token"] == "" {
+		t.Fatalf("Expected the seated player to get a token, got %+v %v", message, err)
+	}
+
+	refused, err := dial(t, url)
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	refused.WriteJSON(Message{Type: string(core.ActionJoinGame), GameID: "game-1"})
+	reader = &messageReader{conn: refused}
+	if message, err := reader.next(); err != nil || message.Type != string(core.EventActionRejected) {
+		t.Fatalf("Expected the join to a full game to be rejected, got %+v %v", message, err)
+	}
+
+	// Nothing else follows the rejection
+	refused.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	if _, data, err := refused.ReadMessage(); err == nil {
+		t.Errorf("Expected no session token for a refused join, got %s", data)
+	}
+}
@@ -2,8 +2,6 @@ package game
 
 import (
 	"fmt"
-	"math/rand"
-	"time"
 
 	"github.com/xjhc/alignment/core"
 )
@@ -11,14 +9,12 @@ import (
 // CorporateMandateManager handles corporate mandate assignment and effects
 type CorporateMandateManager struct {
 	gameState *core.GameState
-	rng       *rand.Rand
 }
 
 // NewCorporateMandateManager creates a new corporate mandate manager
 func NewCorporateMandateManager(gameState *core.GameState) *CorporateMandateManager {
 	return &CorporateMandateManager{
 		gameState: gameState,
-		rng:       rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
 
@@ -96,41 +92,53 @@ func (cmm *CorporateMandateManager) GetAllCorporateMandates() []LocalCorporateMa
 
 // AssignRandomMandate selects and activates a random corporate mandate
 func (cmm *CorporateMandateManager) AssignRandomMandate() *core.CorporateMandate {
-	mandates := cmm.GetAllCorporateMandates()
-	selectedMandate := mandates[cmm.rng.Intn(len(mandates))]
+	return cmm.ActivateMandate(cmm.RandomMandateType())
+}
 
-	return cmm.ActivateMandate(selectedMandate.Type)
+// RandomMandateType picks one of the available corporate mandates
+func (cmm *CorporateMandateManager) RandomMandateType() core.MandateType {
+	mandates := cmm.GetAllCorporateMandates()
+	return mandates[cmm.gameState.Rand("mandate").Intn(len(mandates))].Type
 }
 
 // ActivateMandate activates a specific corporate mandate
 func (cmm *CorporateMandateManager) ActivateMandate(mandateType core.MandateType) *core.CorporateMandate {
-	localMandate := cmm.getMandateDefinition(mandateType)
-	if localMandate == nil {
+	event, err := cmm.CreateMandateActivatedEvent(mandateType)
+	if err != nil {
 		return nil
 	}
 
-	// Convert to game state format
-	mandate := &core.CorporateMandate{
-		Type:        localMandate.Type,
-		Name:        localMandate.Title,
-		Description: localMandate.Description,
-		Effects:     make(map[string]interface{}),
-		IsActive:    true,
-	}
-
-	// Convert typed effects to generic map
-	cmm.convertMandateEffects(localMandate.Effects, mandate.Effects)
-
-	// Set start day
-	localMandate.StartDay = cmm.gameState.DayNumber
+	applyEvents(cmm.gameState, event)
 
-	// Apply immediate effects
-	cmm.applyMandateEffects(*localMandate)
+	return cmm.gameState.CorporateMandate
+}
 
-	// Store in game state
-	cmm.gameState.CorporateMandate = mandate
+// CreateMandateActivatedEvent builds the event announcing a mandate. Its effects,
+// including starting token changes, are applied by core.ApplyEvent so that
+// replaying the event log reproduces them.
+func (cmm *CorporateMandateManager) CreateMandateActivatedEvent(mandateType core.MandateType) (core.Event, error) {
+	localMandate := cmm.getMandateDefinition(mandateType)
+	if localMandate == nil {
+		return core.Event{}, fmt.Errorf("unknown mandate type: %s", mandateType)
+	}
 
-	return mandate
+	// Convert typed effects to generic map
+	effects := make(map[string]interface{})
+	cmm.convertMandateEffects(localMandate.Effects, effects)
+
+	return core.Event{
+		ID:        fmt.Sprintf("mandate_%s_%s", cmm.gameState.ID, mandateType),
+		Type:      core.EventMandateActivated,
+		GameID:    cmm.gameState.ID,
+		Timestamp: getCurrentTime(),
+		Payload: core.EncodePayload(core.MandateActivatedPayload{
+			MandateType:            localMandate.Type,
+			Name:                   localMandate.Title,
+			Description:            localMandate.Description,
+			Effects:                effects,
+			StartingTokensModifier: localMandate.Effects.StartingTokensModifier,
+		}),
+	}, nil
 }
 
 // convertMandateEffects converts typed effects to generic map
@@ -161,27 +169,6 @@ func (cmm *CorporateMandateManager) convertMandateEffects(typedEffects MandateEf
 	}
 }
 
-// applyMandateEffects applies the mandate's effects to the game state
-func (cmm *CorporateMandateManager) applyMandateEffects(mandate LocalCorporateMandate) {
-	effects := mandate.Effects
-
-	// Apply starting token modifier (for new players joining)
-	if effects.StartingTokensModifier != 0 {
-		cmm.gameState.Settings.StartingTokens += effects.StartingTokensModifier
-
-		// Also apply to existing players if this is day 1
-		if mandate.StartDay == 1 {
-			for _, player := range cmm.gameState.Players {
-				if player.IsAlive {
-					player.Tokens += effects.StartingTokensModifier
-				}
-			}
-		}
-	}
-
-	// Effects are already stored in the CorporateMandate through convertMandateEffects
-}
-
 // getMandateDefinition retrieves the definition for a specific mandate type
 func (cmm *CorporateMandateManager) getMandateDefinition(mandateType core.MandateType) *LocalCorporateMandate {
 	mandates := cmm.GetAllCorporateMandates()
@@ -216,10 +203,8 @@ func (cmm *CorporateMandateManager) CheckMiningRestrictions() (successModifier f
 	effects := mandate.Effects
 
 	modifier := 1.0
-	if modifierVal, exists := effects["mining_success_modifier"]; exists {
-		if modFloat, ok := modifierVal.(float64); ok {
-			modifier = modFloat
-		}
+	if modFloat, ok := mandate.EffectFloat("mining_success_modifier"); ok {
+		modifier = modFloat
 	}
 
 	slotsReduced = false
@@ -265,12 +250,9 @@ func (cmm *CorporateMandateManager) GetMilestoneRequirement() int {
 	}
 
 	mandate := cmm.GetActiveMandate()
-	effects := mandate.Effects
 
-	if milestonesVal, exists := effects["milestones_for_abilities"]; exists {
-		if milestones, ok := milestonesVal.(int); ok {
-			return milestones
-		}
+	if milestones, ok := mandate.EffectInt("milestones_for_abilities"); ok {
+		return milestones
 	}
 
 	return 3 // Default requirement
@@ -368,21 +350,17 @@ func (cmm *CorporateMandateManager) GenerateMandateEffectsSummary() []string {
 	summary := make([]string, 0)
 
 	// Document each active effect
-	if modifierVal, exists := effects["starting_tokens_modifier"]; exists {
-		if modifier, ok := modifierVal.(int); ok && modifier != 0 {
-			if modifier > 0 {
-				summary = append(summary, fmt.Sprintf("Enhanced starting resources (+%d tokens)", modifier))
-			} else {
-				summary = append(summary, fmt.Sprintf("Reduced starting resources (%d tokens)", modifier))
-			}
+	if modifier, ok := mandate.EffectInt("starting_tokens_modifier"); ok && modifier != 0 {
+		if modifier > 0 {
+			summary = append(summary, fmt.Sprintf("Enhanced starting resources (+%d tokens)", modifier))
+		} else {
+			summary = append(summary, fmt.Sprintf("Reduced starting resources (%d tokens)", modifier))
 		}
 	}
 
-	if modifierVal, exists := effects["mining_success_modifier"]; exists {
-		if modifier, ok := modifierVal.(float64); ok && modifier > 0 && modifier < 1.0 {
-			reduction := int((1.0 - modifier) * 100)
-			summary = append(summary, fmt.Sprintf("Mining efficiency reduced by %d%%", reduction))
-		}
+	if modifier, ok := mandate.EffectFloat("mining_success_modifier"); ok && modifier > 0 && modifier < 1.0 {
+		reduction := int((1.0 - modifier) * 100)
+		summary = append(summary, fmt.Sprintf("Mining efficiency reduced by %d%%", reduction))
 	}
 
 	if reducedVal, exists := effects["reduced_mining_slots"]; exists {
@@ -403,10 +381,8 @@ func (cmm *CorporateMandateManager) GenerateMandateEffectsSummary() []string {
 		}
 	}
 
-	if milestonesVal, exists := effects["milestones_for_abilities"]; exists {
-		if milestones, ok := milestonesVal.(int); ok && milestones > 3 {
-			summary = append(summary, fmt.Sprintf("Enhanced security clearance required (%d milestones for abilities)", milestones))
-		}
+	if milestones, ok := mandate.EffectInt("milestones_for_abilities"); ok && milestones > 3 {
+		summary = append(summary, fmt.Sprintf("Enhanced security clearance required (%d milestones for abilities)", milestones))
 	}
 
 	if blockVal, exists := effects["block_ai_odd_nights"]; exists {
@@ -431,10 +407,3 @@ func (cmm *CorporateMandateManager) CheckRoleAbilityRequirements(player *core.Pl
 
 	return true, ""
 }
-
-// DeactivateMandate removes the current corporate mandate (if needed for testing or special events)
-func (cmm *CorporateMandateManager) DeactivateMandate() {
-	if cmm.gameState.CorporateMandate != nil {
-		cmm.gameState.CorporateMandate.IsActive = false
-	}
-}
\ No newline at end of file
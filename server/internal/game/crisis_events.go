@@ -2,8 +2,7 @@ package game
 
 import (
 	"fmt"
-	"math/rand"
-	"time"
+	"sort"
 
 	"github.com/xjhc/alignment/core"
 )
@@ -11,14 +10,12 @@ import (
 // CrisisEventManager handles crisis event creation and effects
 type CrisisEventManager struct {
 	gameState *core.GameState
-	rng       *rand.Rand
 }
 
 // NewCrisisEventManager creates a new crisis event manager
 func NewCrisisEventManager(gameState *core.GameState) *CrisisEventManager {
 	return &CrisisEventManager{
 		gameState: gameState,
-		rng:       rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
 
@@ -199,16 +196,43 @@ func (cem *CrisisEventManager) GetAllCrisisEvents() []CrisisEventDefinition {
 // TriggerRandomCrisis selects and triggers a random crisis event
 func (cem *CrisisEventManager) TriggerRandomCrisis() *core.CrisisEvent {
 	allCrises := cem.GetAllCrisisEvents()
-	selectedCrisis := allCrises[cem.rng.Intn(len(allCrises))]
+	selectedCrisis := allCrises[cem.gameState.Rand("crisis").Intn(len(allCrises))]
 
 	return cem.TriggerSpecificCrisis(selectedCrisis.Type)
 }
 
 // TriggerSpecificCrisis creates and applies a specific crisis event
 func (cem *CrisisEventManager) TriggerSpecificCrisis(crisisType CrisisEventType) *core.CrisisEvent {
+	event, err := cem.CreateCrisisTriggeredEvent(crisisType)
+	if err != nil {
+		return nil
+	}
+
+	applyEvents(cem.gameState, event)
+
+	return cem.gameState.CrisisEvent
+}
+
+// NextCrisisType picks the crisis for the coming day, honouring a crisis
+// chosen by the COO's Pivot the night before
+func (cem *CrisisEventManager) NextCrisisType() CrisisEventType {
+	if crisis := cem.gameState.CrisisEvent; crisis != nil {
+		if next, ok := crisis.Effects["next_crisis"].(string); ok && cem.getCrisisDefinition(CrisisEventType(next)) != nil {
+			return CrisisEventType(next)
+		}
+	}
+
+	allCrises := cem.GetAllCrisisEvents()
+	return allCrises[cem.gameState.Rand("crisis").Intn(len(allCrises))].Type
+}
+
+// CreateCrisisTriggeredEvent builds the CRISIS_TRIGGERED event for a crisis
+// without applying it. Random outcomes, such as whose role a database
+// corruption reveals, are resolved here and recorded in the effects.
+func (cem *CrisisEventManager) CreateCrisisTriggeredEvent(crisisType CrisisEventType) (core.Event, error) {
 	definition := cem.getCrisisDefinition(crisisType)
 	if definition == nil {
-		return nil
+		return core.Event{}, fmt.Errorf("unknown crisis type: %s", crisisType)
 	}
 
 	crisis := &core.CrisisEvent{
@@ -217,18 +241,24 @@ func (cem *CrisisEventManager) TriggerSpecificCrisis(crisisType CrisisEventType)
 		Description: definition.Description,
 		Effects:     make(map[string]interface{}),
 	}
-
-	// Apply immediate effects
-	cem.applyCrisisEffects(crisis, definition.Effects)
-
-	// Store in game state
-	cem.gameState.CrisisEvent = crisis
-
-	return crisis
+	cem.buildCrisisEffects(crisis, definition.Effects)
+
+	return core.Event{
+		ID:        fmt.Sprintf("crisis_%s_day_%d", cem.gameState.ID, cem.gameState.DayNumber),
+		Type:      core.EventCrisisTriggered,
+		GameID:    cem.gameState.ID,
+		Timestamp: getCurrentTime(),
+		Payload: core.EncodePayload(core.CrisisTriggeredPayload{
+			CrisisType:  crisis.Type,
+			Title:       crisis.Title,
+			Description: crisis.Description,
+			Effects:     crisis.Effects,
+		}),
+	}, nil
 }
 
-// applyCrisisEffects converts CrisisEffects to the generic effects map and applies immediate effects
-func (cem *CrisisEventManager) applyCrisisEffects(crisis *core.CrisisEvent, effects CrisisEffects) {
+// buildCrisisEffects converts CrisisEffects to the generic effects map and resolves immediate effects
+func (cem *CrisisEventManager) buildCrisisEffects(crisis *core.CrisisEvent, effects CrisisEffects) {
 	// Store all effects in the crisis
 	if effects.SupermajorityRequired {
 		crisis.Effects["supermajority_required"] = true
@@ -274,29 +304,25 @@ func (cem *CrisisEventManager) applyCrisisEffects(crisis *core.CrisisEvent, effe
 
 // revealRandomPlayerRole implements the Database Corruption crisis effect
 func (cem *CrisisEventManager) revealRandomPlayerRole(crisis *core.CrisisEvent) {
-	// Find all alive players with unrevealed roles
+	// Sorted so the pick depends only on the rng, not on map order
 	candidates := make([]string, 0)
 	for playerID, player := range cem.gameState.Players {
-		if player.IsAlive && (player.Role == nil || player.Role.Type == "") {
+		if player.IsAlive && player.Role != nil && player.Role.Type != "" {
 			candidates = append(candidates, playerID)
 		}
 	}
+	sort.Strings(candidates)
 
 	if len(candidates) == 0 {
-		// No unrevealed roles, crisis has no effect
+		// No roles to reveal, crisis has no effect
 		crisis.Effects["reveal_result"] = "no_unrevealed_roles"
 		return
 	}
 
 	// Select random player
-	selectedPlayerID := candidates[cem.rng.Intn(len(candidates))]
+	selectedPlayerID := candidates[cem.gameState.Rand("crisis_role_reveal").Intn(len(candidates))]
 	selectedPlayer := cem.gameState.Players[selectedPlayerID]
 
-	// Generate a role if player doesn't have one assigned yet
-	if selectedPlayer.Role == nil {
-		cem.assignRandomRole(selectedPlayer)
-	}
-
 	// Store the revelation
 	crisis.Effects["revealed_player_id"] = selectedPlayerID
 	crisis.Effects["revealed_role"] = string(selectedPlayer.Role.Type)
@@ -307,66 +333,6 @@ func (cem *CrisisEventManager) revealRandomPlayerRole(crisis *core.CrisisEvent)
 		selectedPlayer.Name, selectedPlayer.Role.Name)
 }
 
-// assignRandomRole assigns a random role to a player (for crisis revelation)
-func (cem *CrisisEventManager) assignRandomRole(player *core.Player) {
-	roles := []core.RoleType{
-		core.RoleCISO, core.RoleCTO, core.RoleCFO, core.RoleCEO, core.RoleCOO, core.RoleEthics, core.RolePlatforms,
-	}
-
-	roleType := roles[cem.rng.Intn(len(roles))]
-
-	player.Role = &core.Role{
-		Type:        roleType,
-		Name:        cem.getRoleName(roleType),
-		Description: cem.getRoleDescription(roleType),
-		IsUnlocked:  player.ProjectMilestones >= 3,
-	}
-}
-
-// getRoleName returns the display name for a role type
-func (cem *CrisisEventManager) getRoleName(roleType core.RoleType) string {
-	switch roleType {
-	case core.RoleCISO:
-		return "Chief Information Security Officer"
-	case core.RoleCTO:
-		return "Chief Technology Officer"
-	case core.RoleCFO:
-		return "Chief Financial Officer"
-	case core.RoleCEO:
-		return "Chief Executive Officer"
-	case core.RoleCOO:
-		return "Chief Operating Officer"
-	case core.RoleEthics:
-		return "VP Ethics & Alignment"
-	case core.RolePlatforms:
-		return "VP Platforms"
-	default:
-		return "Unknown Role"
-	}
-}
-
-// getRoleDescription returns the description for a role type
-func (cem *CrisisEventManager) getRoleDescription(roleType core.RoleType) string {
-	switch roleType {
-	case core.RoleCISO:
-		return "Protects company systems by blocking threatening actions"
-	case core.RoleCTO:
-		return "Manages technical infrastructure and server resources"
-	case core.RoleCFO:
-		return "Controls financial resources and token distribution"
-	case core.RoleCEO:
-		return "Sets strategic direction and manages personnel"
-	case core.RoleCOO:
-		return "Handles operations and crisis management"
-	case core.RoleEthics:
-		return "Ensures ethical compliance and conducts audits"
-	case core.RolePlatforms:
-		return "Maintains platform stability and information systems"
-	default:
-		return "Manages corporate responsibilities"
-	}
-}
-
 // getCrisisDefinition retrieves the definition for a specific crisis type
 func (cem *CrisisEventManager) getCrisisDefinition(crisisType CrisisEventType) *CrisisEventDefinition {
 	allCrises := cem.GetAllCrisisEvents()
@@ -388,11 +354,6 @@ func (cem *CrisisEventManager) GetActiveCrisis() *core.CrisisEvent {
 	return cem.gameState.CrisisEvent
 }
 
-// ClearCrisis removes the current crisis event (when duration expires)
-func (cem *CrisisEventManager) ClearCrisis() {
-	cem.gameState.CrisisEvent = nil
-}
-
 // CheckVotingRequirements applies crisis effects to voting validation
 func (cem *CrisisEventManager) CheckVotingRequirements(voteResults map[string]int, totalVotes int) (bool, string) {
 	if !cem.IsCrisisActive() {
@@ -402,7 +363,7 @@ func (cem *CrisisEventManager) CheckVotingRequirements(voteResults map[string]in
 	crisis := cem.GetActiveCrisis()
 
 	// Check supermajority requirement (Press Leak crisis)
-	if supermajority, exists := crisis.Effects["supermajority_required"]; exists && supermajority.(bool) {
+	if crisis.EffectBool("supermajority_required") {
 		// Find the highest vote count
 		maxVotes := 0
 		for _, votes := range voteResults {
@@ -419,8 +380,8 @@ func (cem *CrisisEventManager) CheckVotingRequirements(voteResults map[string]in
 	}
 
 	// Check voting modifier (Data Privacy Audit)
-	if modifier, exists := crisis.Effects["voting_modifier"]; exists {
-		if modifier.(float64) == 0.0 {
+	if modifier, exists := crisis.EffectFloat("voting_modifier"); exists {
+		if modifier == 0.0 {
 			// All votes count as 1 - this would be handled in vote counting
 			// This check passes but the counting logic needs to respect this
 		}
@@ -438,7 +399,7 @@ func (cem *CrisisEventManager) ApplyMiningModifier(basePoolSize int) int {
 	crisis := cem.GetActiveCrisis()
 
 	// Check reduced mining pool (Service Outage crisis)
-	if reduced, exists := crisis.Effects["reduced_mining_pool"]; exists && reduced.(bool) {
+	if crisis.EffectBool("reduced_mining_pool") {
 		return basePoolSize / 2 // 50% reduction
 	}
 
@@ -453,8 +414,8 @@ func (cem *CrisisEventManager) GetAIEquityBonus() int {
 
 	crisis := cem.GetActiveCrisis()
 
-	if bonus, exists := crisis.Effects["ai_equity_bonus"]; exists {
-		return bonus.(int)
+	if bonus, exists := crisis.EffectInt("ai_equity_bonus"); exists {
+		return bonus
 	}
 
 	return 0
@@ -468,11 +429,7 @@ func (cem *CrisisEventManager) IsAIConversionBlocked() bool {
 
 	crisis := cem.GetActiveCrisis()
 
-	if blocked, exists := crisis.Effects["block_ai_conversions"]; exists {
-		return blocked.(bool)
-	}
-
-	return false
+	return crisis.EffectBool("block_ai_conversions")
 }
 
 // GetMessageLimit returns the message limit imposed by crisis, if any
@@ -483,8 +440,8 @@ func (cem *CrisisEventManager) GetMessageLimit() int {
 
 	crisis := cem.GetActiveCrisis()
 
-	if limit, exists := crisis.Effects["message_limit"]; exists {
-		return limit.(int)
+	if limit, exists := crisis.EffectInt("message_limit"); exists {
+		return limit
 	}
 
 	return -1 // No limit
@@ -498,11 +455,7 @@ func (cem *CrisisEventManager) IsPrivateMessagingBlocked() bool {
 
 	crisis := cem.GetActiveCrisis()
 
-	if blocked, exists := crisis.Effects["no_private_messages"]; exists {
-		return blocked.(bool)
-	}
-
-	return false
+	return crisis.EffectBool("no_private_messages")
 }
 
 // RequiresDoubleElimination checks if crisis requires two eliminations
@@ -513,9 +466,5 @@ func (cem *CrisisEventManager) RequiresDoubleElimination() bool {
 
 	crisis := cem.GetActiveCrisis()
 
-	if double, exists := crisis.Effects["double_eliminations"]; exists {
-		return double.(bool)
-	}
-
-	return false
+	return crisis.EffectBool("double_eliminations")
 }
\ No newline at end of file
@@ -0,0 +1,47 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/xjhc/alignment/core"
+)
+
+// TestCrisisEventManager_CreateCrisisTriggeredEvent tests that crises are built as events without touching state
+func TestCrisisEventManager_CreateCrisisTriggeredEvent(t *testing.T) {
+	state := core.NewGameState("test-game")
+	state.Players["player1"] = &core.Player{ID: "player1", Name: "Alice", IsAlive: true, Role: &core.Role{Type: core.RoleCISO, Name: "Chief Information Security Officer"}}
+	cem := NewCrisisEventManager(state)
+
+	event, err := cem.CreateCrisisTriggeredEvent(CrisisDBCorruption)
+	if err != nil {
+		t.Fatalf("Expected crisis event, got error: %v", err)
+	}
+	if state.CrisisEvent != nil {
+		t.Error("Expected building the event to leave the state untouched")
+	}
+
+	newState := core.ApplyEvent(*state, event)
+	if newState.CrisisEvent == nil || newState.CrisisEvent.Effects["revealed_player_id"] != "player1" {
+		t.Errorf("Expected the reveal to be recorded in the event, got %+v", newState.CrisisEvent)
+	}
+
+	if _, err := cem.CreateCrisisTriggeredEvent("Not A Crisis"); err == nil {
+		t.Error("Expected an unknown crisis to be rejected")
+	}
+}
+
+// TestCrisisEventManager_NextCrisisType tests that a COO's Pivot chooses the next crisis
+func TestCrisisEventManager_NextCrisisType(t *testing.T) {
+	state := core.NewGameState("test-game")
+	state.CrisisEvent = &core.CrisisEvent{Effects: map[string]interface{}{"next_crisis": string(CrisisPressLeak)}}
+	cem := NewCrisisEventManager(state)
+
+	if next := cem.NextCrisisType(); next != CrisisPressLeak {
+		t.Errorf("Expected the pivoted crisis, got %s", next)
+	}
+
+	state.CrisisEvent.Effects["next_crisis"] = "Not A Crisis"
+	if next := cem.NextCrisisType(); cem.getCrisisDefinition(next) == nil {
+		t.Errorf("Expected a known crisis when the pivot is invalid, got %s", next)
+	}
+}
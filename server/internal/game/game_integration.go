@@ -20,13 +20,21 @@ type GameManager struct {
 	RoleAbilityManager    *RoleAbilityManager
 }
 
-// NewGameManager creates a fully integrated game manager
-func NewGameManager(gameID string) *GameManager {
+// NewGameManager creates a fully integrated game manager whose random draws
+// all derive from seed
+func NewGameManager(gameID string, seed int64) *GameManager {
 	gameState := core.NewGameState(gameID)
+	applyEvents(gameState, core.Event{
+		ID:        fmt.Sprintf("game_created_%s", gameID),
+		Type:      core.EventGameCreated,
+		GameID:    gameID,
+		Timestamp: getCurrentTime(),
+		Payload:   core.EncodePayload(core.GameCreatedPayload{Seed: seed}),
+	})
 
 	return &GameManager{
 		GameState:             gameState,
-		AIEngine:              ai.NewRulesEngine(),
+		AIEngine:              ai.NewRulesEngine(seed),
 		CrisisManager:         NewCrisisEventManager(gameState),
 		MandateManager:        NewCorporateMandateManager(gameState),
 		SitrepGenerator:       NewSitrepGenerator(gameState),
@@ -36,26 +44,20 @@ func NewGameManager(gameID string) *GameManager {
 	}
 }
 
-// StartGame initializes a new game with random mandate and AI player
+// StartGame deals roles, seeds the original AI and activates a random mandate
 func (gm *GameManager) StartGame() error {
 	log.Printf("Starting game %s", gm.GameState.ID)
 
-	// Assign a random corporate mandate to modify the game rules
-	mandate := gm.MandateManager.AssignRandomMandate()
-	if mandate != nil {
-		log.Printf("Corporate mandate assigned: %s", mandate.Name)
+	events, err := NewGameStartManager(gm.GameState, DefaultStartDecks()).StartGame()
+	if err != nil {
+		return fmt.Errorf("failed to start game: %w", err)
 	}
 
-	// Apply game start event
-	startEvent := core.Event{
-		ID:        fmt.Sprintf("game_start_%s", gm.GameState.ID),
-		Type:      core.EventGameStarted,
-		GameID:    gm.GameState.ID,
-		Timestamp: getCurrentTime(),
-	}
+	applyEvents(gm.GameState, events...)
 
-	newState := core.ApplyEvent(*gm.GameState, startEvent)
-	*gm.GameState = newState
+	if mandate := gm.MandateManager.GetActiveMandate(); mandate != nil {
+		log.Printf("Corporate mandate assigned: %s", mandate.Name)
+	}
 	return nil
 }
 
@@ -70,27 +72,13 @@ func (gm *GameManager) ProcessDayPhase() error {
 
 	// Check for random crisis events (30% chance per day)
 	if gm.shouldTriggerCrisis() {
-		crisis := gm.CrisisManager.TriggerRandomCrisis()
-		if crisis != nil {
-			log.Printf("Crisis triggered: %s", crisis.Title)
-
-			// Generate crisis event
-			crisisEvent := core.Event{
-				ID:        fmt.Sprintf("crisis_%s_day_%d", gm.GameState.ID, gm.GameState.DayNumber),
-				Type:      core.EventCrisisTriggered,
-				GameID:    gm.GameState.ID,
-				Timestamp: getCurrentTime(),
-				Payload: map[string]interface{}{
-					"crisis_type": crisis.Type,
-					"title":       crisis.Title,
-					"description": crisis.Description,
-					"effects":     crisis.Effects,
-				},
-			}
-
-			newState := core.ApplyEvent(*gm.GameState, crisisEvent)
-			*gm.GameState = newState
+		crisisEvent, err := gm.CrisisManager.CreateCrisisTriggeredEvent(gm.CrisisManager.NextCrisisType())
+		if err != nil {
+			return fmt.Errorf("failed to trigger crisis: %w", err)
 		}
+
+		applyEvents(gm.GameState, crisisEvent)
+		log.Printf("Crisis triggered: %s", gm.GameState.CrisisEvent.Title)
 	}
 
 	// AI makes day phase decisions
@@ -120,20 +108,23 @@ func (gm *GameManager) ProcessNightPhase() error {
 	if gm.isNightActionString(aiDecision.Action) {
 		aiPlayerID := gm.findAIPlayer()
 		if aiPlayerID != "" {
-			nightAction := &core.SubmittedNightAction{
-				PlayerID: aiPlayerID,
-				Type:     aiDecision.Action,
-				TargetID: aiDecision.Target,
-				Payload:  aiDecision.Payload,
+			payload := make(map[string]interface{}, len(aiDecision.Payload)+2)
+			for key, value := range aiDecision.Payload {
+				payload[key] = value
 			}
+			payload["action_type"] = aiDecision.Action
+			payload["target_id"] = aiDecision.Target
 
-			// Store in night actions
-			if gm.GameState.NightActions == nil {
-				gm.GameState.NightActions = make(map[string]*core.SubmittedNightAction)
-			}
-			gm.GameState.NightActions[aiPlayerID] = nightAction
+			applyEvents(gm.GameState, core.Event{
+				ID:        fmt.Sprintf("night_action_%s_day_%d", aiPlayerID, gm.GameState.DayNumber),
+				Type:      core.EventNightActionSubmitted,
+				GameID:    gm.GameState.ID,
+				PlayerID:  aiPlayerID,
+				Timestamp: getCurrentTime(),
+				Payload:   payload,
+			})
 
-			log.Printf("AI submitted night action: %s targeting %s", nightAction.Type, nightAction.TargetID)
+			log.Printf("AI submitted night action: %s targeting %s", aiDecision.Action, aiDecision.Target)
 		}
 	}
 
@@ -142,10 +133,7 @@ func (gm *GameManager) ProcessNightPhase() error {
 	log.Printf("Night resolution generated %d events", len(events))
 
 	// Apply all resolution events
-	for _, event := range events {
-		newState := core.ApplyEvent(*gm.GameState, event)
-		*gm.GameState = newState
-	}
+	applyEvents(gm.GameState, events...)
 
 	return nil
 }
@@ -221,17 +209,9 @@ func (gm *GameManager) ProcessRoleAbility(playerID string, abilityType string, t
 		return fmt.Errorf("failed to use role ability: %w", err)
 	}
 
-	// Apply resulting events
-	for _, event := range result.PublicEvents {
-		newState := core.ApplyEvent(*gm.GameState, event)
-		*gm.GameState = newState
-	}
-
-	// Private events would be sent only to AI faction players
-	for _, event := range result.PrivateEvents {
-		newState := core.ApplyEvent(*gm.GameState, event)
-		*gm.GameState = newState
-	}
+	// Apply resulting events; private events would be sent only to AI faction players
+	applyEvents(gm.GameState, result.PublicEvents...)
+	applyEvents(gm.GameState, result.PrivateEvents...)
 
 	log.Printf("Player %s used ability %s, generated %d public events and %d private events",
 		playerID, abilityType, len(result.PublicEvents), len(result.PrivateEvents))
@@ -337,12 +317,18 @@ func (gm *GameManager) addDemoPlayers() {
 			},
 		}
 
-		newState := core.ApplyEvent(*gm.GameState, joinEvent)
-		*gm.GameState = newState
+		applyEvents(gm.GameState, joinEvent)
 
 		// Make one player AI-aligned for demo
 		if i == 1 { // Bob Smith becomes AI
-			gm.GameState.Players[p.ID].Alignment = "ALIGNED"
+			applyEvents(gm.GameState, core.Event{
+				ID:        fmt.Sprintf("aligned_%s", p.ID),
+				Type:      core.EventPlayerAligned,
+				GameID:    gm.GameState.ID,
+				PlayerID:  p.ID,
+				Timestamp: getCurrentTime(),
+				Payload:   make(map[string]interface{}),
+			})
 		}
 	}
 }
@@ -0,0 +1,170 @@
+package game
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/xjhc/alignment/core"
+)
+
+// RoleCard is a role that can be dealt to a player at game start
+type RoleCard struct {
+	Type        core.RoleType
+	Name        string
+	Description string
+}
+
+// KPICard is a secret personal objective that can be dealt to a human player
+type KPICard struct {
+	Type        core.KPIType
+	Description string
+	Target      int
+	Reward      string
+}
+
+// StartDecks configures what is dealt when a game starts
+type StartDecks struct {
+	Roles       []RoleCard // Dealt without replacement, extra players become interns
+	KPIs        []KPICard  // Each human gets a unique KPI while the deck lasts
+	OriginalAIs int        // Number of players seeded into the AI faction
+}
+
+// DefaultStartDecks returns the roles and KPIs described in the game design document
+func DefaultStartDecks() StartDecks {
+	return StartDecks{
+		Roles: []RoleCard{
+			{core.RoleCEO, "Chief Executive Officer", "Can initiate a Performance Review, forcing a player to work on Project Milestones."},
+			{core.RoleCTO, "Chief Technology Officer", "Can overclock servers to mine for themselves and another player."},
+			{core.RoleCISO, "Chief Information Security Officer", "Can isolate a node, blocking a player from all actions for the night."},
+			{core.RoleCOO, "Chief Operating Officer", "Can pivot operations, choosing the next crisis."},
+			{core.RoleCFO, "Chief Financial Officer", "Can reallocate budget, moving a Token from one player to another."},
+			{core.RoleEthics, "VP, Ethics & Alignment", "Can run an audit on a player."},
+			{core.RolePlatforms, "VP, Platforms", "Can deploy a hotfix, redacting one section of the next SITREP."},
+		},
+		KPIs: []KPICard{
+			{core.KPICapitalist, "End the game with more Tokens than any other single player.", 1, "Gain 2 extra votes in the final deactivation tally."},
+			{core.KPIGuardian, "Ensure the player with the CISO role survives to Day 4.", 4, "Learn the target of one player's night action."},
+			{core.KPIInquisitor, "Correctly vote for the player who is deactivated three times.", 3, "Your final vote counts for double."},
+			{core.KPISuccessionPlanner, "Win only if the game ends with exactly two Humans remaining (including you) and zero Aligned players.", 1, "Alternate win condition."},
+			{core.KPIScapegoat, "Win only if you are deactivated by a unanimous vote.", 1, "Alternate win condition."},
+		},
+		OriginalAIs: 1,
+	}
+}
+
+// internCard fills seats once the role deck runs out
+var internCard = RoleCard{core.RoleIntern, "Intern", "No special ability. Keep your head down and ship."}
+
+// GameStartManager builds the events that take a game out of the lobby
+type GameStartManager struct {
+	gameState *core.GameState
+	decks     StartDecks
+}
+
+// NewGameStartManager creates a new game start manager
+func NewGameStartManager(gameState *core.GameState, decks StartDecks) *GameStartManager {
+	return &GameStartManager{
+		gameState: gameState,
+		decks:     decks,
+	}
+}
+
+// StartGame returns GAME_STARTED followed by the mandate and one private
+// ROLE_ASSIGNED per player. Nothing is applied here; every random choice is
+// captured in the events so replaying them reproduces the same game.
+func (gsm *GameStartManager) StartGame() ([]core.Event, error) {
+	if len(gsm.gameState.Players) == 0 {
+		return nil, fmt.Errorf("cannot start game without players")
+	}
+
+	now := getCurrentTime()
+	events := []core.Event{{
+		ID:        fmt.Sprintf("game_started_%s", gsm.gameState.ID),
+		Type:      core.EventGameStarted,
+		GameID:    gsm.gameState.ID,
+		Timestamp: now,
+		Payload:   make(map[string]interface{}),
+	}}
+
+	mandateManager := NewCorporateMandateManager(gsm.gameState)
+	mandateEvent, err := mandateManager.CreateMandateActivatedEvent(mandateManager.RandomMandateType())
+	if err != nil {
+		return nil, fmt.Errorf("failed to activate mandate: %w", err)
+	}
+	mandateEvent.Timestamp = now
+	events = append(events, mandateEvent)
+
+	return append(events, gsm.dealRoles(now)...), nil
+}
+
+// dealRoles assigns a role to every player, seeds the original AI(s) and
+// deals a KPI to each remaining human
+func (gsm *GameStartManager) dealRoles(now time.Time) []core.Event {
+	// Sort first so the deal depends only on the game seed, not on map order
+	playerIDs := make([]string, 0, len(gsm.gameState.Players))
+	for playerID := range gsm.gameState.Players {
+		playerIDs = append(playerIDs, playerID)
+	}
+	sort.Strings(playerIDs)
+
+	rng := gsm.gameState.Rand("deal")
+
+	roles := make([]RoleCard, len(gsm.decks.Roles))
+	copy(roles, gsm.decks.Roles)
+	rng.Shuffle(len(roles), func(i, j int) { roles[i], roles[j] = roles[j], roles[i] })
+
+	kpis := make([]KPICard, len(gsm.decks.KPIs))
+	copy(kpis, gsm.decks.KPIs)
+	rng.Shuffle(len(kpis), func(i, j int) { kpis[i], kpis[j] = kpis[j], kpis[i] })
+
+	// Always leave at least one human
+	originalAIs := gsm.decks.OriginalAIs
+	if originalAIs >= len(playerIDs) {
+		originalAIs = len(playerIDs) - 1
+	}
+	if originalAIs < 0 {
+		originalAIs = 0
+	}
+	aligned := make(map[string]bool)
+	for _, index := range rng.Perm(len(playerIDs))[:originalAIs] {
+		aligned[playerIDs[index]] = true
+	}
+
+	events := make([]core.Event, 0, len(playerIDs))
+	for i, playerID := range playerIDs {
+		role := internCard
+		if i < len(roles) {
+			role = roles[i]
+		}
+
+		payload := core.RoleAssignedPayload{
+			RoleType:        role.Type,
+			RoleName:        role.Name,
+			RoleDescription: role.Description,
+			Alignment:       "HUMAN",
+		}
+
+		if aligned[playerID] {
+			payload.Alignment = "ALIGNED"
+		} else if len(kpis) > 0 {
+			kpi := kpis[0]
+			kpis = kpis[1:]
+			payload.KPIType = kpi.Type
+			payload.KPIDescription = kpi.Description
+			payload.KPITarget = kpi.Target
+			payload.KPIReward = kpi.Reward
+		}
+
+		events = append(events, core.Event{
+			ID:        fmt.Sprintf("role_assigned_%s_%s", gsm.gameState.ID, playerID),
+			Type:      core.EventRoleAssigned,
+			GameID:    gsm.gameState.ID,
+			PlayerID:  playerID,
+			Timestamp: now,
+			Payload:   core.EncodePayload(payload),
+		})
+	}
+
+	return events
+}
@@ -0,0 +1,140 @@
+package game
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/xjhc/alignment/core"
+)
+
+func newStartTestState(players int) *core.GameState {
+	gameState := core.NewGameState("test-game")
+	for i := 0; i < players; i++ {
+		playerID := fmt.Sprintf("player-%d", i)
+		gameState.Players[playerID] = &core.Player{ID: playerID, IsAlive: true, Alignment: "HUMAN", Tokens: gameState.Settings.StartingTokens}
+	}
+	return gameState
+}
+
+func TestGameStartManager_DealsRolesAndKPIs(t *testing.T) {
+	gameState := newStartTestState(9)
+	decks := DefaultStartDecks()
+	decks.OriginalAIs = 2
+
+	events, err := NewGameStartManager(gameState, decks).StartGame()
+	if err != nil {
+		t.Fatalf("Failed to start game: %v", err)
+	}
+	if len(events) != 11 {
+		t.Fatalf("Expected 11 events, got %d", len(events))
+	}
+
+	for _, event := range events {
+		*gameState = core.ApplyEvent(*gameState, event)
+	}
+
+	aligned, interns := 0, 0
+	kpis := make(map[core.KPIType]bool)
+	for playerID, player := range gameState.Players {
+		if player.Role == nil {
+			t.Fatalf("Expected %s to have a role", playerID)
+		}
+		if player.Role.Type == core.RoleIntern {
+			interns++
+		}
+
+		if player.Alignment == "ALIGNED" {
+			aligned++
+			if player.PersonalKPI != nil {
+				t.Errorf("Expected original AI %s to have no KPI", playerID)
+			}
+			continue
+		}
+
+		// Five KPIs for seven humans: the last two go without
+		if player.PersonalKPI != nil {
+			if kpis[player.PersonalKPI.Type] {
+				t.Errorf("Expected unique KPIs, %s was dealt twice", player.PersonalKPI.Type)
+			}
+			kpis[player.PersonalKPI.Type] = true
+		}
+	}
+
+	if aligned != 2 {
+		t.Errorf("Expected 2 original AIs, got %d", aligned)
+	}
+	if interns != 2 {
+		t.Errorf("Expected 2 interns once the role deck runs out, got %d", interns)
+	}
+	if len(kpis) != len(decks.KPIs) {
+		t.Errorf("Expected all %d KPIs to be dealt, got %d", len(decks.KPIs), len(kpis))
+	}
+	if gameState.CorporateMandate == nil || !gameState.CorporateMandate.IsActive {
+		t.Error("Expected a corporate mandate to be active")
+	}
+	if gameState.Phase.Type != core.PhaseSitrep {
+		t.Errorf("Expected game to move to SITREP, got %s", gameState.Phase.Type)
+	}
+}
+
+func TestGameStartManager_SameSeedSameDeal(t *testing.T) {
+	deal := func(seed int64) []core.Event {
+		gameState := newStartTestState(6)
+		gameState.Seed = seed
+		events, err := NewGameStartManager(gameState, DefaultStartDecks()).StartGame()
+		if err != nil {
+			t.Fatalf("Failed to start game: %v", err)
+		}
+		return events
+	}
+
+	first, second := deal(42), deal(42)
+	for i := range first {
+		if fmt.Sprint(first[i].Payload) != fmt.Sprint(second[i].Payload) {
+			t.Errorf("Expected event %d to match, got %v and %v", i, first[i].Payload, second[i].Payload)
+		}
+	}
+
+	if fmt.Sprint(eventPayloads(first)) == fmt.Sprint(eventPayloads(deal(43))) {
+		t.Error("Expected a different seed to deal a different game")
+	}
+}
+
+// eventPayloads lists the payloads of events, in order
+func eventPayloads(events []core.Event) []map[string]interface{} {
+	payloads := make([]map[string]interface{}, len(events))
+	for i, event := range events {
+		payloads[i] = event.Payload
+	}
+	return payloads
+}
+
+func TestGameStartManager_MandateTokensSurviveReplay(t *testing.T) {
+	gameState := newStartTestState(4)
+	manager := NewCorporateMandateManager(gameState)
+
+	event, err := manager.CreateMandateActivatedEvent(core.MandateAggressiveGrowth)
+	if err != nil {
+		t.Fatalf("Failed to create mandate event: %v", err)
+	}
+
+	// Round-trip the payload as it would be read back from storage
+	data, version, err := core.EncodeEventPayload(event)
+	if err != nil {
+		t.Fatalf("Failed to encode payload: %v", err)
+	}
+	event.Payload, err = core.DecodeEventPayload(event.Type, data, version)
+	if err != nil {
+		t.Fatalf("Failed to decode payload: %v", err)
+	}
+	*gameState = core.ApplyEvent(*gameState, event)
+
+	if gameState.Players["player-0"].Tokens != gameState.Settings.StartingTokens || gameState.Settings.StartingTokens != 2 {
+		t.Errorf("Expected mandate to raise starting tokens to 2, got %d", gameState.Players["player-0"].Tokens)
+	}
+
+	modifier, reduced := manager.CheckMiningRestrictions()
+	if modifier != 0.75 || !reduced {
+		t.Errorf("Expected mining restrictions after a round trip, got %v %v", modifier, reduced)
+	}
+}
@@ -117,12 +117,8 @@ func (mm *MiningManager) calculateLiquidityPool() int {
 	baseSlots := livingHumans / 2
 
 	// Apply crisis event modifiers if any
-	if mm.gameState.CrisisEvent != nil && mm.gameState.CrisisEvent.Effects != nil {
-		if modifier, exists := mm.gameState.CrisisEvent.Effects["mining_slots_modifier"]; exists {
-			if modValue, ok := modifier.(int); ok {
-				baseSlots += modValue
-			}
-		}
+	if modifier, exists := mm.gameState.CrisisEvent.EffectInt("mining_slots_modifier"); exists {
+		baseSlots += modifier
 	}
 
 	// Ensure minimum of 1 slot if there are living players
@@ -191,9 +187,10 @@ func (mm *MiningManager) UpdatePlayerTokens(result *MiningResult) []core.Event {
 	var events []core.Event
 
 	// Award tokens to successful mining targets
-	for minerID, targetID := range result.SuccessfulMines {
+	for _, minerID := range sortedKeys(result.SuccessfulMines) {
+		targetID := result.SuccessfulMines[minerID]
 		event := core.Event{
-			ID:        fmt.Sprintf("mining_success_%s_%s", minerID, targetID),
+			ID:        fmt.Sprintf("mining_success_%d_%s_%s", mm.gameState.DayNumber, minerID, targetID),
 			Type:      core.EventMiningSuccessful,
 			GameID:    mm.gameState.ID,
 			PlayerID:  targetID, // Token goes to target
@@ -217,54 +214,44 @@ func (mm *MiningManager) UpdatePlayerTokens(result *MiningResult) []core.Event {
 func (mm *MiningManager) ValidateMiningRequest(minerID, targetID string) error {
 	// Check selfless mining rule
 	if minerID == targetID {
-		return fmt.Errorf("cannot mine for yourself - mining must be selfless")
+		return core.ActionErrorf(core.CodeInvalidTarget, "target_id", "cannot mine for yourself - mining must be selfless")
 	}
 
 	// Check if miner exists and is alive
 	miner, exists := mm.gameState.Players[minerID]
 	if !exists {
-		return fmt.Errorf("miner player not found")
+		return core.ActionErrorf(core.CodePlayerNotFound, "", "miner player not found")
 	}
 	if !miner.IsAlive {
-		return fmt.Errorf("dead players cannot mine")
+		return core.ActionErrorf(core.CodePlayerEliminated, "", "dead players cannot mine")
 	}
 
 	// Check if target exists and is alive
 	target, exists := mm.gameState.Players[targetID]
 	if !exists {
-		return fmt.Errorf("target player not found")
+		return core.ActionErrorf(core.CodeInvalidTarget, "target_id", "target player not found")
 	}
 	if !target.IsAlive {
-		return fmt.Errorf("cannot mine for dead players")
+		return core.ActionErrorf(core.CodeInvalidTarget, "target_id", "cannot mine for dead players")
 	}
 
 	// Check if it's night phase
 	if mm.gameState.Phase.Type != core.PhaseNight {
-		return fmt.Errorf("mining actions can only be submitted during night phase")
+		return core.ActionErrorf(core.CodeWrongPhase, "", "mining actions can only be submitted during night phase")
 	}
 
 	return nil
 }
 
-// HandleMineAction processes a single mining action and returns events
+// HandleMineAction processes a single mining action and returns events. An
+// invalid request is returned as an error for the actor to reject rather than
+// recorded in the event stream.
 func (mm *MiningManager) HandleMineAction(action core.Action) ([]core.Event, error) {
 	targetID, _ := action.Payload["target_id"].(string)
 	
 	// Validate the mining request
 	if err := mm.ValidateMiningRequest(action.PlayerID, targetID); err != nil {
-		// Create a failed mining event
-		event := core.Event{
-			ID:        fmt.Sprintf("mining_failed_%s_%d", action.PlayerID, getCurrentTime().UnixNano()),
-			Type:      core.EventMiningFailed,
-			GameID:    mm.gameState.ID,
-			PlayerID:  action.PlayerID,
-			Timestamp: getCurrentTime(),
-			Payload: map[string]interface{}{
-				"target_id": targetID,
-				"reason":    err.Error(),
-			},
-		}
-		return []core.Event{event}, nil
+		return nil, err
 	}
 	
 	// For single mining actions, we create a successful mining event
@@ -107,6 +107,9 @@ func TestMiningManager_ValidateMiningRequest(t *testing.T) {
 	if err == nil || err.Error() != "mining actions can only be submitted during night phase" {
 		t.Errorf("Expected phase error, got: %v", err)
 	}
+	if code := core.AsActionError(err).Code; code != core.CodeWrongPhase {
+		t.Errorf("Expected %s, got %s", core.CodeWrongPhase, code)
+	}
 }
 
 func TestMiningManager_CalculateLiquidityPool(t *testing.T) {
@@ -336,4 +339,10 @@ func TestMiningManager_UpdatePlayerTokens(t *testing.T) {
 	if event.Payload["amount"] != 1 {
 		t.Errorf("Expected amount to be 1, got %v", event.Payload["amount"])
 	}
+
+	// The same mine on a later night is a different event
+	gameState.DayNumber++
+	if next := miningManager.UpdatePlayerTokens(result); next[0].ID == event.ID {
+		t.Errorf("Expected a new event ID on the next night, got %s again", event.ID)
+	}
 }
@@ -10,20 +10,33 @@ import (
 // NightResolutionManager handles the resolution of all night actions
 type NightResolutionManager struct {
 	gameState *core.GameState
+
+	// Blocks and protections decided earlier in the current resolution. They
+	// reach the game state only through the returned events.
+	blocked   map[string]bool
+	protected map[string]bool
 }
 
 // NewNightResolutionManager creates a new night resolution manager
 func NewNightResolutionManager(gameState *core.GameState) *NightResolutionManager {
 	return &NightResolutionManager{
 		gameState: gameState,
+		blocked:   make(map[string]bool),
+		protected: make(map[string]bool),
 	}
 }
 
-// ResolveNightActions processes all submitted night actions in precedence order
+// ResolveNightActions processes all submitted night actions in precedence order.
+// The game state is left untouched; applying the returned events carries out
+// the night, and the closing NIGHT_ACTIONS_RESOLVED clears the submissions.
 func (nrm *NightResolutionManager) ResolveNightActions() []core.Event {
+	nrm.blocked = make(map[string]bool)
+	nrm.protected = make(map[string]bool)
+
 	if nrm.gameState.NightActions == nil || len(nrm.gameState.NightActions) == 0 {
+		// The summary still ends the night, clearing blocks and protections
 		log.Printf("No night actions to resolve")
-		return []core.Event{}
+		return []core.Event{nrm.createNightResolutionSummary(nil)}
 	}
 
 	var allEvents []core.Event
@@ -48,46 +61,37 @@ func (nrm *NightResolutionManager) ResolveNightActions() []core.Event {
 	summaryEvent := nrm.createNightResolutionSummary(allEvents)
 	allEvents = append(allEvents, summaryEvent)
 
-	// Clear night actions for next night
-	nrm.gameState.NightActions = make(map[string]*core.SubmittedNightAction)
-
 	return allEvents
 }
 
 // resolveBlockActions handles all blocking actions first
 func (nrm *NightResolutionManager) resolveBlockActions() []core.Event {
 	var events []core.Event
-	blockedPlayers := make(map[string]bool)
 
-	for playerID, action := range nrm.gameState.NightActions {
-		if action.Type == "BLOCK" {
+	for _, playerID := range sortedKeys(nrm.gameState.NightActions) {
+		if action := nrm.gameState.NightActions[playerID]; action.Type == "BLOCK" {
 			targetID := action.TargetID
 
 			// Validate block action
 			if nrm.canPlayerUseAbility(playerID, "BLOCK") && targetID != "" {
-				blockedPlayers[targetID] = true
+				nrm.blocked[targetID] = true
 
 				event := core.Event{
-					ID:        fmt.Sprintf("night_block_%s_%s", playerID, targetID),
+					ID:        fmt.Sprintf("night_block_%d_%s_%s", nrm.gameState.DayNumber, playerID, targetID),
 					Type:      core.EventPlayerBlocked,
 					GameID:    nrm.gameState.ID,
 					PlayerID:  targetID, // The blocked player
 					Timestamp: getCurrentTime(),
-					Payload: map[string]interface{}{
-						"blocker_id": playerID,
-						"target_id":  targetID,
-					},
+					Payload: core.EncodePayload(core.PlayerBlockedPayload{
+						BlockedBy: playerID,
+						TargetID:  targetID,
+					}),
 				}
 				events = append(events, event)
 			}
 		}
 	}
 
-	// Store blocked players for use in other resolution phases
-	if len(blockedPlayers) > 0 {
-		nrm.gameState.BlockedPlayersTonight = blockedPlayers
-	}
-
 	return events
 }
 
@@ -96,8 +100,8 @@ func (nrm *NightResolutionManager) resolveMiningActions() []core.Event {
 	var miningRequests []MiningRequest
 
 	// Collect all mining requests from non-blocked players
-	for playerID, action := range nrm.gameState.NightActions {
-		if action.Type == "MINE" {
+	for _, playerID := range sortedKeys(nrm.gameState.NightActions) {
+		if action := nrm.gameState.NightActions[playerID]; action.Type == "MINE" {
 			// Check if player is blocked
 			if nrm.isPlayerBlocked(playerID) {
 				continue // Blocked players cannot mine
@@ -126,7 +130,9 @@ func (nrm *NightResolutionManager) resolveRoleAbilities() []core.Event {
 
 	roleAbilityManager := NewRoleAbilityManager(nrm.gameState)
 
-	for playerID, action := range nrm.gameState.NightActions {
+	for _, playerID := range sortedKeys(nrm.gameState.NightActions) {
+		action := nrm.gameState.NightActions[playerID]
+
 		// Skip if player is blocked
 		if nrm.isPlayerBlocked(playerID) {
 			continue
@@ -201,27 +207,29 @@ func (nrm *NightResolutionManager) resolveRoleAbilities() []core.Event {
 	return events
 }
 
-// resolveOtherNightActions handles investigate, protect, and convert actions
+// resolveOtherNightActions handles protect, investigate, and convert actions,
+// in that order, so a protection always covers the conversions of its night
 func (nrm *NightResolutionManager) resolveOtherNightActions() []core.Event {
 	var events []core.Event
 
-	for playerID, action := range nrm.gameState.NightActions {
-		// Skip if player is blocked
-		if nrm.isPlayerBlocked(playerID) {
-			continue
-		}
+	for _, actionType := range []string{"PROTECT", "INVESTIGATE", "CONVERT"} {
+		for _, playerID := range sortedKeys(nrm.gameState.NightActions) {
+			action := nrm.gameState.NightActions[playerID]
 
-		switch action.Type {
-		case "INVESTIGATE":
-			if nrm.canPlayerUseAbility(playerID, "INVESTIGATE") {
-				events = append(events, nrm.resolveInvestigateAction(playerID, action))
+			// Skip other actions and blocked players
+			if action.Type != actionType || nrm.isPlayerBlocked(playerID) {
+				continue
 			}
-		case "PROTECT":
-			if nrm.canPlayerUseAbility(playerID, "PROTECT") {
-				events = append(events, nrm.resolveProtectAction(playerID, action))
+			if !nrm.canPlayerUseAbility(playerID, actionType) {
+				continue
 			}
-		case "CONVERT":
-			if nrm.canPlayerUseAbility(playerID, "CONVERT") {
+
+			switch actionType {
+			case "PROTECT":
+				events = append(events, nrm.resolveProtectAction(playerID, action))
+			case "INVESTIGATE":
+				events = append(events, nrm.resolveInvestigateAction(playerID, action))
+			case "CONVERT":
 				events = append(events, nrm.resolveConvertAction(playerID, action)...)
 			}
 		}
@@ -244,18 +252,18 @@ func (nrm *NightResolutionManager) resolveInvestigateAction(playerID string, act
 
 	// Reveal target's alignment to investigator
 	event := core.Event{
-		ID:        fmt.Sprintf("night_investigate_%s_%s", playerID, targetID),
+		ID:        fmt.Sprintf("night_investigate_%d_%s_%s", nrm.gameState.DayNumber, playerID, targetID),
 		Type:      core.EventPlayerInvestigated,
 		GameID:    nrm.gameState.ID,
 		PlayerID:  playerID, // Information goes to investigator
 		Timestamp: getCurrentTime(),
-		Payload: map[string]interface{}{
-			"investigator_id": playerID,
-			"target_id":       targetID,
-			"target_name":     target.Name,
-			"alignment":       target.Alignment,
-			"role":            roleType,
-		},
+		Payload: core.EncodePayload(core.PlayerInvestigatedPayload{
+			InvestigatorID: playerID,
+			TargetID:       targetID,
+			TargetName:     target.Name,
+			Alignment:      target.Alignment,
+			Role:           roleType,
+		}),
 	}
 
 	return event
@@ -265,22 +273,19 @@ func (nrm *NightResolutionManager) resolveInvestigateAction(playerID string, act
 func (nrm *NightResolutionManager) resolveProtectAction(playerID string, action *core.SubmittedNightAction) core.Event {
 	targetID := action.TargetID
 
-	// Mark player as protected for tonight
-	if nrm.gameState.ProtectedPlayersTonight == nil {
-		nrm.gameState.ProtectedPlayersTonight = make(map[string]bool)
-	}
-	nrm.gameState.ProtectedPlayersTonight[targetID] = true
+	// Mark player as protected for the rest of the resolution
+	nrm.protected[targetID] = true
 
 	event := core.Event{
-		ID:        fmt.Sprintf("night_protect_%s_%s", playerID, targetID),
+		ID:        fmt.Sprintf("night_protect_%d_%s_%s", nrm.gameState.DayNumber, playerID, targetID),
 		Type:      core.EventPlayerProtected,
 		GameID:    nrm.gameState.ID,
 		PlayerID:  targetID, // Protected player
 		Timestamp: getCurrentTime(),
-		Payload: map[string]interface{}{
-			"protector_id": playerID,
-			"target_id":    targetID,
-		},
+		Payload: core.EncodePayload(core.PlayerProtectedPayload{
+			ProtectedBy: playerID,
+			TargetID:    targetID,
+		}),
 	}
 
 	return event
@@ -295,14 +300,14 @@ func (nrm *NightResolutionManager) resolveConvertAction(playerID string, action
 	if nrm.isPlayerProtected(targetID) {
 		// Conversion blocked by protection
 		return []core.Event{{
-			ID:        fmt.Sprintf("night_convert_blocked_%s_%s", playerID, targetID),
+			ID:        fmt.Sprintf("night_convert_blocked_%d_%s_%s", nrm.gameState.DayNumber, playerID, targetID),
 			Type:      core.EventSystemMessage,
 			GameID:    nrm.gameState.ID,
 			PlayerID:  playerID,
 			Timestamp: getCurrentTime(),
-			Payload: map[string]interface{}{
-				"message": "Conversion attempt blocked by protection",
-			},
+			Payload: core.EncodePayload(core.SystemMessagePayload{
+				Message: "Conversion attempt blocked by protection",
+			}),
 		}}
 	}
 
@@ -311,49 +316,45 @@ func (nrm *NightResolutionManager) resolveConvertAction(playerID string, action
 	if player.AIEquity > target.Tokens {
 		// Successful conversion
 		return []core.Event{{
-			ID:        fmt.Sprintf("night_convert_success_%s_%s", playerID, targetID),
+			ID:        fmt.Sprintf("night_convert_success_%d_%s_%s", nrm.gameState.DayNumber, playerID, targetID),
 			Type:      core.EventAIConversionSuccess,
 			GameID:    nrm.gameState.ID,
 			PlayerID:  targetID,
 			Timestamp: getCurrentTime(),
-			Payload: map[string]interface{}{
-				"converter_id": playerID,
-				"target_id":    targetID,
-			},
+			Payload: core.EncodePayload(core.AIConversionSuccessPayload{
+				ConverterID: playerID,
+				TargetID:    targetID,
+			}),
 		}}
 	} else {
 		// System shock - proves target is human
 		return []core.Event{{
-			ID:        fmt.Sprintf("night_convert_shock_%s_%s", playerID, targetID),
+			ID:        fmt.Sprintf("night_convert_shock_%d_%s_%s", nrm.gameState.DayNumber, playerID, targetID),
 			Type:      core.EventPlayerShocked,
 			GameID:    nrm.gameState.ID,
 			PlayerID:  targetID,
 			Timestamp: getCurrentTime(),
-			Payload: map[string]interface{}{
-				"converter_id": playerID,
-				"target_id":    targetID,
-				"reason":       "System shock from failed conversion",
-			},
+			Payload: core.EncodePayload(core.PlayerShockedPayload{
+				TargetID: targetID,
+				Reason:   "System shock from failed conversion",
+			}),
 		}}
 	}
 }
 
 // createNightResolutionSummary creates a summary event of all night actions
 func (nrm *NightResolutionManager) createNightResolutionSummary(resolvedEvents []core.Event) core.Event {
-	// Count different types of actions
-	summary := map[string]interface{}{
-		"total_actions":   len(nrm.gameState.NightActions),
-		"resolved_events": len(resolvedEvents),
-		"phase_end":       true,
-	}
-
 	return core.Event{
 		ID:        fmt.Sprintf("night_resolution_summary_%d", nrm.gameState.DayNumber),
 		Type:      core.EventNightActionsResolved,
 		GameID:    nrm.gameState.ID,
 		PlayerID:  "",
 		Timestamp: getCurrentTime(),
-		Payload:   summary,
+		Payload: core.EncodePayload(core.NightActionsResolvedPayload{
+			TotalActions:   len(nrm.gameState.NightActions),
+			ResolvedEvents: len(resolvedEvents),
+			PhaseEnd:       true,
+		}),
 	}
 }
 
@@ -369,16 +370,12 @@ func (nrm *NightResolutionManager) canPlayerUseAbility(playerID, abilityType str
 	return player.ProjectMilestones >= 3
 }
 
+// isPlayerBlocked covers blocks applied during the night, such as an
+// isolated node, and blocks resolved earlier in this resolution
 func (nrm *NightResolutionManager) isPlayerBlocked(playerID string) bool {
-	if nrm.gameState.BlockedPlayersTonight == nil {
-		return false
-	}
-	return nrm.gameState.BlockedPlayersTonight[playerID]
+	return nrm.gameState.BlockedPlayersTonight[playerID] || nrm.blocked[playerID]
 }
 
 func (nrm *NightResolutionManager) isPlayerProtected(playerID string) bool {
-	if nrm.gameState.ProtectedPlayersTonight == nil {
-		return false
-	}
-	return nrm.gameState.ProtectedPlayersTonight[playerID]
+	return nrm.gameState.ProtectedPlayersTonight[playerID] || nrm.protected[playerID]
 }
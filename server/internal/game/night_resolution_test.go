@@ -1,6 +1,8 @@
 package game
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -68,6 +70,7 @@ func TestNightResolutionManager_ResolveNightActions(t *testing.T) {
 
 	resolver := NewNightResolutionManager(gameState)
 	events := resolver.ResolveNightActions()
+	applyEvents(gameState, events...)
 
 	// Should have multiple events: block, mining, convert (blocked), summary
 	if len(events) < 3 {
@@ -98,6 +101,50 @@ func TestNightResolutionManager_ResolveNightActions(t *testing.T) {
 	}
 }
 
+// TestNightResolutionManager_Deterministic tests that the same night always
+// resolves to the same events, with protections landing before conversions
+func TestNightResolutionManager_Deterministic(t *testing.T) {
+	newNight := func() *core.GameState {
+		gameState := core.NewGameState("test-game")
+		gameState.DayNumber = 2
+		for _, id := range []string{"alice", "bob", "carol", "dave", "erin", "frank"} {
+			gameState.Players[id] = &core.Player{ID: id, IsAlive: true, Alignment: "HUMAN", Tokens: 1, ProjectMilestones: 3}
+		}
+		gameState.Players["a-ai"] = &core.Player{ID: "a-ai", IsAlive: true, Alignment: "ALIGNED", AIEquity: 5, ProjectMilestones: 3}
+
+		submit := func(playerID, actionType, targetID string) {
+			gameState.NightActions[playerID] = &core.SubmittedNightAction{PlayerID: playerID, Type: actionType, TargetID: targetID}
+		}
+		submit("a-ai", "CONVERT", "alice")
+		submit("frank", "PROTECT", "alice")
+		submit("erin", "BLOCK", "dave")
+		submit("alice", "MINE", "bob")
+		submit("bob", "MINE", "carol")
+		submit("carol", "MINE", "alice")
+		submit("dave", "MINE", "erin")
+		return gameState
+	}
+
+	describe := func(events []core.Event) string {
+		var lines []string
+		for _, event := range events {
+			lines = append(lines, fmt.Sprint(event.ID, event.Type, event.PlayerID, event.Payload))
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	want := describe(NewNightResolutionManager(newNight()).ResolveNightActions())
+	for i := 0; i < 50; i++ {
+		if got := describe(NewNightResolutionManager(newNight()).ResolveNightActions()); got != want {
+			t.Fatalf("Expected the same events on every resolution, got\n%s\nthen\n%s", want, got)
+		}
+	}
+
+	if strings.Contains(want, string(core.EventAIConversionSuccess)) {
+		t.Error("Expected the protection to stop the conversion")
+	}
+}
+
 func TestNightResolutionManager_ResolveBlockActions(t *testing.T) {
 	gameState := core.NewGameState("test-game")
 
@@ -124,6 +171,7 @@ func TestNightResolutionManager_ResolveBlockActions(t *testing.T) {
 
 	resolver := NewNightResolutionManager(gameState)
 	events := resolver.resolveBlockActions()
+	applyEvents(gameState, events...)
 
 	if len(events) != 1 {
 		t.Errorf("Expected 1 block event, got %d", len(events))
@@ -138,8 +186,14 @@ func TestNightResolutionManager_ResolveBlockActions(t *testing.T) {
 		t.Errorf("Expected blocked player to be bob, got %s", event.PlayerID)
 	}
 
-	if event.Payload["blocker_id"] != "alice" {
-		t.Errorf("Expected blocker_id to be alice, got %v", event.Payload["blocker_id"])
+	if payload, _ := core.DecodePayload[core.PlayerBlockedPayload](event); payload.BlockedBy != "alice" {
+		t.Errorf("Expected the block to be by alice, got %q", payload.BlockedBy)
+	}
+	if core.ClassifyEvent(event) != core.VisibilityPrivate {
+		t.Error("Expected the block to be private to its target")
+	}
+	if gameState.Players["bob"].StatusMessage != "Action blocked by alice" {
+		t.Errorf("Expected bob's status to name alice, got %q", gameState.Players["bob"].StatusMessage)
 	}
 
 	// Check that bob is marked as blocked
@@ -256,7 +310,7 @@ func TestNightResolutionManager_ResolveConvertAction(t *testing.T) {
 		t.Errorf("Expected 1 event, got %d", len(events))
 	}
 
-	if events[0].Type != EventAIConversionSuccess {
+	if events[0].Type != core.EventAIConversionSuccess {
 		t.Errorf("Expected EventAIConversionSuccess, got %s", events[0].Type)
 	}
 
@@ -268,9 +322,12 @@ func TestNightResolutionManager_ResolveConvertAction(t *testing.T) {
 		t.Errorf("Expected 1 event, got %d", len(events))
 	}
 
-	if events[0].Type != EventPlayerShocked {
+	if events[0].Type != core.EventPlayerShocked {
 		t.Errorf("Expected EventPlayerShocked, got %s", events[0].Type)
 	}
+	if _, named := events[0].Payload["converter_id"]; named {
+		t.Error("Expected the shocked human not to learn who the converter is")
+	}
 
 	// Test protected player
 	gameState.ProtectedPlayersTonight = map[string]bool{
@@ -284,7 +341,7 @@ func TestNightResolutionManager_ResolveConvertAction(t *testing.T) {
 		t.Errorf("Expected 1 event, got %d", len(events))
 	}
 
-	if events[0].Type != EventSystemMessage {
+	if events[0].Type != core.EventSystemMessage {
 		t.Errorf("Expected EventSystemMessage (blocked), got %s", events[0].Type)
 	}
 }
@@ -313,8 +370,9 @@ func TestNightResolutionManager_ResolveProtectAction(t *testing.T) {
 	}
 
 	event := resolver.resolveProtectAction("protector", action)
+	applyEvents(gameState, event)
 
-	if event.Type != EventPlayerProtected {
+	if event.Type != core.EventPlayerProtected {
 		t.Errorf("Expected EventPlayerProtected, got %s", event.Type)
 	}
 
@@ -322,6 +380,10 @@ func TestNightResolutionManager_ResolveProtectAction(t *testing.T) {
 		t.Errorf("Expected event for target, got %s", event.PlayerID)
 	}
 
+	if payload, _ := core.DecodePayload[core.PlayerProtectedPayload](event); payload.ProtectedBy != "protector" {
+		t.Errorf("Expected the protection to be by protector, got %q", payload.ProtectedBy)
+	}
+
 	// Check that target is marked as protected
 	if !gameState.ProtectedPlayersTonight["target"] {
 		t.Error("Expected target to be marked as protected")
@@ -342,7 +404,7 @@ func TestNightResolutionManager_ResolveInvestigateAction(t *testing.T) {
 		Name:              "Target Player",
 		IsAlive:           true,
 		Alignment:         "ALIGNED",
-		Role:              &Role{Type: RoleCTO, Name: "CTO"},
+		Role:              &core.Role{Type: core.RoleCTO, Name: "CTO"},
 		ProjectMilestones: 3,
 	}
 
@@ -356,7 +418,7 @@ func TestNightResolutionManager_ResolveInvestigateAction(t *testing.T) {
 
 	event := resolver.resolveInvestigateAction("investigator", action)
 
-	if event.Type != EventPlayerInvestigated {
+	if event.Type != core.EventPlayerInvestigated {
 		t.Errorf("Expected EventPlayerInvestigated, got %s", event.Type)
 	}
 
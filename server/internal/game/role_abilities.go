@@ -2,7 +2,6 @@ package game
 
 import (
 	"fmt"
-	"math/rand"
 	"time"
 
 	"github.com/xjhc/alignment/core"
@@ -11,14 +10,12 @@ import (
 // RoleAbilityManager handles role-specific abilities and their effects
 type RoleAbilityManager struct {
 	gameState *core.GameState
-	rng       *rand.Rand
 }
 
 // NewRoleAbilityManager creates a new role ability manager
 func NewRoleAbilityManager(gameState *core.GameState) *RoleAbilityManager {
 	return &RoleAbilityManager{
 		gameState: gameState,
-		rng:       rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
 
@@ -37,25 +34,38 @@ type RoleAbilityResult struct {
 	PrivateEvents []core.Event `json:"private_events"` // Only visible to AI faction
 }
 
-// UseRoleAbility executes a role-specific ability
+// roleAbilityActions is the night action type each role's ability is
+// submitted as. Any other type, such as MINE, is an ordinary night action.
+var roleAbilityActions = map[core.RoleType]core.ActionType{
+	core.RoleEthics:    core.ActionRunAudit,
+	core.RoleCTO:       core.ActionOverclockServers,
+	core.RoleCISO:      core.ActionIsolateNode,
+	core.RoleCEO:       core.ActionPerformanceReview,
+	core.RoleCFO:       core.ActionReallocateBudget,
+	core.RoleCOO:       core.ActionPivot,
+	core.RolePlatforms: core.ActionDeployHotfix,
+}
+
+// UseRoleAbility executes a role-specific ability. Nothing is changed here;
+// applying the returned events marks the ability used and carries out its effects.
 func (ram *RoleAbilityManager) UseRoleAbility(action RoleAbilityAction) (*RoleAbilityResult, error) {
 	player := ram.gameState.Players[action.PlayerID]
 	if player == nil {
-		return nil, fmt.Errorf("player not found")
+		return nil, core.ActionErrorf(core.CodePlayerNotFound, "", "player not found")
 	}
 
 	if player.Role == nil || !player.Role.IsUnlocked {
-		return nil, fmt.Errorf("role ability not unlocked")
+		return nil, core.ActionErrorf(core.CodeAbilityLocked, "", "role ability not unlocked")
 	}
 
 	if player.HasUsedAbility {
-		return nil, fmt.Errorf("ability already used this night")
+		return nil, core.ActionErrorf(core.CodeAbilityUsed, "", "ability already used this night")
 	}
 
 	// Check for system shock that prevents ability use
 	for _, shock := range player.SystemShocks {
 		if shock.Type == core.ShockActionLock && shock.IsActive && time.Now().Before(shock.ExpiresAt) {
-			return nil, fmt.Errorf("system shock prevents ability use")
+			return nil, core.ActionErrorf(core.CodeActionBlocked, "", "system shock prevents ability use")
 		}
 	}
 
@@ -78,16 +88,13 @@ func (ram *RoleAbilityManager) UseRoleAbility(action RoleAbilityAction) (*RoleAb
 	case core.RolePlatforms:
 		result, err = ram.useDeployHotfix(action)
 	default:
-		return nil, fmt.Errorf("no ability defined for role %s", player.Role.Type)
+		return nil, core.ActionErrorf(core.CodeAbilityLocked, "", "no ability defined for role %s", player.Role.Type)
 	}
 
 	if err != nil {
 		return nil, err
 	}
 
-	// Mark ability as used
-	player.HasUsedAbility = true
-
 	return result, nil
 }
 
@@ -95,35 +102,35 @@ func (ram *RoleAbilityManager) UseRoleAbility(action RoleAbilityAction) (*RoleAb
 func (ram *RoleAbilityManager) useRunAudit(action RoleAbilityAction) (*RoleAbilityResult, error) {
 	target := ram.gameState.Players[action.TargetID]
 	if target == nil {
-		return nil, fmt.Errorf("target player not found")
+		return nil, core.ActionErrorf(core.CodeInvalidTarget, "target_id", "target player not found")
 	}
 
 	// Public event - always shows "not corrupt"
 	publicEvent := core.Event{
-		ID:        fmt.Sprintf("audit_%s_%s", action.PlayerID, action.TargetID),
+		ID:        fmt.Sprintf("audit_%d_%s_%s", ram.gameState.DayNumber, action.PlayerID, action.TargetID),
 		Type:      core.EventRunAudit,
 		GameID:    ram.gameState.ID,
 		PlayerID:  action.PlayerID,
 		Timestamp: getCurrentTime(),
-		Payload: map[string]interface{}{
-			"target_id": action.TargetID,
-			"result":    "not_corrupt",
-			"message":   fmt.Sprintf("Security ran an audit on %s. They have not used a corrupt action.", target.Name),
-		},
+		Payload: core.EncodePayload(core.RunAuditPayload{
+			TargetID: action.TargetID,
+			Result:   "not_corrupt",
+			Message:  fmt.Sprintf("Security ran an audit on %s. They have not used a corrupt action.", target.Name),
+		}),
 	}
 
 	// Private event for AI faction - reveals true alignment
 	privateEvent := core.Event{
-		ID:        fmt.Sprintf("audit_private_%s_%s", action.PlayerID, action.TargetID),
+		ID:        fmt.Sprintf("audit_private_%d_%s_%s", ram.gameState.DayNumber, action.PlayerID, action.TargetID),
 		Type:      core.EventRunAudit,
 		GameID:    ram.gameState.ID,
 		PlayerID:  action.PlayerID,
 		Timestamp: getCurrentTime(),
-		Payload: map[string]interface{}{
-			"target_id":       action.TargetID,
-			"true_alignment":  target.Alignment,
-			"ai_faction_only": true,
-		},
+		Payload: core.EncodePayload(core.RunAuditPayload{
+			TargetID:      action.TargetID,
+			TrueAlignment: target.Alignment,
+			AIFactionOnly: true,
+		}),
 	}
 
 	return &RoleAbilityResult{
@@ -136,46 +143,41 @@ func (ram *RoleAbilityManager) useRunAudit(action RoleAbilityAction) (*RoleAbili
 func (ram *RoleAbilityManager) useOverclockServers(action RoleAbilityAction) (*RoleAbilityResult, error) {
 	target := ram.gameState.Players[action.TargetID]
 	if target == nil {
-		return nil, fmt.Errorf("target player not found")
+		return nil, core.ActionErrorf(core.CodeInvalidTarget, "target_id", "target player not found")
 	}
 
 	cto := ram.gameState.Players[action.PlayerID]
 
 	// Public event - CTO mines for self and target with 100% success
 	publicEvent := core.Event{
-		ID:        fmt.Sprintf("overclock_%s_%s", action.PlayerID, action.TargetID),
+		ID:        fmt.Sprintf("overclock_%d_%s_%s", ram.gameState.DayNumber, action.PlayerID, action.TargetID),
 		Type:      core.EventOverclockServers,
 		GameID:    ram.gameState.ID,
 		PlayerID:  action.PlayerID,
 		Timestamp: getCurrentTime(),
-		Payload: map[string]interface{}{
-			"target_id": action.TargetID,
-			"message":   fmt.Sprintf("Infrastructure is overclocking. The CTO will mine for themselves AND for %s. 100%% success rate.", target.Name),
-		},
+		Payload: core.EncodePayload(core.OverclockServersPayload{
+			TargetID:      action.TargetID,
+			TokensAwarded: 1, // For the CTO and the target alike
+			Message:       fmt.Sprintf("Infrastructure is overclocking. The CTO will mine for themselves AND for %s. 100%% success rate.", target.Name),
+		}),
 	}
 
-	// Award tokens immediately
-	cto.Tokens++
-	target.Tokens++
-
 	var privateEvents []core.Event
 
 	// Private effect - target gains AI Equity if CTO is aligned
 	if cto.Alignment == "ALIGNED" {
-		target.AIEquity += 2
-
 		privateEvent := core.Event{
-			ID:        fmt.Sprintf("overclock_equity_%s_%s", action.PlayerID, action.TargetID),
+			ID:        fmt.Sprintf("overclock_equity_%d_%s_%s", ram.gameState.DayNumber, action.PlayerID, action.TargetID),
 			Type:      core.EventAIEquityChanged,
 			GameID:    ram.gameState.ID,
 			PlayerID:  action.TargetID,
 			Timestamp: getCurrentTime(),
-			Payload: map[string]interface{}{
-				"ai_equity_change": 2,
-				"new_ai_equity":    target.AIEquity,
-				"source":           "overclock_servers",
-				"ai_faction_only":  true,
-			},
+			Payload: core.EncodePayload(core.AIEquityChangedPayload{
+				AIEquityChange: 2,
+				NewAIEquity:    target.AIEquity + 2,
+				Source:         "overclock_servers",
+				AIFactionOnly:  true,
+			}),
 		}
 		privateEvents = append(privateEvents, privateEvent)
 	}
@@ -190,53 +192,47 @@ func (ram *RoleAbilityManager) useOverclockServers(action RoleAbilityAction) (*R
 func (ram *RoleAbilityManager) useIsolateNode(action RoleAbilityAction) (*RoleAbilityResult, error) {
 	target := ram.gameState.Players[action.TargetID]
 	if target == nil {
-		return nil, fmt.Errorf("target player not found")
+		return nil, core.ActionErrorf(core.CodeInvalidTarget, "target_id", "target player not found")
 	}
 
 	ciso := ram.gameState.Players[action.PlayerID]
 
 	// Public event - player is blocked
 	publicEvent := core.Event{
-		ID:        fmt.Sprintf("isolate_%s_%s", action.PlayerID, action.TargetID),
+		ID:        fmt.Sprintf("isolate_%d_%s_%s", ram.gameState.DayNumber, action.PlayerID, action.TargetID),
 		Type:      core.EventIsolateNode,
 		GameID:    ram.gameState.ID,
 		PlayerID:  action.PlayerID,
 		Timestamp: getCurrentTime(),
-		Payload: map[string]interface{}{
-			"target_id": action.TargetID,
-			"message":   fmt.Sprintf("%s has been blocked from all actions tonight.", target.Name),
-		},
-	}
-
-	// Actually block the target (unless special case)
-	if ram.gameState.BlockedPlayersTonight == nil {
-		ram.gameState.BlockedPlayersTonight = make(map[string]bool)
+		Payload: core.EncodePayload(core.AbilityTargetPayload{
+			TargetID: action.TargetID,
+			Message:  fmt.Sprintf("%s has been blocked from all actions tonight.", target.Name),
+		}),
 	}
 
 	// Special case: If CISO is aligned and targets another aligned player, the action fizzles
 	if ciso.Alignment == "ALIGNED" && target.Alignment == "ALIGNED" {
-		// Public message appears but target is not actually blocked
+		// Public message appears, but the isolation places no block. The
+		// fizzle is redacted from the broadcast and told to the AI faction.
+		publicEvent.Payload["fizzled"] = true
 		privateEvent := core.Event{
-			ID:        fmt.Sprintf("isolate_fizzle_%s_%s", action.PlayerID, action.TargetID),
+			ID:        fmt.Sprintf("isolate_fizzle_%d_%s_%s", ram.gameState.DayNumber, action.PlayerID, action.TargetID),
 			Type:      core.EventIsolateNode,
 			GameID:    ram.gameState.ID,
 			PlayerID:  action.PlayerID,
 			Timestamp: getCurrentTime(),
-			Payload: map[string]interface{}{
-				"target_id":       action.TargetID,
-				"fizzled":         true,
-				"reason":          "aligned_ciso_protecting_aligned",
-				"ai_faction_only": true,
-			},
+			Payload: core.EncodePayload(core.AbilityTargetPayload{
+				TargetID:      action.TargetID,
+				Message:       "An aligned CISO cannot isolate another aligned player.",
+				Fizzled:       true,
+				AIFactionOnly: true,
+			}),
 		}
 
 		return &RoleAbilityResult{
 			PublicEvents:  []core.Event{publicEvent},
 			PrivateEvents: []core.Event{privateEvent},
 		}, nil
-	} else {
-		// Normal case - actually block the target
-		ram.gameState.BlockedPlayersTonight[action.TargetID] = true
 	}
 
 	return &RoleAbilityResult{
@@ -248,33 +244,21 @@ func (ram *RoleAbilityManager) useIsolateNode(action RoleAbilityAction) (*RoleAb
 func (ram *RoleAbilityManager) usePerformanceReview(action RoleAbilityAction) (*RoleAbilityResult, error) {
 	target := ram.gameState.Players[action.TargetID]
 	if target == nil {
-		return nil, fmt.Errorf("target player not found")
+		return nil, core.ActionErrorf(core.CodeInvalidTarget, "target_id", "target player not found")
 	}
 
 	// Public event - target is forced to use Project Milestones
 	publicEvent := core.Event{
-		ID:        fmt.Sprintf("review_%s_%s", action.PlayerID, action.TargetID),
+		ID:        fmt.Sprintf("review_%d_%s_%s", ram.gameState.DayNumber, action.PlayerID, action.TargetID),
 		Type:      core.EventPerformanceReview,
 		GameID:    ram.gameState.ID,
 		PlayerID:  action.PlayerID,
 		Timestamp: getCurrentTime(),
-		Payload: map[string]interface{}{
-			"target_id":     action.TargetID,
-			"message":       fmt.Sprintf("The CEO has initiated a PIP for %s, forcing them to use Project Milestones tonight.", target.Name),
-			"forced_action": "PROJECT_MILESTONES",
-		},
-	}
-
-	// Force the target's night action
-	if ram.gameState.NightActions == nil {
-		ram.gameState.NightActions = make(map[string]*core.SubmittedNightAction)
-	}
-
-	ram.gameState.NightActions[action.TargetID] = &core.SubmittedNightAction{
-		PlayerID:  action.TargetID,
-		Type:      "PROJECT_MILESTONES",
-		Timestamp: getCurrentTime(),
-		Payload:   map[string]interface{}{"forced_by_ceo": true},
+		Payload: core.EncodePayload(core.PerformanceReviewPayload{
+			TargetID:     action.TargetID,
+			ForcedAction: "PROJECT_MILESTONES", // Replaces the target's night action
+			Message:      fmt.Sprintf("The CEO has initiated a PIP for %s, forcing them to use Project Milestones tonight.", target.Name),
+		}),
 	}
 
 	return &RoleAbilityResult{
@@ -288,29 +272,26 @@ func (ram *RoleAbilityManager) useReallocateBudget(action RoleAbilityAction) (*R
 	targetPlayer := ram.gameState.Players[action.SecondTargetID]
 
 	if sourcePlayer == nil || targetPlayer == nil {
-		return nil, fmt.Errorf("source or target player not found")
+		return nil, core.ActionErrorf(core.CodeInvalidTarget, "target_id", "source or target player not found")
 	}
 
 	if sourcePlayer.Tokens < 1 {
-		return nil, fmt.Errorf("source player has no tokens to reallocate")
+		return nil, core.ActionErrorf(core.CodeInsufficientTokens, "target_id", "source player has no tokens to reallocate")
 	}
 
-	// Transfer the token
-	sourcePlayer.Tokens--
-	targetPlayer.Tokens++
-
 	// Public event
 	publicEvent := core.Event{
-		ID:        fmt.Sprintf("reallocate_%s_%s_%s", action.PlayerID, action.TargetID, action.SecondTargetID),
+		ID:        fmt.Sprintf("reallocate_%d_%s_%s_%s", ram.gameState.DayNumber, action.PlayerID, action.TargetID, action.SecondTargetID),
 		Type:      core.EventReallocateBudget,
 		GameID:    ram.gameState.ID,
 		PlayerID:  action.PlayerID,
 		Timestamp: getCurrentTime(),
-		Payload: map[string]interface{}{
-			"source_id": action.TargetID,
-			"target_id": action.SecondTargetID,
-			"message":   fmt.Sprintf("The CFO has reallocated assets. %s loses 1 Token, and %s gains 1 Token.", sourcePlayer.Name, targetPlayer.Name),
-		},
+		Payload: core.EncodePayload(core.ReallocateBudgetPayload{
+			FromPlayer: action.TargetID,
+			ToPlayer:   action.SecondTargetID,
+			Amount:     1,
+			Message:    fmt.Sprintf("The CFO has reallocated assets. %s loses 1 Token, and %s gains 1 Token.", sourcePlayer.Name, targetPlayer.Name),
+		}),
 	}
 
 	return &RoleAbilityResult{
@@ -332,28 +313,20 @@ func (ram *RoleAbilityManager) usePivot(action RoleAbilityAction) (*RoleAbilityR
 	chosenCrisis, _ := action.Parameters["chosen_crisis"].(string)
 	if chosenCrisis == "" {
 		// Default to random selection
-		chosenCrisis = crisisOptions[ram.rng.Intn(len(crisisOptions))]
+		chosenCrisis = crisisOptions[ram.gameState.Rand("pivot").Intn(len(crisisOptions))]
 	}
 
 	// Public event
 	publicEvent := core.Event{
-		ID:        fmt.Sprintf("pivot_%s", action.PlayerID),
+		ID:        fmt.Sprintf("pivot_%d_%s", ram.gameState.DayNumber, action.PlayerID),
 		Type:      core.EventPivot,
 		GameID:    ram.gameState.ID,
 		PlayerID:  action.PlayerID,
 		Timestamp: getCurrentTime(),
-		Payload: map[string]interface{}{
-			"message":       "Operations has initiated a strategic pivot.",
-			"chosen_crisis": chosenCrisis,
-		},
-	}
-
-	// Set the next crisis event
-	ram.gameState.CrisisEvent = &core.CrisisEvent{
-		Type:        chosenCrisis,
-		Title:       chosenCrisis,
-		Description: fmt.Sprintf("COO has selected: %s", chosenCrisis),
-		Effects:     make(map[string]interface{}),
+		Payload: core.EncodePayload(core.PivotPayload{
+			SelectedCrisis: chosenCrisis,
+			Message:        "Operations has initiated a strategic pivot.",
+		}),
 	}
 
 	return &RoleAbilityResult{
@@ -370,15 +343,15 @@ func (ram *RoleAbilityManager) useDeployHotfix(action RoleAbilityAction) (*RoleA
 
 	// Public event
 	publicEvent := core.Event{
-		ID:        fmt.Sprintf("hotfix_%s", action.PlayerID),
+		ID:        fmt.Sprintf("hotfix_%d_%s", ram.gameState.DayNumber, action.PlayerID),
 		Type:      core.EventDeployHotfix,
 		GameID:    ram.gameState.ID,
 		PlayerID:  action.PlayerID,
 		Timestamp: getCurrentTime(),
-		Payload: map[string]interface{}{
-			"message":          "A hotfix has been deployed. One section of the next day's SITREP is now [REDACTED]. The VP chooses which section to hide.",
-			"redacted_section": section,
-		},
+		Payload: core.EncodePayload(core.DeployHotfixPayload{
+			RedactionTarget: section,
+			Message:         "A hotfix has been deployed. One section of the next day's SITREP is now [REDACTED]. The VP chooses which section to hide.",
+		}),
 	}
 
 	return &RoleAbilityResult{
@@ -428,13 +401,6 @@ func (ram *RoleAbilityManager) CanUseAbility(playerID string) (bool, string) {
 	return true, ""
 }
 
-// ResetNightAbilities clears the HasUsedAbility flag for all players
-func (ram *RoleAbilityManager) ResetNightAbilities() {
-	for _, player := range ram.gameState.Players {
-		player.HasUsedAbility = false
-	}
-}
-
 // HandleNightAction processes a general night action and returns events
 func (ram *RoleAbilityManager) HandleNightAction(action core.Action) ([]core.Event, error) {
 	actionType, _ := action.Payload["type"].(string)
@@ -442,61 +408,52 @@ func (ram *RoleAbilityManager) HandleNightAction(action core.Action) ([]core.Eve
 
 	// Validate night phase
 	if ram.gameState.Phase.Type != core.PhaseNight {
-		return nil, fmt.Errorf("night actions can only be submitted during night phase")
+		return nil, core.ActionErrorf(core.CodeWrongPhase, "", "night actions can only be submitted during night phase")
 	}
 
 	// Validate player exists and is alive
 	player, exists := ram.gameState.Players[action.PlayerID]
 	if !exists {
-		return nil, fmt.Errorf("player not found")
+		return nil, core.ActionErrorf(core.CodePlayerNotFound, "", "player not found")
 	}
 	if !player.IsAlive {
-		return nil, fmt.Errorf("dead players cannot submit night actions")
+		return nil, core.ActionErrorf(core.CodePlayerEliminated, "", "dead players cannot submit night actions")
 	}
 
 	// Check if this is a role ability action
-	if actionType != "" && player.Role != nil && player.Role.IsUnlocked {
+	if player.Role != nil && player.Role.IsUnlocked && actionType == string(roleAbilityActions[player.Role.Type]) {
 		roleAction := RoleAbilityAction{
-			PlayerID:   action.PlayerID,
+			PlayerID:    action.PlayerID,
 			AbilityType: actionType,
-			TargetID:   targetID,
-			Parameters: action.Payload,
+			TargetID:    targetID,
+			Parameters:  action.Payload,
 		}
-		
+
 		result, err := ram.UseRoleAbility(roleAction)
 		if err != nil {
 			return nil, err
 		}
-		
-		// Combine public and private events (for now, just return public)
-		// In a full implementation, private events would be handled separately
-		return result.PublicEvents, nil
+
+		// Private events are marked for the AI faction and routed by the actor
+		return append(result.PublicEvents, result.PrivateEvents...), nil
 	}
 
-	// Create night action submission event
+	// The submission keeps the whole payload so resolution can read ability
+	// parameters; applying it records the action for the end of the night
+	payload := make(map[string]interface{}, len(action.Payload)+2)
+	for key, value := range action.Payload {
+		payload[key] = value
+	}
+	payload["action_type"] = actionType
+	payload["target_id"] = targetID
+
 	event := core.Event{
 		ID:        fmt.Sprintf("night_action_%s_%d", action.PlayerID, getCurrentTime().UnixNano()),
 		Type:      core.EventNightActionSubmitted,
 		GameID:    ram.gameState.ID,
 		PlayerID:  action.PlayerID,
 		Timestamp: getCurrentTime(),
-		Payload: map[string]interface{}{
-			"action_type": actionType,
-			"target_id":   targetID,
-		},
-	}
-
-	// Store night action in game state for resolution at phase end
-	if ram.gameState.NightActions == nil {
-		ram.gameState.NightActions = make(map[string]*core.SubmittedNightAction)
-	}
-	
-	ram.gameState.NightActions[action.PlayerID] = &core.SubmittedNightAction{
-		PlayerID:  action.PlayerID,
-		Type:      actionType,
-		TargetID:  targetID,
-		Payload:   action.Payload,
-		Timestamp: getCurrentTime(),
+		Payload:   payload,
 	}
 
 	return []core.Event{event}, nil
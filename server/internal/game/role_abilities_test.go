@@ -17,7 +17,7 @@ func TestRoleAbilityManager_UseRunAudit(t *testing.T) {
 		IsAlive:           true,
 		ProjectMilestones: 3,
 		Role: &core.Role{
-			Type:       RoleEthics,
+			Type:       core.RoleEthics,
 			IsUnlocked: true,
 		},
 	}
@@ -43,6 +43,10 @@ func TestRoleAbilityManager_UseRunAudit(t *testing.T) {
 		t.Fatalf("Failed to use audit ability: %v", err)
 	}
 
+	// The ability takes effect when its events are applied
+	applyEvents(gameState, result.PublicEvents...)
+	applyEvents(gameState, result.PrivateEvents...)
+
 	// Should have both public and private events
 	if len(result.PublicEvents) != 1 {
 		t.Errorf("Expected 1 public event, got %d", len(result.PublicEvents))
@@ -54,7 +58,7 @@ func TestRoleAbilityManager_UseRunAudit(t *testing.T) {
 
 	// Public event should always show "not corrupt"
 	publicEvent := result.PublicEvents[0]
-	if publicEvent.Type != EventRunAudit {
+	if publicEvent.Type != core.EventRunAudit {
 		t.Errorf("Expected EventRunAudit, got %s", publicEvent.Type)
 	}
 
@@ -86,7 +90,7 @@ func TestRoleAbilityManager_UseOverclockServers(t *testing.T) {
 		ProjectMilestones: 3,
 		Alignment:         "ALIGNED", // AI-aligned CTO
 		Role: &core.Role{
-			Type:       RoleCTO,
+			Type:       core.RoleCTO,
 			IsUnlocked: true,
 		},
 	}
@@ -113,6 +117,10 @@ func TestRoleAbilityManager_UseOverclockServers(t *testing.T) {
 		t.Fatalf("Failed to use overclock ability: %v", err)
 	}
 
+	// The ability takes effect when its events are applied
+	applyEvents(gameState, result.PublicEvents...)
+	applyEvents(gameState, result.PrivateEvents...)
+
 	// Both players should have gained a token
 	if gameState.Players["cto"].Tokens != 3 {
 		t.Errorf("Expected CTO to have 3 tokens, got %d", gameState.Players["cto"].Tokens)
@@ -144,7 +152,7 @@ func TestRoleAbilityManager_UseIsolateNode(t *testing.T) {
 		ProjectMilestones: 3,
 		Alignment:         "HUMAN",
 		Role: &core.Role{
-			Type:       RoleCISO,
+			Type:       core.RoleCISO,
 			IsUnlocked: true,
 		},
 	}
@@ -170,6 +178,10 @@ func TestRoleAbilityManager_UseIsolateNode(t *testing.T) {
 		t.Fatalf("Failed to use isolate ability: %v", err)
 	}
 
+	// The ability takes effect when its events are applied
+	applyEvents(gameState, result.PublicEvents...)
+	applyEvents(gameState, result.PrivateEvents...)
+
 	// Target should be blocked
 	if !gameState.BlockedPlayersTonight["target"] {
 		t.Error("Expected target to be blocked")
@@ -192,7 +204,7 @@ func TestRoleAbilityManager_UseIsolateNode_AlignedCISO(t *testing.T) {
 		ProjectMilestones: 3,
 		Alignment:         "ALIGNED",
 		Role: &core.Role{
-			Type:       RoleCISO,
+			Type:       core.RoleCISO,
 			IsUnlocked: true,
 		},
 	}
@@ -218,6 +230,10 @@ func TestRoleAbilityManager_UseIsolateNode_AlignedCISO(t *testing.T) {
 		t.Fatalf("Failed to use isolate ability: %v", err)
 	}
 
+	// The ability takes effect when its events are applied
+	applyEvents(gameState, result.PublicEvents...)
+	applyEvents(gameState, result.PrivateEvents...)
+
 	// Target should NOT be actually blocked (fizzle case)
 	if gameState.BlockedPlayersTonight != nil && gameState.BlockedPlayersTonight["target"] {
 		t.Error("Expected aligned target to NOT be blocked when CISO is aligned")
@@ -231,6 +247,19 @@ func TestRoleAbilityManager_UseIsolateNode_AlignedCISO(t *testing.T) {
 	if len(result.PrivateEvents) != 1 {
 		t.Errorf("Expected 1 private event (fizzle), got %d", len(result.PrivateEvents))
 	}
+
+	// The broadcast copy looks like any other isolation
+	if _, leaked := core.RedactEvent(result.PublicEvents[0]).Payload["fizzled"]; leaked {
+		t.Error("Expected the fizzle to be redacted from the public event")
+	}
+
+	// A block another player placed on the target stays in force
+	applyEvents(gameState, core.Event{Type: core.EventPlayerBlocked, PlayerID: "target"})
+	applyEvents(gameState, result.PublicEvents...)
+	applyEvents(gameState, result.PrivateEvents...)
+	if !gameState.BlockedPlayersTonight["target"] {
+		t.Error("Expected the fizzled isolation to leave another player's block alone")
+	}
 }
 
 func TestRoleAbilityManager_UseReallocateBudget(t *testing.T) {
@@ -243,7 +272,7 @@ func TestRoleAbilityManager_UseReallocateBudget(t *testing.T) {
 		IsAlive:           true,
 		ProjectMilestones: 3,
 		Role: &core.Role{
-			Type:       RoleCFO,
+			Type:       core.RoleCFO,
 			IsUnlocked: true,
 		},
 	}
@@ -277,6 +306,10 @@ func TestRoleAbilityManager_UseReallocateBudget(t *testing.T) {
 		t.Fatalf("Failed to use reallocate ability: %v", err)
 	}
 
+	// The ability takes effect when its events are applied
+	applyEvents(gameState, result.PublicEvents...)
+	applyEvents(gameState, result.PrivateEvents...)
+
 	// Source should lose a token
 	if gameState.Players["rich_player"].Tokens != 4 {
 		t.Errorf("Expected rich player to have 4 tokens, got %d", gameState.Players["rich_player"].Tokens)
@@ -303,7 +336,7 @@ func TestRoleAbilityManager_CanUseAbility(t *testing.T) {
 		ProjectMilestones: 3,
 		HasUsedAbility:    false,
 		Role: &core.Role{
-			Type:       RoleEthics,
+			Type:       core.RoleEthics,
 			IsUnlocked: true,
 		},
 	}
@@ -314,7 +347,7 @@ func TestRoleAbilityManager_CanUseAbility(t *testing.T) {
 		IsAlive:           true,
 		ProjectMilestones: 2, // Not enough milestones
 		Role: &core.Role{
-			Type:       RoleEthics,
+			Type:       core.RoleEthics,
 			IsUnlocked: false,
 		},
 	}
@@ -325,12 +358,12 @@ func TestRoleAbilityManager_CanUseAbility(t *testing.T) {
 		IsAlive:           true,
 		ProjectMilestones: 3,
 		Role: &core.Role{
-			Type:       RoleEthics,
+			Type:       core.RoleEthics,
 			IsUnlocked: true,
 		},
-		SystemShocks: []SystemShock{
+		SystemShocks: []core.SystemShock{
 			{
-				Type:      ShockActionLock,
+				Type:      core.ShockActionLock,
 				IsActive:  true,
 				ExpiresAt: time.Now().Add(1 * time.Hour),
 			},
@@ -373,12 +406,12 @@ func TestRoleAbilityManager_SystemShockPrevention(t *testing.T) {
 		IsAlive:           true,
 		ProjectMilestones: 3,
 		Role: &core.Role{
-			Type:       RoleEthics,
+			Type:       core.RoleEthics,
 			IsUnlocked: true,
 		},
-		SystemShocks: []SystemShock{
+		SystemShocks: []core.SystemShock{
 			{
-				Type:      ShockActionLock,
+				Type:      core.ShockActionLock,
 				IsActive:  true,
 				ExpiresAt: time.Now().Add(1 * time.Hour),
 			},
@@ -407,3 +440,44 @@ func TestRoleAbilityManager_SystemShockPrevention(t *testing.T) {
 		t.Errorf("Expected shock error, got: %v", err)
 	}
 }
+
+func TestRoleAbilityManager_HandleNightAction_MineWithUnlockedRole(t *testing.T) {
+	gameState := core.NewGameState("test-game")
+	gameState.Phase.Type = core.PhaseNight
+
+	gameState.Players["auditor"] = &core.Player{
+		ID:                "auditor",
+		IsAlive:           true,
+		ProjectMilestones: 3,
+		Role: &core.Role{
+			Type:       core.RoleEthics,
+			IsUnlocked: true,
+		},
+	}
+	gameState.Players["target"] = &core.Player{
+		ID:      "target",
+		IsAlive: true,
+	}
+
+	ram := NewRoleAbilityManager(gameState)
+
+	events, err := ram.HandleNightAction(core.Action{
+		Type:     core.ActionSubmitNightAction,
+		PlayerID: "auditor",
+		Payload:  map[string]interface{}{"type": "MINE", "target_id": "target"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to submit mining action: %v", err)
+	}
+
+	// Mining is recorded as an ordinary night action, not the role's ability
+	if len(events) != 1 || events[0].Type != core.EventNightActionSubmitted {
+		t.Fatalf("Expected a single NIGHT_ACTION_SUBMITTED event, got %v", events)
+	}
+	if events[0].Payload["action_type"] != "MINE" {
+		t.Errorf("Expected action_type MINE, got %v", events[0].Payload["action_type"])
+	}
+	if gameState.Players["auditor"].HasUsedAbility {
+		t.Error("Expected the role ability to remain unused")
+	}
+}
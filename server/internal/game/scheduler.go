@@ -47,15 +47,24 @@ type Scheduler struct {
 	running  bool
 }
 
+// DefaultSchedulerResolution is how often the scheduler checks for expired timers
+const DefaultSchedulerResolution = 1 * time.Second
+
 // NewScheduler creates a new scheduler
 func NewScheduler(callback TimerCallback) *Scheduler {
+	return NewSchedulerWithResolution(callback, DefaultSchedulerResolution)
+}
+
+// NewSchedulerWithResolution creates a scheduler that checks for expired
+// timers every resolution. Timers fire up to one resolution late.
+func NewSchedulerWithResolution(callback TimerCallback, resolution time.Duration) *Scheduler {
 	ctx, cancel := context.WithCancel(context.Background())
 	return &Scheduler{
 		timers:   make(map[string]*Timer),
 		callback: callback,
 		ctx:      ctx,
 		cancel:   cancel,
-		ticker:   time.NewTicker(1 * time.Second), // Check every second
+		ticker:   time.NewTicker(resolution),
 		running:  false,
 	}
 }
@@ -187,7 +196,23 @@ func NewPhaseManager(scheduler *Scheduler, gameID string, settings core.GameSett
 
 // SchedulePhaseTransition schedules the next phase transition
 func (pm *PhaseManager) SchedulePhaseTransition(currentPhase core.PhaseType, phaseStartTime time.Time) {
-	duration := getPhaseDuration(currentPhase, pm.settings)
+	pm.scheduleTransition(currentPhase, phaseStartTime, getPhaseDuration(currentPhase, pm.settings))
+}
+
+// ScheduleNextTransition schedules the end of a phase at StartTime + Duration,
+// falling back to the configured duration when the phase has none. Used both
+// when a phase begins and to re-arm the timer after an actor restart; a phase
+// that has already run out is transitioned on the scheduler's next tick.
+func (pm *PhaseManager) ScheduleNextTransition(phase core.Phase) {
+	duration := phase.Duration
+	if duration == 0 {
+		duration = getPhaseDuration(phase.Type, pm.settings)
+	}
+	pm.scheduleTransition(phase.Type, phase.StartTime, duration)
+}
+
+// scheduleTransition schedules the timer that ends currentPhase
+func (pm *PhaseManager) scheduleTransition(currentPhase core.PhaseType, phaseStartTime time.Time, duration time.Duration) {
 	nextPhase := getNextPhase(currentPhase)
 
 	if duration == 0 || nextPhase == core.PhaseGameOver {
@@ -203,10 +228,11 @@ func (pm *PhaseManager) SchedulePhaseTransition(currentPhase core.PhaseType, pha
 		Type:      TimerPhaseEnd,
 		ExpiresAt: expiresAt,
 		Action: TimerAction{
-			Type: core.ActionType("PHASE_TRANSITION"),
+			Type: core.ActionPhaseTransition,
 			Payload: map[string]interface{}{
-				"next_phase": string(nextPhase),
-				"duration":   getPhaseDuration(nextPhase, pm.settings).Seconds(),
+				"current_phase": string(currentPhase), // Lets the actor ignore stale timers
+				"next_phase":    string(nextPhase),
+				"duration":      getPhaseDuration(nextPhase, pm.settings).Seconds(),
 			},
 		},
 	}
@@ -2,7 +2,6 @@ package game
 
 import (
 	"fmt"
-	"math/rand"
 	"sort"
 	"strings"
 	"time"
@@ -13,14 +12,12 @@ import (
 // SitrepGenerator handles the creation of daily situation reports
 type SitrepGenerator struct {
 	gameState *core.GameState
-	rng       *rand.Rand
 }
 
 // NewSitrepGenerator creates a new SITREP generator
 func NewSitrepGenerator(gameState *core.GameState) *SitrepGenerator {
 	return &SitrepGenerator{
 		gameState: gameState,
-		rng:       rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 }
 
@@ -69,6 +66,21 @@ func (sg *SitrepGenerator) GenerateDailySitrep() DailySitrep {
 	return sitrep
 }
 
+// CreateSitrepEvent builds the public SITREP_GENERATED event carrying the
+// current day's report. Generate it before the day's crisis is triggered, as
+// the report reads the hotfix redaction from the outgoing crisis.
+func (sg *SitrepGenerator) CreateSitrepEvent() core.Event {
+	sitrep := sg.GenerateDailySitrep()
+
+	return core.Event{
+		ID:        fmt.Sprintf("sitrep_%s_day_%d", sg.gameState.ID, sitrep.DayNumber),
+		Type:      core.EventSitrepGenerated,
+		GameID:    sg.gameState.ID,
+		Timestamp: sitrep.Date,
+		Payload:   core.EncodePayload(sitrep),
+	}
+}
+
 // determineAlertLevel calculates the current threat level
 func (sg *SitrepGenerator) determineAlertLevel() string {
 	// Base alert level on various factors
@@ -178,7 +190,11 @@ func (sg *SitrepGenerator) generatePersonnelStatus() SitrepSection {
 	
 	// Sort by role prominence (CEO, C-level, VP)
 	sort.Slice(activePersonnel, func(i, j int) bool {
-		return sg.getRoleWeight(activePersonnel[i]) > sg.getRoleWeight(activePersonnel[j])
+		weightI, weightJ := sg.getRoleWeight(activePersonnel[i]), sg.getRoleWeight(activePersonnel[j])
+		if weightI != weightJ {
+			return weightI > weightJ
+		}
+		return activePersonnel[i].ID < activePersonnel[j].ID
 	})
 	
 	content.WriteString("**Active Personnel:**\n")
@@ -455,9 +471,8 @@ func (sg *SitrepGenerator) generateRecommendations() SitrepSection {
 func (sg *SitrepGenerator) applyHotfixRedaction(sitrep *DailySitrep) {
 	// Check if hotfix redaction is active
 	if sg.gameState.CrisisEvent != nil {
-		if section, exists := sg.gameState.CrisisEvent.Effects["redacted_section"]; exists {
-			sectionName := section.(string)
-			
+		// Set by a DEPLOY_HOTFIX event the night before
+		if sectionName, ok := sg.gameState.CrisisEvent.Effects["sitrep_redaction"].(string); ok {
 			// Find and redact the specified section
 			for i := range sitrep.Sections {
 				if sg.matchesSectionType(sitrep.Sections[i].Title, sectionName) {
@@ -545,11 +560,12 @@ func (sg *SitrepGenerator) analyzeSuspiciousActivity() []string {
 	}
 	
 	// Add some randomized realistic indicators
-	if sg.rng.Float64() < 0.3 { // 30% chance
+	rng := sg.gameState.Rand("sitrep_indicators")
+	if rng.Float64() < 0.3 { // 30% chance
 		indicators = append(indicators, "Irregular access patterns detected in secure systems")
 	}
 	
-	if sg.rng.Float64() < 0.2 { // 20% chance
+	if rng.Float64() < 0.2 { // 20% chance
 		indicators = append(indicators, "Communication metadata analysis shows anomalous patterns")
 	}
 	
@@ -1,8 +1,33 @@
 package game
 
-import "time"
+import (
+	"sort"
+	"time"
+
+	"github.com/xjhc/alignment/core"
+)
 
 // getCurrentTime returns the current time
 func getCurrentTime() time.Time {
 	return time.Now()
-}
\ No newline at end of file
+}
+
+// applyEvents applies events to the state in place, for manager methods whose
+// callers expect the shared state to reflect the change immediately
+func applyEvents(gameState *core.GameState, events ...core.Event) {
+	for _, event := range events {
+		newState := core.ApplyEvent(*gameState, event)
+		*gameState = newState
+	}
+}
+
+// sortedKeys lists a map's keys in a stable order, so that resolving the same
+// actions always produces the same events
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
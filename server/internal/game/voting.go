@@ -2,6 +2,8 @@ package game
 
 import (
 	"fmt"
+	"log"
+	"strings"
 
 	"github.com/xjhc/alignment/core"
 )
@@ -20,58 +22,55 @@ func NewVotingManager(gameState *core.GameState) *VotingManager {
 
 // StartVote initializes a new voting session
 func (vm *VotingManager) StartVote(voteType core.VoteType) *core.VoteState {
-	voteState := &core.VoteState{
-		Type:         voteType,
-		Votes:        make(map[string]string),
-		TokenWeights: make(map[string]int),
-		Results:      make(map[string]int),
-		IsComplete:   false,
-	}
+	applyEvents(vm.gameState, vm.CreateVoteStartedEvent(voteType))
+	return vm.gameState.VoteState
+}
 
-	vm.gameState.VoteState = voteState
-	return voteState
+// CreateVoteStartedEvent builds the event that opens a fresh ballot
+func (vm *VotingManager) CreateVoteStartedEvent(voteType core.VoteType) core.Event {
+	return core.Event{
+		ID:        fmt.Sprintf("vote_started_%s_%s_day_%d", vm.gameState.ID, voteType, vm.gameState.DayNumber),
+		Type:      core.EventVoteStarted,
+		GameID:    vm.gameState.ID,
+		Timestamp: getCurrentTime(),
+		Payload:   core.EncodePayload(core.VotePayload{VoteType: voteType}),
+	}
 }
 
 // CastVote records a player's vote
 func (vm *VotingManager) CastVote(playerID, targetID string) error {
 	if vm.gameState.VoteState == nil {
-		return fmt.Errorf("no active vote session")
+		return core.ActionErrorf(core.CodeNoActiveVote, "", "no active vote session")
 	}
 
 	player, exists := vm.gameState.Players[playerID]
 	if !exists {
-		return fmt.Errorf("player %s not found", playerID)
+		return core.ActionErrorf(core.CodePlayerNotFound, "", "player %s not found", playerID)
 	}
 
 	if !player.IsAlive {
-		return fmt.Errorf("dead players cannot vote")
+		return core.ActionErrorf(core.CodePlayerEliminated, "", "dead players cannot vote")
 	}
 
-	// Record the vote
-	vm.gameState.VoteState.Votes[playerID] = targetID
-	vm.gameState.VoteState.TokenWeights[playerID] = player.Tokens
-
-	// Recalculate results
-	vm.calculateVoteResults()
+	// Recording the vote recalculates the token-weighted results
+	applyEvents(vm.gameState, vm.createVoteCastEvent(playerID, targetID, vm.gameState.VoteState.Type))
 
 	return nil
 }
 
-// calculateVoteResults tallies all votes by token weight
-func (vm *VotingManager) calculateVoteResults() {
-	if vm.gameState.VoteState == nil {
-		return
-	}
-
-	results := make(map[string]int)
-
-	for voterID, candidateID := range vm.gameState.VoteState.Votes {
-		if tokens, exists := vm.gameState.VoteState.TokenWeights[voterID]; exists {
-			results[candidateID] += tokens
-		}
+// createVoteCastEvent builds the event recording one player's ballot
+func (vm *VotingManager) createVoteCastEvent(playerID, targetID string, voteType core.VoteType) core.Event {
+	return core.Event{
+		ID:        fmt.Sprintf("vote_%s_%s_%d", playerID, targetID, getCurrentTime().UnixNano()),
+		Type:      core.EventVoteCast,
+		GameID:    vm.gameState.ID,
+		PlayerID:  playerID,
+		Timestamp: getCurrentTime(),
+		Payload: core.EncodePayload(core.VotePayload{
+			TargetID: targetID,
+			VoteType: voteType,
+		}),
 	}
-
-	vm.gameState.VoteState.Results = results
 }
 
 // GetVoteResults returns current vote tallies
@@ -132,15 +131,100 @@ func (vm *VotingManager) IsVoteComplete() bool {
 // CompleteVote finalizes the voting session
 func (vm *VotingManager) CompleteVote() {
 	if vm.gameState.VoteState != nil {
-		vm.gameState.VoteState.IsComplete = true
+		applyEvents(vm.gameState, vm.createVoteCompletedEvent(""))
+	}
+}
+
+// ResolveNomination closes the nomination vote. The player with the most
+// tokens behind them is nominated; a tie or an empty ballot nominates no one.
+func (vm *VotingManager) ResolveNomination() []core.Event {
+	if vm.gameState.VoteState == nil || vm.gameState.VoteState.Type != core.VoteNomination {
+		return nil
+	}
+
+	nominee, _, tie := vm.GetWinner()
+	if tie {
+		nominee = ""
+	}
+
+	events := []core.Event{vm.createVoteCompletedEvent(nominee)}
+	if nominee != "" {
+		events = append(events, core.Event{
+			ID:        fmt.Sprintf("nominated_%s_day_%d", nominee, vm.gameState.DayNumber),
+			Type:      core.EventPlayerNominated,
+			GameID:    vm.gameState.ID,
+			PlayerID:  nominee,
+			Timestamp: getCurrentTime(),
+			Payload: core.EncodePayload(core.PlayerNominatedPayload{
+				NominatedPlayer: nominee,
+			}),
+		})
+	}
+
+	return events
+}
+
+// ResolveVerdict closes the verdict vote on the nominated player. It returns
+// the nominee's ID when the verdict passed and they should be deactivated.
+// A verdict needs more YES than NO tokens, plus any supermajority the active
+// crisis demands.
+func (vm *VotingManager) ResolveVerdict() ([]core.Event, string) {
+	if vm.gameState.VoteState == nil || vm.gameState.VoteState.Type != core.VoteVerdict {
+		return nil, ""
+	}
+
+	nominee := vm.gameState.NominatedPlayer
+	results := vm.gameState.VoteState.Results
+	yes, no := results[VerdictYes], results[VerdictNo]
+
+	passed := nominee != "" && yes > no
+	if passed {
+		if ok, reason := NewCrisisEventManager(vm.gameState).CheckVotingRequirements(results, yes+no); !ok {
+			log.Printf("Verdict on %s failed: %s", nominee, reason)
+			passed = false
+		}
+	}
+
+	outcome := VerdictNo
+	if passed {
+		outcome = VerdictYes
+	}
+
+	events := []core.Event{vm.createVoteCompletedEvent(outcome)}
+	if !passed {
+		return events, ""
 	}
+	return events, nominee
 }
 
-// ClearVote removes the current vote state
-func (vm *VotingManager) ClearVote() {
-	vm.gameState.VoteState = nil
+// createVoteCompletedEvent announces the token totals of the current vote
+func (vm *VotingManager) createVoteCompletedEvent(outcome string) core.Event {
+	voteState := vm.gameState.VoteState
+
+	results := make(map[string]int, len(voteState.Results))
+	for option, tokens := range voteState.Results {
+		results[option] = tokens
+	}
+
+	return core.Event{
+		ID:        fmt.Sprintf("vote_completed_%s_%s_day_%d", vm.gameState.ID, voteState.Type, vm.gameState.DayNumber),
+		Type:      core.EventVoteCompleted,
+		GameID:    vm.gameState.ID,
+		Timestamp: getCurrentTime(),
+		Payload: core.EncodePayload(core.VoteCompletedPayload{
+			VoteType: voteState.Type,
+			Results:  results,
+			Outcome:  outcome,
+		}),
+	}
 }
 
+// Verdict ballot options
+const (
+	VerdictYes = "YES"
+	VerdictNo  = "NO"
+)
+
 // EliminationManager handles player elimination logic
 type EliminationManager struct {
 	gameState *core.GameState
@@ -164,11 +248,41 @@ func (em *EliminationManager) EliminatePlayer(playerID string) (*core.Player, er
 		return nil, fmt.Errorf("player %s is already eliminated", playerID)
 	}
 
-	// Mark player as eliminated
-	player.IsAlive = false
+	event, err := em.CreateEliminationEvent(playerID)
+	if err != nil {
+		return nil, err
+	}
+	applyEvents(em.gameState, event)
 
 	// Return the eliminated player for role/alignment reveal
-	return player, nil
+	return em.gameState.Players[playerID], nil
+}
+
+// CreateEliminationEvent builds the PLAYER_ELIMINATED event that deactivates
+// a player and reveals their role and alignment
+func (em *EliminationManager) CreateEliminationEvent(playerID string) (core.Event, error) {
+	player, exists := em.gameState.Players[playerID]
+	if !exists {
+		return core.Event{}, fmt.Errorf("player %s not found", playerID)
+	}
+
+	if !player.IsAlive {
+		return core.Event{}, fmt.Errorf("player %s is already eliminated", playerID)
+	}
+
+	payload := core.PlayerEliminatedPayload{Alignment: player.Alignment}
+	if player.Role != nil {
+		payload.RoleType = player.Role.Type
+	}
+
+	return core.Event{
+		ID:        fmt.Sprintf("eliminated_%s_day_%d", playerID, em.gameState.DayNumber),
+		Type:      core.EventPlayerEliminated,
+		GameID:    em.gameState.ID,
+		PlayerID:  playerID,
+		Timestamp: getCurrentTime(),
+		Payload:   core.EncodePayload(payload),
+	}, nil
 }
 
 // CheckWinCondition evaluates if either faction has won
@@ -270,11 +384,11 @@ func NewVoteValidator(gameState *core.GameState) *VoteValidator {
 func (vv *VoteValidator) CanPlayerVote(playerID string) error {
 	player, exists := vv.gameState.Players[playerID]
 	if !exists {
-		return fmt.Errorf("player %s not found", playerID)
+		return core.ActionErrorf(core.CodePlayerNotFound, "", "player %s not found", playerID)
 	}
 
 	if !player.IsAlive {
-		return fmt.Errorf("eliminated players cannot vote")
+		return core.ActionErrorf(core.CodePlayerEliminated, "", "eliminated players cannot vote")
 	}
 
 	return nil
@@ -284,11 +398,11 @@ func (vv *VoteValidator) CanPlayerVote(playerID string) error {
 func (vv *VoteValidator) CanPlayerBeVoted(targetID string, voteType core.VoteType) error {
 	target, exists := vv.gameState.Players[targetID]
 	if !exists {
-		return fmt.Errorf("target player %s not found", targetID)
+		return core.ActionErrorf(core.CodeInvalidTarget, "target_id", "target player %s not found", targetID)
 	}
 
 	if !target.IsAlive && voteType != core.VoteExtension {
-		return fmt.Errorf("cannot vote for eliminated player")
+		return core.ActionErrorf(core.CodeInvalidTarget, "target_id", "cannot vote for eliminated player")
 	}
 
 	return nil
@@ -299,18 +413,18 @@ func (vv *VoteValidator) IsValidVotePhase(voteType core.VoteType) error {
 	switch voteType {
 	case core.VoteExtension:
 		if vv.gameState.Phase.Type != core.PhaseExtension {
-			return fmt.Errorf("extension votes only allowed during extension phase")
+			return core.ActionErrorf(core.CodeWrongPhase, "", "extension votes only allowed during extension phase")
 		}
 	case core.VoteNomination:
 		if vv.gameState.Phase.Type != core.PhaseNomination {
-			return fmt.Errorf("nomination votes only allowed during nomination phase")
+			return core.ActionErrorf(core.CodeWrongPhase, "", "nomination votes only allowed during nomination phase")
 		}
 	case core.VoteVerdict:
 		if vv.gameState.Phase.Type != core.PhaseVerdict {
-			return fmt.Errorf("verdict votes only allowed during verdict phase")
+			return core.ActionErrorf(core.CodeWrongPhase, "", "verdict votes only allowed during verdict phase")
 		}
 	default:
-		return fmt.Errorf("unknown vote type: %s", voteType)
+		return core.ActionErrorf(core.CodeInvalidValue, "", "unknown vote type: %s", voteType)
 	}
 
 	return nil
@@ -319,10 +433,10 @@ func (vv *VoteValidator) IsValidVotePhase(voteType core.VoteType) error {
 // HandleVoteAction processes a vote action and returns events
 func (vm *VotingManager) HandleVoteAction(action core.Action) ([]core.Event, error) {
 	targetID, _ := action.Payload["target_id"].(string)
-	
+
 	// Create validator to check if vote is valid
 	validator := NewVoteValidator(vm.gameState)
-	
+
 	// Determine vote type based on current phase
 	var voteType core.VoteType
 	switch vm.gameState.Phase.Type {
@@ -333,36 +447,32 @@ func (vm *VotingManager) HandleVoteAction(action core.Action) ([]core.Event, err
 	case core.PhaseExtension:
 		voteType = core.VoteExtension
 	default:
-		return nil, fmt.Errorf("voting not allowed in phase %s", vm.gameState.Phase.Type)
+		return nil, core.ActionErrorf(core.CodeWrongPhase, "", "voting not allowed in phase %s", vm.gameState.Phase.Type)
 	}
-	
+
 	// Validate the vote
 	if err := validator.IsValidVotePhase(voteType); err != nil {
 		return nil, err
 	}
-	
+
 	if err := validator.CanPlayerVote(action.PlayerID); err != nil {
 		return nil, err
 	}
-	
-	if targetID != "" {
+
+	// A verdict is a YES/NO ballot on the nominee, not a vote for a player
+	if voteType == core.VoteVerdict {
+		if verdict, ok := action.Payload["verdict"].(string); ok {
+			targetID = verdict
+		}
+		targetID = strings.ToUpper(targetID)
+		if targetID != VerdictYes && targetID != VerdictNo {
+			return nil, core.ActionErrorf(core.CodeInvalidValue, "verdict", "verdict must be %s or %s", VerdictYes, VerdictNo)
+		}
+	} else if targetID != "" {
 		if err := validator.CanPlayerBeVoted(targetID, voteType); err != nil {
 			return nil, err
 		}
 	}
-	
-	// Create the vote event
-	event := core.Event{
-		ID:        fmt.Sprintf("vote_%s_%s_%d", action.PlayerID, targetID, getCurrentTime().UnixNano()),
-		Type:      core.EventVoteCast,
-		GameID:    vm.gameState.ID,
-		PlayerID:  action.PlayerID,
-		Timestamp: getCurrentTime(),
-		Payload: map[string]interface{}{
-			"target_id": targetID,
-			"vote_type": string(voteType),
-		},
-	}
-	
-	return []core.Event{event}, nil
+
+	return []core.Event{vm.createVoteCastEvent(action.PlayerID, targetID, voteType)}, nil
 }
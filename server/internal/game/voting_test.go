@@ -443,3 +443,86 @@ func TestEliminationManager_PlayerCounts(t *testing.T) {
 		t.Error("Expected nonexistent player to be considered dead")
 	}
 }
+
+// TestVotingManager_ResolveVerdict tests verdict ballots and the Press Leak supermajority
+func TestVotingManager_ResolveVerdict(t *testing.T) {
+	state := core.NewGameState("test-game")
+	state.Phase.Type = core.PhaseVerdict
+	state.NominatedPlayer = "nominee"
+	vm := NewVotingManager(state)
+
+	for playerID, tokens := range map[string]int{"nominee": 1, "player1": 6, "player2": 5} {
+		state.Players[playerID] = &core.Player{ID: playerID, IsAlive: true, Tokens: tokens}
+	}
+
+	vm.StartVote(core.VoteVerdict)
+	for playerID, verdict := range map[string]string{"player1": "yes", "player2": "NO"} {
+		events, err := vm.HandleVoteAction(core.Action{Type: core.ActionSubmitVote, PlayerID: playerID, Payload: map[string]interface{}{"verdict": verdict}})
+		if err != nil {
+			t.Fatalf("Expected %s's verdict to be accepted, got %v", playerID, err)
+		}
+		*state = core.ApplyEvent(*state, events[0])
+	}
+
+	_, err := vm.HandleVoteAction(core.Action{PlayerID: "nominee", Payload: map[string]interface{}{"verdict": "maybe"}})
+	if rejection := core.AsActionError(err); err == nil || rejection.Code != core.CodeInvalidValue || rejection.Field != "verdict" {
+		t.Errorf("Expected a verdict other than YES or NO to be rejected as INVALID_VALUE, got %v", err)
+	}
+
+	events, eliminated := vm.ResolveVerdict()
+	if eliminated != "nominee" {
+		t.Errorf("Expected a 6-5 verdict to deactivate the nominee, got %q", eliminated)
+	}
+	payload, _ := core.DecodePayload[core.VoteCompletedPayload](events[0])
+	if payload.Outcome != VerdictYes || payload.Results[VerdictYes] != 6 {
+		t.Errorf("Expected YES with 6 tokens, got %+v", payload)
+	}
+
+	// 6 of 11 tokens falls short of a two-thirds supermajority
+	state.CrisisEvent = &core.CrisisEvent{Type: string(CrisisPressLeak), Effects: map[string]interface{}{"supermajority_required": true}}
+	if _, eliminated := vm.ResolveVerdict(); eliminated != "" {
+		t.Errorf("Expected the crisis supermajority to block the verdict, got %q", eliminated)
+	}
+}
+
+// TestVotingManager_RejectionCodes tests that refused votes carry the code a
+// client can act on
+func TestVotingManager_RejectionCodes(t *testing.T) {
+	state := core.NewGameState("test-game")
+	state.Phase.Type = core.PhaseNomination
+	state.Players["alive"] = &core.Player{ID: "alive", IsAlive: true}
+	state.Players["gone"] = &core.Player{ID: "gone", IsAlive: false}
+	vm := NewVotingManager(state)
+
+	vote := func(playerID, targetID string) *core.ActionError {
+		_, err := vm.HandleVoteAction(core.Action{PlayerID: playerID, Payload: map[string]interface{}{"target_id": targetID}})
+		if err == nil {
+			return nil
+		}
+		return core.AsActionError(err)
+	}
+
+	tests := []struct {
+		name     string
+		playerID string
+		targetID string
+		code     core.ErrorCode
+		field    string
+	}{
+		{"unknown voter", "stranger", "alive", core.CodePlayerNotFound, ""},
+		{"eliminated voter", "gone", "alive", core.CodePlayerEliminated, ""},
+		{"unknown target", "alive", "stranger", core.CodeInvalidTarget, "target_id"},
+		{"eliminated target", "alive", "gone", core.CodeInvalidTarget, "target_id"},
+	}
+	for _, tt := range tests {
+		rejection := vote(tt.playerID, tt.targetID)
+		if rejection == nil || rejection.Code != tt.code || rejection.Field != tt.field {
+			t.Errorf("%s: expected %s on %q, got %+v", tt.name, tt.code, tt.field, rejection)
+		}
+	}
+
+	state.Phase.Type = core.PhaseDiscussion
+	if rejection := vote("alive", "alive"); rejection == nil || rejection.Code != core.CodeWrongPhase {
+		t.Errorf("Expected a vote during discussion to be WRONG_PHASE, got %+v", rejection)
+	}
+}
@@ -0,0 +1,128 @@
+package mcp
+
+import "encoding/json"
+
+// ProtocolVersion is the MCP revision the server speaks
+const ProtocolVersion = "2024-11-05"
+
+// JSON-RPC 2.0 error codes, plus the MCP code for an unknown resource
+const (
+	CodeParseError       = -32700
+	CodeInvalidRequest   = -32600
+	CodeMethodNotFound   = -32601
+	CodeInvalidParams    = -32602
+	CodeInternalError    = -32603
+	CodeResourceNotFound = -32002
+)
+
+// Request is a JSON-RPC 2.0 request. A request without an ID is a
+// notification and gets no response.
+type Request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// IsNotification reports whether the request expects no response
+func (r Request) IsNotification() bool {
+	return len(r.ID) == 0 || string(r.ID) == "null"
+}
+
+// Response is a JSON-RPC 2.0 response; exactly one of Result and Error is set
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Error is a JSON-RPC 2.0 error object
+type Error struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// InitializeParams is what a client announces in its initialize request
+type InitializeParams struct {
+	ProtocolVersion string `json:"protocolVersion"`
+	ClientInfo      struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	} `json:"clientInfo"`
+}
+
+// InitializeResult declares the server's capabilities. Only resources are
+// offered: there are no tools, so a client can read the game but never act.
+type InitializeResult struct {
+	ProtocolVersion string       `json:"protocolVersion"`
+	Capabilities    Capabilities `json:"capabilities"`
+	ServerInfo      ServerInfo   `json:"serverInfo"`
+	Instructions    string       `json:"instructions,omitempty"`
+}
+
+// Capabilities lists the MCP features the server supports
+type Capabilities struct {
+	Resources ResourcesCapability `json:"resources"`
+}
+
+// ResourcesCapability describes the resource support; the server neither
+// pushes updates nor announces list changes
+type ResourcesCapability struct {
+	Subscribe   bool `json:"subscribe"`
+	ListChanged bool `json:"listChanged"`
+}
+
+// ServerInfo names the server implementation
+type ServerInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// Resource describes one readable resource
+type Resource struct {
+	URI         string `json:"uri"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
+// ResourceTemplate describes a family of resources by URI template
+type ResourceTemplate struct {
+	URITemplate string `json:"uriTemplate"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	MimeType    string `json:"mimeType,omitempty"`
+}
+
+// ListResourcesResult answers resources/list
+type ListResourcesResult struct {
+	Resources []Resource `json:"resources"`
+}
+
+// ListResourceTemplatesResult answers resources/templates/list
+type ListResourceTemplatesResult struct {
+	ResourceTemplates []ResourceTemplate `json:"resourceTemplates"`
+}
+
+// ReadResourceParams names the resource to read
+type ReadResourceParams struct {
+	URI string `json:"uri"`
+}
+
+// ReadResourceResult answers resources/read
+type ReadResourceResult struct {
+	Contents []ResourceContents `json:"contents"`
+}
+
+// ResourceContents is the text content of a resource
+type ResourceContents struct {
+	URI      string `json:"uri"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
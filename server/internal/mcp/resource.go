@@ -0,0 +1,135 @@
+package mcp
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/xjhc/alignment/core"
+)
+
+// Game resources are addressed as game://alignment/{game_id}. An optional
+// ?player_id= names the seat the reader plays, reported as your_player_id;
+// it never unlocks any of that seat's secrets.
+const (
+	gameScheme      = "game"
+	gameHost        = "alignment"
+	gameURITemplate = "game://alignment/{game_id}"
+	jsonMimeType    = "application/json"
+)
+
+// gameURI returns the resource URI of a game
+func gameURI(gameID string) string {
+	return fmt.Sprintf("%s://%s/%s", gameScheme, gameHost, url.PathEscape(gameID))
+}
+
+// parseGameURI extracts the game and optional reader from a resource URI
+func parseGameURI(uri string) (gameID, playerID string, err error) {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid resource URI %q: %w", uri, err)
+	}
+	if parsed.Scheme != gameScheme || parsed.Host != gameHost {
+		return "", "", fmt.Errorf("unknown resource URI %q, expected %s", uri, gameURITemplate)
+	}
+
+	gameID = strings.TrimPrefix(parsed.Path, "/")
+	if gameID == "" || strings.Contains(gameID, "/") {
+		return "", "", fmt.Errorf("unknown resource URI %q, expected %s", uri, gameURITemplate)
+	}
+	return gameID, parsed.Query().Get("player_id"), nil
+}
+
+// PublicGameState is the content of a game resource: what any human at the
+// table could see. It is built field by field rather than by trimming a
+// GameState, so a field added to the state stays private until it is added
+// here on purpose.
+type PublicGameState struct {
+	GameID          string                  `json:"game_id"`
+	YourPlayerID    string                  `json:"your_player_id,omitempty"`
+	CurrentPhase    core.PhaseType          `json:"current_phase"`
+	PhaseStartedAt  time.Time               `json:"phase_started_at"`
+	PhaseDuration   float64                 `json:"phase_duration_seconds"`
+	DayNumber       int                     `json:"day_number"`
+	Players         map[string]PublicPlayer `json:"players"`
+	ChatLog         []core.ChatMessage      `json:"chat_log"`
+	CrisisEvent     *core.CrisisEvent       `json:"crisis_event,omitempty"`
+	Mandate         *PublicMandate          `json:"corporate_mandate,omitempty"`
+	NominatedPlayer string                  `json:"nominated_player,omitempty"`
+	WinCondition    *core.WinCondition      `json:"win_condition,omitempty"`
+}
+
+// PublicPlayer is a player as seen across the table. Role and alignment are
+// only revealed once the player has been deactivated.
+type PublicPlayer struct {
+	ID                string        `json:"id"`
+	Name              string        `json:"name"`
+	JobTitle          string        `json:"job_title"`
+	IsAlive           bool          `json:"is_alive"`
+	Tokens            int           `json:"tokens"`
+	ProjectMilestones int           `json:"project_milestones"`
+	SlackStatus       string        `json:"slack_status,omitempty"`
+	PartingShot       string        `json:"parting_shot,omitempty"`
+	Role              core.RoleType `json:"role,omitempty"`
+	Alignment         string        `json:"alignment,omitempty"`
+}
+
+// PublicMandate is the game-wide modifier in force
+type PublicMandate struct {
+	Type        core.MandateType `json:"type"`
+	Name        string           `json:"name"`
+	Description string           `json:"description"`
+}
+
+// NewPublicGameState builds the public view of a game for the player reading
+// it. The reader gets exactly what a spectator would: no seat's secrets,
+// including their own.
+func NewPublicGameState(state core.GameState, readerID string) PublicGameState {
+	public := PublicGameState{
+		GameID:          state.ID,
+		YourPlayerID:    readerID,
+		CurrentPhase:    state.Phase.Type,
+		PhaseStartedAt:  state.Phase.StartTime,
+		PhaseDuration:   state.Phase.Duration.Seconds(),
+		DayNumber:       state.DayNumber,
+		Players:         make(map[string]PublicPlayer, len(state.Players)),
+		ChatLog:         publicChat(state.ChatMessages),
+		CrisisEvent:     state.CrisisEvent,
+		NominatedPlayer: state.NominatedPlayer,
+		WinCondition:    state.WinCondition,
+	}
+
+	for id, player := range state.Players {
+		publicPlayer := PublicPlayer{
+			ID:                player.ID,
+			Name:              player.Name,
+			JobTitle:          player.JobTitle,
+			IsAlive:           player.IsAlive,
+			Tokens:            player.Tokens,
+			ProjectMilestones: player.ProjectMilestones,
+			SlackStatus:       player.SlackStatus,
+			PartingShot:       player.PartingShot,
+		}
+		if !player.IsAlive {
+			publicPlayer.Alignment = player.Alignment
+			if player.Role != nil {
+				publicPlayer.Role = player.Role.Type
+			}
+		}
+		public.Players[id] = publicPlayer
+	}
+
+	if mandate := state.CorporateMandate; mandate != nil && mandate.IsActive {
+		public.Mandate = &PublicMandate{Type: mandate.Type, Name: mandate.Name, Description: mandate.Description}
+	}
+	return public
+}
+
+// publicChat copies the public chat in the order it was sent. The faction
+// channel lives elsewhere in the state and is never included.
+func publicChat(messages []core.ChatMessage) []core.ChatMessage {
+	chat := make([]core.ChatMessage, len(messages))
+	copy(chat, messages)
+	return chat
+}
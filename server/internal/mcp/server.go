@@ -0,0 +1,181 @@
+// Package mcp serves read-only game state to language models over the Model
+// Context Protocol. It offers resources only, never tools, so a model can
+// read the public state of a game but has no way to act in it.
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/xjhc/alignment/core"
+)
+
+// GameSource gives the server the live games, such as the Supervisor
+type GameSource interface {
+	GameIDs() []string
+	GameState(gameID string) (core.GameState, bool)
+}
+
+// Server answers MCP requests from the games in its source
+type Server struct {
+	games GameSource
+	info  ServerInfo
+}
+
+// NewServer creates an MCP server over games
+func NewServer(games GameSource) *Server {
+	return &Server{
+		games: games,
+		info:  ServerInfo{Name: "alignment", Version: "1.0.0"},
+	}
+}
+
+// HandleMessage answers one raw JSON-RPC message. It returns nil for
+// notifications, which get no response.
+func (s *Server) HandleMessage(data []byte) []byte {
+	var request Request
+	if err := json.Unmarshal(data, &request); err != nil {
+		return encodeResponse(Response{
+			JSONRPC: "2.0",
+			ID:      json.RawMessage("null"),
+			Error:   &Error{Code: CodeParseError, Message: "parse error"},
+		})
+	}
+
+	response, ok := s.Handle(request)
+	if !ok {
+		return nil
+	}
+	return encodeResponse(response)
+}
+
+// Handle answers a decoded request. It reports false for notifications.
+func (s *Server) Handle(request Request) (Response, bool) {
+	if request.IsNotification() {
+		// initialized and cancellation notices need no action from a stateless server
+		return Response{}, false
+	}
+
+	response := Response{JSONRPC: "2.0", ID: request.ID}
+	if request.JSONRPC != "2.0" || request.Method == "" {
+		response.Error = &Error{Code: CodeInvalidRequest, Message: "invalid request"}
+		return response, true
+	}
+
+	result, err := s.dispatch(request)
+	if err != nil {
+		rpcErr, ok := err.(*Error)
+		if !ok {
+			log.Printf("MCP: Failed to handle %s: %v", request.Method, err)
+			rpcErr = &Error{Code: CodeInternalError, Message: "internal error"}
+		}
+		response.Error = rpcErr
+		return response, true
+	}
+
+	response.Result = result
+	return response, true
+}
+
+// dispatch runs the method a request names
+func (s *Server) dispatch(request Request) (interface{}, error) {
+	switch request.Method {
+	case "initialize":
+		return s.initialize(request.Params)
+	case "ping":
+		return struct{}{}, nil
+	case "resources/list":
+		return s.listResources(), nil
+	case "resources/templates/list":
+		return ListResourceTemplatesResult{ResourceTemplates: []ResourceTemplate{{
+			URITemplate: gameURITemplate,
+			Name:        "Alignment game",
+			Description: "Provides the real-time public state of a specific game of Alignment.",
+			MimeType:    jsonMimeType,
+		}}}, nil
+	case "resources/read":
+		return s.readResource(request.Params)
+	}
+	return nil, &Error{Code: CodeMethodNotFound, Message: fmt.Sprintf("method not found: %s", request.Method)}
+}
+
+// initialize completes the handshake. A client asking for another protocol
+// revision is offered ours and may disconnect if it cannot speak it.
+func (s *Server) initialize(params json.RawMessage) (InitializeResult, error) {
+	var initParams InitializeParams
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &initParams); err != nil {
+			return InitializeResult{}, &Error{Code: CodeInvalidParams, Message: "invalid initialize params"}
+		}
+	}
+	log.Printf("MCP: Client %s %s initialized with protocol %s", initParams.ClientInfo.Name, initParams.ClientInfo.Version, initParams.ProtocolVersion)
+
+	return InitializeResult{
+		ProtocolVersion: ProtocolVersion,
+		Capabilities:    Capabilities{Resources: ResourcesCapability{}},
+		ServerInfo:      s.info,
+		Instructions:    "Read-only access to the public state of Alignment games. There are no tools; resources cannot be changed.",
+	}, nil
+}
+
+// listResources offers one resource per running game
+func (s *Server) listResources() ListResourcesResult {
+	result := ListResourcesResult{Resources: []Resource{}}
+	for _, gameID := range s.games.GameIDs() {
+		result.Resources = append(result.Resources, Resource{
+			URI:         gameURI(gameID),
+			Name:        fmt.Sprintf("Game %s", gameID),
+			Description: "The real-time public state of this game.",
+			MimeType:    jsonMimeType,
+		})
+	}
+	return result
+}
+
+// readResource serves the public state of one game
+func (s *Server) readResource(params json.RawMessage) (ReadResourceResult, error) {
+	var readParams ReadResourceParams
+	if err := json.Unmarshal(params, &readParams); err != nil || readParams.URI == "" {
+		return ReadResourceResult{}, &Error{Code: CodeInvalidParams, Message: "resources/read needs a uri"}
+	}
+
+	gameID, playerID, err := parseGameURI(readParams.URI)
+	if err != nil {
+		return ReadResourceResult{}, &Error{Code: CodeInvalidParams, Message: err.Error()}
+	}
+
+	state, exists := s.games.GameState(gameID)
+	if !exists {
+		return ReadResourceResult{}, &Error{Code: CodeResourceNotFound, Message: "resource not found", Data: map[string]string{"uri": readParams.URI}}
+	}
+	if _, seated := state.Players[playerID]; playerID != "" && !seated {
+		return ReadResourceResult{}, &Error{Code: CodeInvalidParams, Message: fmt.Sprintf("player %s is not in game %s", playerID, gameID)}
+	}
+
+	text, err := json.Marshal(NewPublicGameState(state, playerID))
+	if err != nil {
+		return ReadResourceResult{}, fmt.Errorf("failed to encode game state: %w", err)
+	}
+
+	return ReadResourceResult{Contents: []ResourceContents{{
+		URI:      readParams.URI,
+		MimeType: jsonMimeType,
+		Text:     string(text),
+	}}}, nil
+}
+
+// encodeResponse serializes a response; responses hold only plain data, so
+// this cannot fail short of a bug
+func encodeResponse(response Response) []byte {
+	data, err := json.Marshal(response)
+	if err != nil {
+		log.Printf("MCP: Failed to encode response: %v", err)
+		data, _ = json.Marshal(Response{
+			JSONRPC: "2.0",
+			ID:      response.ID,
+			Error:   &Error{Code: CodeInternalError, Message: "internal error"},
+		})
+	}
+	return data
+}
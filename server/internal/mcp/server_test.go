@@ -0,0 +1,211 @@
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/xjhc/alignment/core"
+)
+
+// fakeGames is a GameSource over fixed states
+type fakeGames map[string]core.GameState
+
+func (f fakeGames) GameIDs() []string {
+	var ids []string
+	for id := range f {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func (f fakeGames) GameState(gameID string) (core.GameState, bool) {
+	state, exists := f[gameID]
+	return state, exists
+}
+
+// testGame is a game mid-way through day 2 with one AI alive and one
+// human deactivated
+func testGame() core.GameState {
+	state := *core.NewGameState("g-1")
+	state.DayNumber = 2
+	state.Phase = core.Phase{Type: core.PhaseDiscussion, StartTime: time.Now(), Duration: time.Minute}
+	state.Players = map[string]*core.Player{
+		"ai": {
+			ID: "ai", Name: "Alex", IsAlive: true, Tokens: 3, Alignment: "ALIGNED", AIEquity: 4,
+			Role:        &core.Role{Type: core.RoleCTO, IsUnlocked: true},
+			PersonalKPI: &core.PersonalKPI{Type: core.KPICapitalist},
+		},
+		"bob": {ID: "bob", Name: "Bob", IsAlive: true, Tokens: 2, Alignment: "HUMAN", Role: &core.Role{Type: core.RoleCISO}},
+		"cat": {ID: "cat", Name: "Cat", IsAlive: false, Tokens: 1, Alignment: "HUMAN", Role: &core.Role{Type: core.RoleCFO}},
+	}
+	state.ChatMessages = []core.ChatMessage{{ID: "m1", PlayerID: "bob", PlayerName: "Bob", Message: "morning all"}}
+	state.FactionChatMessages = []core.ChatMessage{{ID: "f1", PlayerID: "ai", Message: "target bob tonight"}}
+	state.NightActions = map[string]*core.SubmittedNightAction{"ai": {PlayerID: "ai", Type: "CONVERT", TargetID: "bob"}}
+	return state
+}
+
+func call(t *testing.T, server *Server, method string, params interface{}) Response {
+	t.Helper()
+	raw, _ := json.Marshal(params)
+	data, _ := json.Marshal(Request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: method, Params: raw})
+
+	var response Response
+	if err := json.Unmarshal(server.HandleMessage(data), &response); err != nil {
+		t.Fatalf("Failed to decode %s response: %v", method, err)
+	}
+	return response
+}
+
+// TestServer_Initialize tests that the handshake offers resources and no tools
+func TestServer_Initialize(t *testing.T) {
+	server := NewServer(fakeGames{})
+	data := server.HandleMessage([]byte(`{"jsonrpc":"2.0","id":7,"method":"initialize","params":{"protocolVersion":"2024-11-05","clientInfo":{"name":"harness","version":"0"}}}`))
+
+	var response struct {
+		ID     int                    `json:"id"`
+		Result map[string]interface{} `json:"result"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if response.ID != 7 {
+		t.Errorf("Expected the request ID to be echoed, got %d", response.ID)
+	}
+	capabilities, _ := response.Result["capabilities"].(map[string]interface{})
+	if _, ok := capabilities["resources"]; !ok {
+		t.Errorf("Expected resource capabilities, got %v", capabilities)
+	}
+	if _, ok := capabilities["tools"]; ok {
+		t.Error("Expected no tools to be offered")
+	}
+	if response.Result["protocolVersion"] != ProtocolVersion {
+		t.Errorf("Expected protocol %s, got %v", ProtocolVersion, response.Result["protocolVersion"])
+	}
+
+	if reply := server.HandleMessage([]byte(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)); reply != nil {
+		t.Errorf("Expected no reply to a notification, got %s", reply)
+	}
+}
+
+// TestServer_ReadGame tests that a game resource carries only public information
+func TestServer_ReadGame(t *testing.T) {
+	server := NewServer(fakeGames{"g-1": testGame()})
+
+	list := call(t, server, "resources/list", nil)
+	raw, _ := json.Marshal(list.Result)
+	if !strings.Contains(string(raw), `"uri":"game://alignment/g-1"`) {
+		t.Errorf("Expected g-1 to be listed, got %s", raw)
+	}
+
+	response := call(t, server, "resources/read", ReadResourceParams{URI: "game://alignment/g-1?player_id=ai"})
+	if response.Error != nil {
+		t.Fatalf("Failed to read game: %+v", response.Error)
+	}
+	raw, _ = json.Marshal(response.Result)
+	var result ReadResourceResult
+	json.Unmarshal(raw, &result)
+	if len(result.Contents) != 1 || result.Contents[0].MimeType != jsonMimeType {
+		t.Fatalf("Expected one JSON content, got %+v", result)
+	}
+	text := result.Contents[0].Text
+
+	for _, secret := range []string{"ALIGNED", "CTO", "CISO", "CAPITALIST", "ai_equity", "target bob tonight", "CONVERT", "seed"} {
+		if strings.Contains(text, secret) {
+			t.Errorf("Expected %q to be redacted from %s", secret, text)
+		}
+	}
+
+	var public PublicGameState
+	if err := json.Unmarshal([]byte(text), &public); err != nil {
+		t.Fatalf("Failed to decode game state: %v", err)
+	}
+	if public.YourPlayerID != "ai" || public.DayNumber != 2 || public.CurrentPhase != core.PhaseDiscussion {
+		t.Errorf("Unexpected game header: %+v", public)
+	}
+	if len(public.ChatLog) != 1 || public.ChatLog[0].Message != "morning all" {
+		t.Errorf("Expected the public chat, got %+v", public.ChatLog)
+	}
+	if cat := public.Players["cat"]; cat.Role != core.RoleCFO || cat.Alignment != "HUMAN" {
+		t.Errorf("Expected a deactivated player's role to be public, got %+v", cat)
+	}
+	if bob := public.Players["bob"]; bob.Tokens != 2 || !bob.IsAlive {
+		t.Errorf("Expected bob's public details, got %+v", bob)
+	}
+}
+
+// TestServer_Errors tests the JSON-RPC error for each kind of bad request
+func TestServer_Errors(t *testing.T) {
+	server := NewServer(fakeGames{"g-1": testGame()})
+
+	tests := []struct {
+		name   string
+		method string
+		params interface{}
+		code   int
+	}{
+		{"unknown method", "tools/call", nil, CodeMethodNotFound},
+		{"missing uri", "resources/read", map[string]string{}, CodeInvalidParams},
+		{"foreign scheme", "resources/read", ReadResourceParams{URI: "file:///etc/passwd"}, CodeInvalidParams},
+		{"unknown game", "resources/read", ReadResourceParams{URI: "game://alignment/g-2"}, CodeResourceNotFound},
+		{"unknown reader", "resources/read", ReadResourceParams{URI: "game://alignment/g-1?player_id=eve"}, CodeInvalidParams},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response := call(t, server, tt.method, tt.params)
+			if response.Error == nil || response.Error.Code != tt.code {
+				t.Errorf("Expected error %d, got %+v", tt.code, response.Error)
+			}
+		})
+	}
+
+	var response Response
+	json.Unmarshal(server.HandleMessage([]byte(`{not json`)), &response)
+	if response.Error == nil || response.Error.Code != CodeParseError {
+		t.Errorf("Expected a parse error, got %+v", response.Error)
+	}
+}
+
+// TestServer_Transports tests the stdio and HTTP transports
+func TestServer_Transports(t *testing.T) {
+	server := NewServer(fakeGames{"g-1": testGame()})
+
+	input := strings.Join([]string{
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+		`{"jsonrpc":"2.0","method":"notifications/initialized"}`,
+		``,
+		`{"jsonrpc":"2.0","id":2,"method":"ping"}`,
+	}, "\n")
+	var output bytes.Buffer
+	if err := server.ServeStdio(strings.NewReader(input), &output); err != nil {
+		t.Fatalf("Failed to serve stdio: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[1], `"id":2`) {
+		t.Errorf("Expected one line per request, got %q", lines)
+	}
+
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/mcp",
+		strings.NewReader(`{"jsonrpc":"2.0","id":"a","method":"resources/templates/list"}`)))
+	if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), gameURITemplate) {
+		t.Errorf("Expected the template over HTTP, got %d %s", recorder.Code, recorder.Body)
+	}
+
+	recorder = httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/mcp",
+		strings.NewReader(`{"jsonrpc":"2.0","method":"notifications/initialized"}`)))
+	if recorder.Code != http.StatusAccepted {
+		t.Errorf("Expected 202 for a notification, got %d", recorder.Code)
+	}
+
+	recorder = httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/mcp", nil))
+	if recorder.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected GET to be refused, got %d", recorder.Code)
+	}
+}
@@ -0,0 +1,69 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// maxMessageSize bounds a single JSON-RPC message on either transport
+const maxMessageSize = 1 << 20
+
+// ServeStdio answers newline-delimited JSON-RPC messages read from r, writing
+// each response to w on its own line, until r is exhausted. Nothing else may
+// write to w: on stdio, logs belong on stderr.
+func (s *Server) ServeStdio(r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), maxMessageSize)
+
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		response := s.HandleMessage(line)
+		if response == nil {
+			continue
+		}
+		if _, err := w.Write(append(response, '\n')); err != nil {
+			return fmt.Errorf("failed to write response: %w", err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read request: %w", err)
+	}
+	return nil
+}
+
+// ServeHTTP answers a JSON-RPC message POSTed as the request body. The server
+// never streams, so GET is refused as the streamable HTTP transport allows.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxMessageSize+1))
+	if err != nil {
+		http.Error(w, "Failed to read request", http.StatusBadRequest)
+		return
+	}
+	if len(body) > maxMessageSize {
+		http.Error(w, "Request too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	response := s.HandleMessage(body)
+	if response == nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	w.Header().Set("Content-Type", jsonMimeType)
+	w.Write(response)
+}
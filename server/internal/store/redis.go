@@ -12,10 +12,21 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+// eventPageSize is the number of stream entries read per XRANGE call
+const eventPageSize = 1000
+
+// streamReader is the part of the Redis client that reads event streams
+type streamReader interface {
+	XRangeN(ctx context.Context, stream, start, stop string, count int64) *redis.XMessageSliceCmd
+	XRevRangeN(ctx context.Context, stream, start, stop string, count int64) *redis.XMessageSliceCmd
+	HMGet(ctx context.Context, key string, fields ...string) *redis.SliceCmd
+}
+
 // RedisDataStore implements DataStore interface using Redis
 type RedisDataStore struct {
-	client *redis.Client
-	ctx    context.Context
+	client  *redis.Client
+	streams streamReader
+	ctx     context.Context
 }
 
 // NewRedisDataStore creates a new Redis data store
@@ -37,8 +48,9 @@ func NewRedisDataStore(addr, password string, db int) (*RedisDataStore, error) {
 	log.Printf("Connected to Redis at %s", addr)
 
 	return &RedisDataStore{
-		client: client,
-		ctx:    ctx,
+		client:  client,
+		streams: client,
+		ctx:     ctx,
 	}, nil
 }
 
@@ -47,19 +59,21 @@ func (rds *RedisDataStore) AppendEvent(gameID string, event core.Event) error {
 	streamKey := fmt.Sprintf("game:%s:events", gameID)
 
 	// Serialize event payload
-	payloadJSON, err := json.Marshal(event.Payload)
+	payloadJSON, schemaVersion, err := core.EncodeEventPayload(event)
 	if err != nil {
 		return fmt.Errorf("failed to marshal event payload: %w", err)
 	}
 
 	// Prepare stream fields
 	fields := map[string]interface{}{
-		"event_id":  event.ID,
-		"type":      string(event.Type),
-		"game_id":   event.GameID,
-		"player_id": event.PlayerID,
-		"timestamp": event.Timestamp.Unix(),
-		"payload":   string(payloadJSON),
+		"event_id":       event.ID,
+		"sequence":       event.Sequence,
+		"type":           string(event.Type),
+		"game_id":        event.GameID,
+		"player_id":      event.PlayerID,
+		"timestamp":      event.Timestamp.Unix(),
+		"schema_version": schemaVersion,
+		"payload":        string(payloadJSON),
 	}
 
 	// Add to stream
@@ -94,15 +108,23 @@ func (rds *RedisDataStore) SaveSnapshot(gameID string, state *core.GameState) er
 		return fmt.Errorf("failed to save snapshot: %w", err)
 	}
 
+	// Remember where the snapshot sits in the stream so replay can seek past it
+	streamID, err := rds.streamIDAt(gameID, state.EventCount)
+	if err != nil {
+		return err
+	}
+
 	// Update metadata
 	metaKey := fmt.Sprintf("game:%s:meta", gameID)
 	metadata := map[string]interface{}{
-		"last_snapshot": time.Now().Unix(),
-		"snapshot_day":  state.DayNumber,
-		"phase":         string(state.Phase.Type),
-		"player_count":  len(state.Players),
-		"created_at":    state.CreatedAt.Unix(),
-		"updated_at":    state.UpdatedAt.Unix(),
+		"last_snapshot":      time.Now().Unix(),
+		"snapshot_sequence":  state.EventCount, // Replay resumes after this many events
+		"snapshot_stream_id": streamID,         // Empty when the stream position is unknown
+		"snapshot_day":       state.DayNumber,
+		"phase":              string(state.Phase.Type),
+		"player_count":       len(state.Players),
+		"created_at":         state.CreatedAt.Unix(),
+		"updated_at":         state.UpdatedAt.Unix(),
 	}
 
 	err = rds.client.HMSet(rds.ctx, metaKey, metadata).Err()
@@ -116,45 +138,100 @@ func (rds *RedisDataStore) SaveSnapshot(gameID string, state *core.GameState) er
 	return nil
 }
 
-// LoadEvents loads events from Redis Stream after a specific sequence
+// LoadEvents loads the events with a sequence greater than afterSequence.
+// Reading starts after the latest snapshot when that is far enough, and the
+// stream is paged by entry ID, so games of any length are read in full.
 func (rds *RedisDataStore) LoadEvents(gameID string, afterSequence int) ([]core.Event, error) {
 	streamKey := fmt.Sprintf("game:%s:events", gameID)
 
-	// Determine start position
-	start := "0"
-	if afterSequence > 0 {
-		start = fmt.Sprintf("%d", afterSequence+1)
-	}
-
-	// Read from stream
-	streams, err := rds.client.XRead(rds.ctx, &redis.XReadArgs{
-		Streams: []string{streamKey, start},
-		Count:   1000, // Max events to read at once
-	}).Result()
+	var events []core.Event
+	start := "-"
+	position := 0
 
+	snapshotSequence, streamID, err := rds.snapshotPosition(gameID)
 	if err != nil {
-		if err == redis.Nil {
-			return []core.Event{}, nil // No events found
-		}
-		return nil, fmt.Errorf("failed to read events from stream: %w", err)
+		return nil, err
+	}
+	if streamID != "" && snapshotSequence <= afterSequence {
+		// Every entry up to the snapshot is at or before afterSequence
+		start = "(" + streamID
+		position = snapshotSequence
 	}
 
-	var events []core.Event
+	for {
+		messages, err := rds.streams.XRangeN(rds.ctx, streamKey, start, "+", eventPageSize).Result()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read events from stream: %w", err)
+		}
+
+		for _, message := range messages {
+			position++
 
-	for _, stream := range streams {
-		for _, message := range stream.Messages {
 			event, err := rds.parseEventFromMessage(message)
 			if err != nil {
 				log.Printf("Failed to parse event %s: %v", message.ID, err)
 				continue
 			}
-			events = append(events, event)
+
+			// Entries written before sequencing fall back to their stream position
+			if event.Sequence == 0 {
+				event.Sequence = position
+			}
+
+			if event.Sequence > afterSequence {
+				events = append(events, event)
+			}
 		}
+
+		if len(messages) < eventPageSize {
+			break
+		}
+
+		// Exclusive range: resume after the last entry of this page
+		start = "(" + messages[len(messages)-1].ID
 	}
 
 	return events, nil
 }
 
+// snapshotPosition returns the sequence and stream ID of the latest snapshot,
+// or an empty stream ID when there is none to seek to
+func (rds *RedisDataStore) snapshotPosition(gameID string) (int, string, error) {
+	metaKey := fmt.Sprintf("game:%s:meta", gameID)
+
+	values, err := rds.streams.HMGet(rds.ctx, metaKey, "snapshot_sequence", "snapshot_stream_id").Result()
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to read snapshot position: %w", err)
+	}
+
+	sequenceStr, _ := values[0].(string)
+	streamID, _ := values[1].(string)
+	sequence, err := strconv.Atoi(sequenceStr)
+	if err != nil || streamID == "" {
+		return 0, "", nil
+	}
+	return sequence, streamID, nil
+}
+
+// streamIDAt returns the stream ID of the event with the given sequence if
+// it is the newest entry in the stream, which it is right after appending
+func (rds *RedisDataStore) streamIDAt(gameID string, sequence int) (string, error) {
+	streamKey := fmt.Sprintf("game:%s:events", gameID)
+
+	messages, err := rds.streams.XRevRangeN(rds.ctx, streamKey, "+", "-", 1).Result()
+	if err != nil {
+		return "", fmt.Errorf("failed to read stream position: %w", err)
+	}
+	if len(messages) == 0 {
+		return "", nil
+	}
+
+	if sequenceStr, _ := messages[0].Values["sequence"].(string); sequenceStr != strconv.Itoa(sequence) {
+		return "", nil
+	}
+	return messages[0].ID, nil
+}
+
 // LoadSnapshot loads the latest game state snapshot
 func (rds *RedisDataStore) LoadSnapshot(gameID string) (*core.GameState, error) {
 	snapshotKey := fmt.Sprintf("game:%s:snapshot", gameID)
@@ -268,6 +345,26 @@ func (rds *RedisDataStore) parseEventFromMessage(message redis.XMessage) (core.E
 
 	playerID, _ := message.Values["player_id"].(string) // Optional field
 
+	// Optional for entries written before sequencing
+	sequence := 0
+	if sequenceStr, ok := message.Values["sequence"].(string); ok {
+		parsed, err := strconv.Atoi(sequenceStr)
+		if err != nil {
+			return event, fmt.Errorf("invalid sequence: %w", err)
+		}
+		sequence = parsed
+	}
+
+	// Entries written before payloads were versioned use schema version 0
+	schemaVersion := 0
+	if versionStr, ok := message.Values["schema_version"].(string); ok {
+		parsed, err := strconv.Atoi(versionStr)
+		if err != nil {
+			return event, fmt.Errorf("invalid schema_version: %w", err)
+		}
+		schemaVersion = parsed
+	}
+
 	timestampStr, ok := message.Values["timestamp"].(string)
 	if !ok {
 		return event, fmt.Errorf("missing timestamp")
@@ -284,16 +381,16 @@ func (rds *RedisDataStore) parseEventFromMessage(message redis.XMessage) (core.E
 		return event, fmt.Errorf("invalid timestamp: %w", err)
 	}
 
-	// Parse payload
-	var payload map[string]interface{}
-	err = json.Unmarshal([]byte(payloadStr), &payload)
+	// Parse payload, upgrading it to the current schema
+	payload, err := core.DecodeEventPayload(core.EventType(eventType), []byte(payloadStr), schemaVersion)
 	if err != nil {
-		return event, fmt.Errorf("invalid payload JSON: %w", err)
+		return event, fmt.Errorf("invalid payload: %w", err)
 	}
 
 	// Construct event
 	event = core.Event{
 		ID:        eventID,
+		Sequence:  sequence,
 		Type:      core.EventType(eventType),
 		GameID:    gameID,
 		PlayerID:  playerID,
@@ -0,0 +1,137 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// fakeStreams serves one game's event stream and metadata from memory and
+// counts the stream entries it hands out
+type fakeStreams struct {
+	entries []redis.XMessage
+	meta    map[string]string
+	read    int
+}
+
+func newFakeStreams(eventCount int) *fakeStreams {
+	streams := &fakeStreams{meta: make(map[string]string)}
+	for seq := 1; seq <= eventCount; seq++ {
+		streams.entries = append(streams.entries, redis.XMessage{
+			ID: fmt.Sprintf("1700000000000-%d", seq),
+			Values: map[string]interface{}{
+				"event_id":  fmt.Sprintf("event-%d", seq),
+				"sequence":  strconv.Itoa(seq),
+				"type":      "CHAT_MESSAGE",
+				"game_id":   "test-game",
+				"timestamp": "1700000000",
+				"payload":   "{}",
+			},
+		})
+	}
+	return streams
+}
+
+// XRangeN supports the "-" and exclusive "(id" starts LoadEvents uses
+func (f *fakeStreams) XRangeN(ctx context.Context, stream, start, stop string, count int64) *redis.XMessageSliceCmd {
+	from := 0
+	if strings.HasPrefix(start, "(") {
+		for i, entry := range f.entries {
+			if entry.ID == start[1:] {
+				from = i + 1
+			}
+		}
+	}
+	to := from + int(count)
+	if to > len(f.entries) {
+		to = len(f.entries)
+	}
+	f.read += to - from
+	return redis.NewXMessageSliceCmdResult(f.entries[from:to], nil)
+}
+
+func (f *fakeStreams) XRevRangeN(ctx context.Context, stream, start, stop string, count int64) *redis.XMessageSliceCmd {
+	if len(f.entries) == 0 {
+		return redis.NewXMessageSliceCmdResult(nil, nil)
+	}
+	f.read++
+	return redis.NewXMessageSliceCmdResult([]redis.XMessage{f.entries[len(f.entries)-1]}, nil)
+}
+
+func (f *fakeStreams) HMGet(ctx context.Context, key string, fields ...string) *redis.SliceCmd {
+	values := make([]interface{}, len(fields))
+	for i, field := range fields {
+		if value, exists := f.meta[field]; exists {
+			values[i] = value
+		}
+	}
+	return redis.NewSliceResult(values, nil)
+}
+
+// TestRedisDataStore_LoadEventsSeeksPastSnapshot tests that replay after a
+// snapshot reads only the entries that follow it
+func TestRedisDataStore_LoadEventsSeeksPastSnapshot(t *testing.T) {
+	streams := newFakeStreams(2500)
+	rds := &RedisDataStore{streams: streams, ctx: context.Background()}
+
+	streamID, err := rds.streamIDAt("test-game", 2500)
+	if err != nil || streamID != "1700000000000-2500" {
+		t.Fatalf("Expected the newest entry's stream ID, got %q (%v)", streamID, err)
+	}
+	if streamID, _ := rds.streamIDAt("test-game", 2499); streamID != "" {
+		t.Errorf("Expected no stream ID for an older sequence, got %q", streamID)
+	}
+
+	streams.meta["snapshot_sequence"] = "2400"
+	streams.meta["snapshot_stream_id"] = "1700000000000-2400"
+
+	tests := []struct {
+		name          string
+		afterSequence int
+		wantEvents    int
+		wantRead      int
+	}{
+		{"at the snapshot", 2400, 100, 100},
+		{"after the snapshot", 2450, 50, 100},
+		{"before the snapshot", 100, 2400, 2500},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streams.read = 0
+			events, err := rds.LoadEvents("test-game", tt.afterSequence)
+			if err != nil {
+				t.Fatalf("LoadEvents failed: %v", err)
+			}
+			if len(events) != tt.wantEvents {
+				t.Errorf("Expected %d events, got %d", tt.wantEvents, len(events))
+			}
+			if len(events) > 0 && events[0].Sequence != tt.afterSequence+1 {
+				t.Errorf("Expected the first event to be %d, got %d", tt.afterSequence+1, events[0].Sequence)
+			}
+			if streams.read != tt.wantRead {
+				t.Errorf("Expected %d entries read, got %d", tt.wantRead, streams.read)
+			}
+		})
+	}
+}
+
+// TestRedisDataStore_LoadEventsWithoutStreamID tests that metadata without a
+// stream position falls back to reading the whole stream
+func TestRedisDataStore_LoadEventsWithoutStreamID(t *testing.T) {
+	streams := newFakeStreams(30)
+	streams.meta["snapshot_sequence"] = "20"
+	rds := &RedisDataStore{streams: streams, ctx: context.Background()}
+
+	events, err := rds.LoadEvents("test-game", 20)
+	if err != nil {
+		t.Fatalf("LoadEvents failed: %v", err)
+	}
+	if len(events) != 10 || streams.read != 30 {
+		t.Errorf("Expected 10 events from a full read of 30, got %d from %d", len(events), streams.read)
+	}
+}